@@ -3,7 +3,7 @@ set startingDir="%CD%"
 set basepath="%~dp0"
 
 cd %basepath%\..\Source
-set Sources=actutils.go automaticcomponenttoolkit.go buildbindingccpp.go buildbindingcsharp.go buildbindinggo.go buildbindingnode.go buildbindingpascal.go buildbindingpython.go buildimplementationcpp.go buildimplementationpascal.go componentdefinition.go componentdiff.go languagewriter.go languagec.go languagecpp.go languagepascal.go
+set Sources=actutils.go automaticcomponenttoolkit.go buildbindingccpp.go buildbindingcsharp.go buildbindinggo.go buildbindingjava.go buildbindingnode.go buildbindingpascal.go buildbindingpython.go buildbindingrust.go builddocumentation.go buildimplementationcpp.go buildimplementationpascal.go buildreplaycpp.go componentdefinition.go componentdiff.go componentformats.go componentdiffreport.go componentvalidation.go languagewriter.go outputfiles.go stubupdate.go languagec.go languagecpp.go languagepascal.go
 set GOARCH=amd64
 
 set GOOS=windows
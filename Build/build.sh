@@ -4,7 +4,7 @@ startingpath="$(pwd)"
 basepath="$(cd "$(dirname "$0")" && pwd)"
 cd "$basepath/../Source"
 
-Sources="actutils.go automaticcomponenttoolkit.go buildbindingccpp.go buildbindingcsharp.go buildbindinggo.go buildbindingnode.go buildbindingpascal.go buildbindingpython.go buildimplementationcpp.go buildimplementationpascal.go componentdefinition.go componentdiff.go languagewriter.go languagec.go languagecpp.go languagepascal.go"
+Sources="actutils.go automaticcomponenttoolkit.go buildbindingccpp.go buildbindingcsharp.go buildbindinggo.go buildbindingjava.go buildbindingnode.go buildbindingpascal.go buildbindingpython.go buildbindingrust.go builddocumentation.go buildimplementationcpp.go buildimplementationpascal.go buildreplaycpp.go componentdefinition.go componentdiff.go componentformats.go componentdiffreport.go componentvalidation.go languagewriter.go outputfiles.go stubupdate.go languagec.go languagecpp.go languagepascal.go"
 GOARCH="amd64"
 
 echo "Build act.exe"
@@ -0,0 +1,52 @@
+#!/bin/bash
+
+# Generates the API reference of Examples/Documentation and checks that it documents the declarations of
+# deprecated methods together with their attributes. Usage: checkdocumentation.sh [ACT_BINARY]
+# Without ACT_BINARY, act is built from the sources first.
+
+basepath="$(cd "$(dirname "$0")" && pwd)"
+examplepath="$basepath/../Examples/Documentation"
+
+act="$1"
+if [ -z "$act" ]; then
+	act="$(mktemp -d)/act"
+	echo "Build $act"
+	(cd "$basepath/../Source" && go build -o "$act" *.go) || exit 1
+fi
+
+outputpath="$(mktemp -d)"
+"$act" generate -o "$outputpath" "$examplepath/libDocumented.xml" >/dev/null 2>&1 || {
+	echo "FAILED generating the documentation of libDocumented.xml"
+	exit 1
+}
+markdown="$outputpath/LibDocumented_component/Documentation/libdocumented.md"
+html="$outputpath/LibDocumented_component/Documentation/libdocumented.html"
+
+failures=0
+
+# check DESCRIPTION DECLARATION
+check() {
+	escaped="$(echo "$2" | sed 's/"/\&#34;/g')"
+	if ! grep -qxF "$2" "$markdown"; then
+		echo "FAILED $1: \"$2\" is missing in the Markdown reference"
+		failures=$((failures + 1))
+	elif ! grep -qF "$escaped" "$html"; then
+		echo "FAILED $1: \"$2\" is missing in the HTML reference"
+		failures=$((failures + 1))
+	else
+		echo "OK     $1"
+	fi
+}
+
+check "method"                   'LibDocumented_double Run();'
+check "deprecated method"        '[[deprecated("Calculator.Configure is deprecated since 1.1.0, use Calculator.Run instead")]] void Configure(const LibDocumented_uint32 nPrecision);'
+check "deprecated static method" '[[deprecated("Calculator.CreateDefault is deprecated since 1.2.0")]] static PCalculator CreateDefault(CWrapper * pWrapper);'
+check "deprecated global method" '[[deprecated("Wrapper.CreateCalculator is deprecated since 1.2.0, use Calculator.CreateDefault instead")]] PCalculator CreateCalculator();'
+
+rm -rf "$outputpath"
+
+if [ $failures -gt 0 ]; then
+	echo "$failures documentation check(s) failed"
+	exit 1
+fi
+echo "All documentation checks passed"
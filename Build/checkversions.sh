@@ -0,0 +1,51 @@
+#!/bin/bash
+
+# Diffs the consecutive versions in Examples/Version and checks the classification of their changes
+# and whether their version bump suffices. Usage: checkversions.sh [ACT_BINARY]
+# Without ACT_BINARY, act is built from the sources first.
+
+basepath="$(cd "$(dirname "$0")" && pwd)"
+versionpath="$basepath/../Examples/Version"
+
+act="$1"
+if [ -z "$act" ]; then
+	act="$(mktemp -d)/act"
+	echo "Build $act"
+	(cd "$basepath/../Source" && go build -o "$act" *.go) || exit 1
+fi
+
+failures=0
+
+# check OLD_FILE NEW_FILE EXPECTED_COUNTS sufficient|insufficient
+check() {
+	report="$("$act" diff --format text "$versionpath/$1" "$versionpath/$2" 2>/dev/null)"
+	counts="$(echo "$report" | head -n 1 | sed 's/^.*: //')"
+	bump="sufficient"
+	if echo "$report" | grep -q "NOT sufficient"; then
+		bump="insufficient"
+	fi
+	if [ "$counts" != "$3" ] || [ "$bump" != "$4" ]; then
+		echo "FAILED $1 -> $2: expected \"$3\" ($4), got \"$counts\" ($bump)"
+		failures=$((failures + 1))
+	else
+		echo "OK     $1 -> $2: $counts ($bump)"
+	fi
+}
+
+check libVersion.xml       libVersion.1.1.0.xml "0 breaking, 1 additive, 0 cosmetic" sufficient
+check libVersion.1.1.0.xml libVersion.1.2.0.xml "0 breaking, 1 additive, 0 cosmetic" sufficient
+check libVersion.1.2.0.xml libVersion.1.3.0.xml "2 breaking, 0 additive, 0 cosmetic" insufficient
+check libVersion.1.3.0.xml libVersion.2.0.0.xml "3 breaking, 0 additive, 0 cosmetic" sufficient
+check libVersion.2.0.0.xml libVersion.3.0.0.xml "4 breaking, 1 additive, 2 cosmetic" sufficient
+check libVersion.3.0.0.xml libVersion.3.1.0.xml "0 breaking, 2 additive, 0 cosmetic" sufficient
+check libVersion.3.1.0.xml libVersion.4.0.0.xml "2 breaking, 0 additive, 1 cosmetic" sufficient
+check libVersion.4.0.0.xml libVersion.4.1.0.xml "0 breaking, 3 additive, 1 cosmetic" sufficient
+check libVersion.4.1.0.xml libVersion.4.2.0.xml "0 breaking, 1 additive, 0 cosmetic" sufficient
+check libVersion.4.2.0.xml libVersion.4.2.0.xml "0 breaking, 0 additive, 0 cosmetic" sufficient
+check libVersion.4.2.0.xml libVersion.4.1.0.xml "1 breaking, 0 additive, 0 cosmetic" insufficient
+
+if [ $failures -gt 0 ]; then
+	echo "$failures version check(s) failed"
+	exit 1
+fi
+echo "All version checks passed"
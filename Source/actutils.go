@@ -34,11 +34,184 @@ SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package main
 
 import (
+	"fmt"
 	"os"
+	"strings"
 )
 
 // FileExists returns true if and only if the file in a given path exists
 func FileExists(path string) bool {
+	if (generatedFilesInMemory != nil) && generatedFilesInMemory.exists(path) {
+		return true
+	}
 	_, err := os.Stat(path)
-	return !os.IsNotExist(err)
+	exists := !os.IsNotExist(err)
+	if exists && (generatedFilesInMemory != nil) {
+		// Files that are only generated if they do not exist yet remain part of the output
+		generatedFilesInMemory.keep(path)
+	} else if exists {
+		recordOutputFile(path)
+	}
+	return exists
+}
+
+// splitLines splits a text into lines, keeping the line endings
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffOperation is a single line of an edit script
+type diffOperation struct {
+	Kind byte // ' ', '-' or '+'
+	Line string
+}
+
+// diffLines computes a shortest edit script between two lists of lines (Myers' algorithm)
+func diffLines(a []string, b []string) []diffOperation {
+	n := len(a)
+	m := len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	trace := make([][]int, 0)
+
+	found := false
+	for d := 0; (d <= max) && !found; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if (k == -d) || ((k != d) && (v[offset+k-1] < v[offset+k+1])) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for (x < n) && (y < m) && (a[x] == b[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if (x >= n) && (y >= m) {
+				found = true
+				break
+			}
+		}
+	}
+
+	operations := make([]diffOperation, 0, n+m)
+	x := n
+	y := m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if (k == -d) || ((k != d) && (v[offset+k-1] < v[offset+k+1])) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for (x > prevX) && (y > prevY) {
+			x--
+			y--
+			operations = append(operations, diffOperation{' ', a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				operations = append(operations, diffOperation{'+', b[y]})
+			} else {
+				x--
+				operations = append(operations, diffOperation{'-', a[x]})
+			}
+		}
+	}
+
+	for i, j := 0, len(operations)-1; i < j; i, j = i+1, j-1 {
+		operations[i], operations[j] = operations[j], operations[i]
+	}
+	return operations
+}
+
+// UnifiedDiff returns the differences between two texts in unified diff format
+func UnifiedDiff(oldName string, newName string, oldText string, newText string, contextLines int) string {
+	operations := diffLines(splitLines(oldText), splitLines(newText))
+
+	var result strings.Builder
+	fmt.Fprintf(&result, "--- %s\n", oldName)
+	fmt.Fprintf(&result, "+++ %s\n", newName)
+
+	i := 0
+	for i < len(operations) {
+		if operations[i].Kind == ' ' {
+			i++
+			continue
+		}
+
+		// extend the hunk until the next change is further away than twice the context
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(operations); j++ {
+			if operations[j].Kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*contextLines {
+				break
+			}
+		}
+		end += contextLines
+		if end > len(operations) {
+			end = len(operations)
+		}
+
+		oldStart := 0
+		newStart := 0
+		for _, operation := range operations[:start] {
+			if operation.Kind != '+' {
+				oldStart++
+			}
+			if operation.Kind != '-' {
+				newStart++
+			}
+		}
+		oldCount := 0
+		newCount := 0
+		for _, operation := range operations[start:end] {
+			if operation.Kind != '+' {
+				oldCount++
+			}
+			if operation.Kind != '-' {
+				newCount++
+			}
+		}
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+
+		fmt.Fprintf(&result, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, operation := range operations[start:end] {
+			result.WriteByte(operation.Kind)
+			result.WriteString(operation.Line)
+			if !strings.HasSuffix(operation.Line, "\n") {
+				result.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return result.String()
 }
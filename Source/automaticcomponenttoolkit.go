@@ -34,16 +34,24 @@ SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package main
 
 import (
-	"encoding/xml"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path"
+	"strings"
 )
 
+// ACTVersion is the version of this toolkit
+const ACTVersion = "1.6.0"
+
+// Exit codes of the act command line tool
 const (
-	eACTModeGenerate = 0
-	eACTModeDiff     = 1
+	eACTExitSuccess = 0
+	eACTExitFailure = 1
+	eACTExitUsage   = 2
+	eACTExitDrift   = 3
 )
 
 func createComponent(component ComponentDefinition, outfolderBase string) error {
@@ -60,8 +68,9 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 	outputFolderBindings := path.Join(outputFolder, "Bindings")
 	outputFolderExamples := path.Join(outputFolder, "Examples")
 	outputFolderImplementations := path.Join(outputFolder, "Implementations")
+	outputFolderDocumentation := path.Join(outputFolder, "Documentation")
 
-	err := os.MkdirAll(outputFolder, os.ModePerm)
+	err := CreateOutputFolder(outputFolder)
 	if err != nil {
 		return err
 	}
@@ -74,8 +83,17 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 	}
 	licenseFile.WritePlainLicenseHeader(component, "", false)
 
+	err = CreateOutputFolder(outputFolderDocumentation)
+	if err != nil {
+		return err
+	}
+	err = BuildDocumentation(component, outputFolderDocumentation)
+	if err != nil {
+		return err
+	}
+
 	if len(component.BindingList.Bindings) > 0 {
-		err = os.MkdirAll(outputFolderBindings, os.ModePerm)
+		err = CreateOutputFolder(outputFolderBindings)
 		if err != nil {
 			return err
 		}
@@ -90,7 +108,7 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 			{
 				outputFolderBindingC := outputFolderBindings + "/C"
 
-				err = os.MkdirAll(outputFolderBindingC, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingC)
 				if err != nil {
 					return err
 				}
@@ -104,12 +122,12 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 		case "CDynamic":
 			{
 				outputFolderBindingCDynamic := outputFolderBindings + "/CDynamic"
-				err = os.MkdirAll(outputFolderBindingCDynamic, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingCDynamic)
 				if err != nil {
 					return err
 				}
 				outputFolderExampleCDynamic := outputFolderExamples + "/CDynamic"
-				err = os.MkdirAll(outputFolderExampleCDynamic, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExampleCDynamic)
 				if err != nil {
 					return err
 				}
@@ -129,12 +147,12 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 		case "CppDynamic":
 			{
 				outputFolderBindingCppDynamic := outputFolderBindings + "/CppDynamic"
-				err = os.MkdirAll(outputFolderBindingCppDynamic, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingCppDynamic)
 				if err != nil {
 					return err
 				}
 				outputFolderExampleCppDynamic := outputFolderExamples + "/CppDynamic"
-				err = os.MkdirAll(outputFolderExampleCppDynamic, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExampleCppDynamic)
 				if err != nil {
 					return err
 				}
@@ -161,12 +179,12 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 		case "Cpp":
 			{
 				outputFolderBindingCppImplicit := outputFolderBindings + "/Cpp"
-				err = os.MkdirAll(outputFolderBindingCppImplicit, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingCppImplicit)
 				if err != nil {
 					return err
 				}
 				outputFolderExampleCppImplicit := outputFolderExamples + "/Cpp"
-				err = os.MkdirAll(outputFolderExampleCppImplicit, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExampleCppImplicit)
 				if err != nil {
 					return err
 				}
@@ -193,13 +211,13 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 		case "Go":
 			{
 				outputFolderBindingGo := outputFolderBindings + "/Go"
-				err = os.MkdirAll(outputFolderBindingGo, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingGo)
 				if err != nil {
 					return err
 				}
 
 				outputFolderExampleGo := outputFolderExamples + "/Go"
-				err = os.MkdirAll(outputFolderExampleGo, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExampleGo)
 				if err != nil {
 					return err
 				}
@@ -214,7 +232,7 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 			{
 				outputFolderBindingNode := outputFolderBindings + "/NodeJS"
 
-				err = os.MkdirAll(outputFolderBindingNode, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingNode)
 				if err != nil {
 					return err
 				}
@@ -239,13 +257,13 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 		case "Pascal":
 			{
 				outputFolderBindingPascal := outputFolderBindings + "/Pascal"
-				err = os.MkdirAll(outputFolderBindingPascal, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingPascal)
 				if err != nil {
 					return err
 				}
 
 				outputFolderExamplePascal := outputFolderExamples + "/Pascal"
-				err = os.MkdirAll(outputFolderExamplePascal, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExamplePascal)
 				if err != nil {
 					return err
 				}
@@ -259,32 +277,32 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 		case "CSharp":
 			{
 				outputFolderBindingCSharp := outputFolderBindings + "/CSharp"
-				err = os.MkdirAll(outputFolderBindingCSharp, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingCSharp)
 				if err != nil {
-					log.Fatal(err)
+					return err
 				}
 
 				outputFolderExampleCSharp := outputFolderExamples + "/CSharp"
-				err = os.MkdirAll(outputFolderExampleCSharp, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExampleCSharp)
 				if err != nil {
-					log.Fatal(err)
+					return err
 				}
 
 				err = BuildBindingCSharp(component, outputFolderBindingCSharp, outputFolderExampleCSharp, indentString)
 				if err != nil {
-					log.Fatal(err)
+					return err
 				}
 			}
 		case "Python":
 			{
 				outputFolderBindingPython := outputFolderBindings + "/Python"
-				err = os.MkdirAll(outputFolderBindingPython, os.ModePerm)
+				err = CreateOutputFolder(outputFolderBindingPython)
 				if err != nil {
 					return err
 				}
 
 				outputFolderExamplePython := outputFolderExamples + "/Python"
-				err = os.MkdirAll(outputFolderExamplePython, os.ModePerm)
+				err = CreateOutputFolder(outputFolderExamplePython)
 				if err != nil {
 					return err
 				}
@@ -295,18 +313,58 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 				}
 			}
 
+		case "Rust":
+			{
+				outputFolderBindingRust := outputFolderBindings + "/Rust"
+				err = CreateOutputFolder(outputFolderBindingRust)
+				if err != nil {
+					return err
+				}
+
+				outputFolderExampleRust := outputFolderExamples + "/Rust"
+				err = CreateOutputFolder(outputFolderExampleRust)
+				if err != nil {
+					return err
+				}
+
+				err = BuildBindingRust(component, outputFolderBindingRust, outputFolderExampleRust, indentString)
+				if err != nil {
+					return err
+				}
+			}
+
+		case "Java":
+			{
+				outputFolderBindingJava := outputFolderBindings + "/Java"
+				err = CreateOutputFolder(outputFolderBindingJava)
+				if err != nil {
+					return err
+				}
+
+				outputFolderExampleJava := outputFolderExamples + "/Java"
+				err = CreateOutputFolder(outputFolderExampleJava)
+				if err != nil {
+					return err
+				}
+
+				err = BuildBindingJava(component, outputFolderBindingJava, outputFolderExampleJava, indentString)
+				if err != nil {
+					return err
+				}
+			}
+
 		case "Fortran":
 			{
 				log.Printf("Interface binding for language \"%s\" is not yet supported.", binding.Language)
 			}
 
 		default:
-			log.Fatal("Unknown binding export")
+			return fmt.Errorf("unknown binding export \"%s\"", binding.Language)
 		}
 	}
 
 	if len(component.ImplementationList.Implementations) > 0 {
-		err = os.MkdirAll(outputFolderImplementations, os.ModePerm)
+		err = CreateOutputFolder(outputFolderImplementations)
 		if err != nil {
 			return err
 		}
@@ -322,12 +380,12 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 				outputFolderImplementationCpp := outputFolderImplementations + "/Cpp/Interfaces"
 				outputFolderImplementationCppStub := outputFolderImplementations + "/Cpp/Stub"
 
-				err = os.MkdirAll(outputFolderImplementationCpp, os.ModePerm)
+				err = CreateOutputFolder(outputFolderImplementationCpp)
 				if err != nil {
 					return err
 				}
 
-				err = os.MkdirAll(outputFolderImplementationCppStub, os.ModePerm)
+				err = CreateOutputFolder(outputFolderImplementationCppStub)
 				if err != nil {
 					return err
 				}
@@ -357,12 +415,12 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 				outputFolderImplementationPascal := outputFolderImplementations + "/Pascal/Interfaces"
 				outputFolderImplementationPascalStub := outputFolderImplementations + "/Pascal/Stub"
 
-				err = os.MkdirAll(outputFolderImplementationPascal, os.ModePerm)
+				err = CreateOutputFolder(outputFolderImplementationPascal)
 				if err != nil {
 					return err
 				}
 
-				err = os.MkdirAll(outputFolderImplementationPascalStub, os.ModePerm)
+				err = CreateOutputFolder(outputFolderImplementationPascalStub)
 				if err != nil {
 					return err
 				}
@@ -379,110 +437,464 @@ func createComponent(component ComponentDefinition, outfolderBase string) error
 				log.Printf("Implementation in language \"%s\" is not yet supported.", implementation.Language)
 			}
 		default:
-			log.Fatal("Unknown export")
+			return fmt.Errorf("unknown implementation export \"%s\"", implementation.Language)
 		}
 	}
 
 	return nil
 }
 
-func main() {
-	ACTVersion := "1.6.0"
-	fmt.Fprintln(os.Stdout, "Automatic Component Toolkit v"+ACTVersion)
-	if len(os.Args) < 2 {
-		log.Fatal("Please run with the Interface Description XML as command line parameter.")
-		log.Fatal("To specify a path for the generated source code use the optional flag \"-o ABSOLUTE_PATH_TO_OUTPUT_FOLDER\"")
-		log.Fatal("To create a diff between two versions of an Interface Description XML use the optional flag \"-d OTHER_IDL_FILE\"")
+// actCommand describes a subcommand of the act command line tool
+type actCommand struct {
+	Name     string
+	Synopsis string
+	Run      func(args []string) int
+}
+
+func actCommands() []actCommand {
+	return []actCommand{
+		{"generate", "generate bindings and implementation stubs from an IDL file", runGenerateCommand},
+		{"check", "validate an IDL file without generating any code", runCheckCommand},
+		{"diff", "compute the difference between two IDL files", runDiffCommand},
+		{"convert", "translate an IDL file between XML, JSON and YAML", runConvertCommand},
+		{"replay", "generate a C++ program that replays a journal of the library", runReplayCommand},
+		{"version", "print the version of ACT", runVersionCommand},
+		{"help", "print help for a command", runHelpCommand},
 	}
-	if os.Args[1] == "-v" {
-		fmt.Fprintln(os.Stdout, "Version: "+ACTVersion)
-		return
+}
+
+func findACTCommand(name string) (actCommand, bool) {
+	for _, command := range actCommands() {
+		if command.Name == name {
+			return command, true
+		}
 	}
-	log.Printf("---------------------------------------\n")
+	return actCommand{}, false
+}
 
-	mode := eACTModeGenerate
-	outfolderBase, err := os.Getwd()
-	if err != nil {
-		log.Fatal(err)
+func printUsage() {
+	w := os.Stderr
+	fmt.Fprintln(w, "Usage: act <command> [flags] [arguments]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	for _, command := range actCommands() {
+		fmt.Fprintf(w, "  %-10s %s\n", command.Name, command.Synopsis)
 	}
-	diffFile := ""
-	if len(os.Args) >= 4 {
-		if os.Args[2] == "-o" {
-			outfolderBase = os.Args[3]
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Run \"act <command> --help\" for the flags of a command.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Exit codes:")
+	fmt.Fprintf(w, "  %d  success\n", eACTExitSuccess)
+	fmt.Fprintf(w, "  %d  the IDL file is invalid or the command failed\n", eACTExitFailure)
+	fmt.Fprintf(w, "  %d  invalid command line\n", eACTExitUsage)
+	fmt.Fprintf(w, "  %d  generate --verify: the generated code is out of date\n", eACTExitDrift)
+}
+
+// newCommandFlagSet creates the flag set of a subcommand with a uniform usage message
+func newCommandFlagSet(name string, arguments string, description string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: act %s [flags] %s\n\n", name, arguments)
+		fmt.Fprintf(os.Stderr, "%s\n", description)
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintf(os.Stderr, "\nFlags:\n")
+			flags.PrintDefaults()
 		}
+	}
+	return flags
+}
 
-		if os.Args[2] == "-d" {
-			diffFile = os.Args[3]
-			mode = eACTModeDiff
+// parseCommandFlags parses flags and positional arguments in any order.
+// It returns the positional arguments and the exit code to use if the command must not continue.
+func parseCommandFlags(flags *flag.FlagSet, args []string, numPositional int) ([]string, int, bool) {
+	positional := make([]string, 0)
+	for {
+		err := flags.Parse(args)
+		if err == flag.ErrHelp {
+			return nil, eACTExitSuccess, false
+		}
+		if err != nil {
+			return nil, eACTExitUsage, false
 		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
 	}
-	if mode == eACTModeGenerate {
-		log.Printf("Output directory: " + outfolderBase)
+	if len(positional) != numPositional {
+		fmt.Fprintf(os.Stderr, "act %s: expected %d argument(s), got %d\n", flags.Name(), numPositional, len(positional))
+		flags.Usage()
+		return nil, eACTExitUsage, false
 	}
+	return positional, eACTExitSuccess, true
+}
 
-	log.Printf("Loading Component Description File")
-	component, err := ReadComponentDefinition(os.Args[1], ACTVersion)
+// loadComponent reads a component definition file and checks it
+func loadComponent(fileName string) (ComponentDefinition, error) {
+	log.Printf("Loading Component Description File \"%s\"", fileName)
+	component, err := ReadComponentDefinition(fileName, ACTVersion)
 	if err != nil {
-		log.Fatal(err)
+		return component, err
 	}
 
 	log.Printf("Checking Component Description")
-	err = component.CheckComponentDefinition()
+	validation := component.ValidateComponentDefinition()
+	for _, diagnostic := range validation.Diagnostics {
+		log.Println(diagnostic.Error())
+	}
+	if validation.ErrorCount() > 0 {
+		return component, fmt.Errorf("component description \"%s\" contains %d error(s)", fileName, validation.ErrorCount())
+	}
+	return component, nil
+}
+
+func runGenerateCommand(args []string) int {
+	flags := newCommandFlagSet("generate", "IDL_FILE",
+		"Generates the language bindings and implementation stubs defined in IDL_FILE.\n"+
+			"With --verify, nothing is written. Instead, the generated Bindings, Implementations and Documentation are compared\n"+
+			"with the existing ones and a unified diff of every file that would change or would not be generated anymore is printed.\n"+
+			"Files that are not generated anymore are taken from the act_manifest.txt written by the previous generation.\n"+
+			"With --update, existing C++ and Pascal implementation stubs are updated to the component definition.\n"+
+			"The code in their protected regions is kept, regions of removed methods are disabled.")
+	outfolderBase := ""
+	verify := false
+	update := false
+	flags.StringVar(&outfolderBase, "o", "", "output `folder` for the generated source code (default: current directory)")
+	flags.StringVar(&outfolderBase, "output", "", "output `folder` for the generated source code (alias for -o)")
+	flags.BoolVar(&verify, "verify", false, "check that the existing generated code is up to date instead of writing it")
+	flags.BoolVar(&update, "update", false, "update existing implementation stubs, keeping the code in their protected regions")
+	positional, exitCode, ok := parseCommandFlags(flags, args, 1)
+	if !ok {
+		return exitCode
+	}
+
+	if outfolderBase == "" {
+		workingDirectory, err := os.Getwd()
+		if err != nil {
+			log.Println(err)
+			return eACTExitFailure
+		}
+		outfolderBase = workingDirectory
+	}
+	log.Printf("Output directory: " + outfolderBase)
+
+	component, err := loadComponent(positional[0])
 	if err != nil {
-		log.Fatal(err)
+		log.Println(err)
+		return eACTExitFailure
 	}
 
-	if mode == eACTModeDiff {
-		log.Printf("Loading Component Description File to compare to")
-		componentB, err := ReadComponentDefinition(diffFile, ACTVersion)
+	updateImplementationStubs = update
+
+	if verify {
+		differences, err := verifyComponent(component, outfolderBase, os.Stdout)
 		if err != nil {
-			log.Fatal(err)
+			log.Println("Fatal error")
+			log.Println(err)
+			return eACTExitFailure
+		}
+		if differences > 0 {
+			log.Printf("%d generated file(s) are out of date", differences)
+			return eACTExitDrift
+		}
+		log.Println("Generated code is up to date")
+		return eACTExitSuccess
+	}
+
+	err = writeComponent(component, outfolderBase)
+	if err != nil {
+		log.Println("Fatal error")
+		log.Println(err)
+		return eACTExitFailure
+	}
+	log.Println("Success")
+	return eACTExitSuccess
+}
+
+func runCheckCommand(args []string) int {
+	flags := newCommandFlagSet("check", "IDL_FILE",
+		"Validates IDL_FILE and all components it imports and reports all errors and warnings\nwith their file, line and column.")
+	format := "text"
+	flags.StringVar(&format, "format", "text", "output `format` of the report: text or json")
+	positional, exitCode, ok := parseCommandFlags(flags, args, 1)
+	if !ok {
+		return exitCode
+	}
+	if (format != "text") && (format != "json") {
+		fmt.Fprintf(os.Stderr, "act check: invalid format \"%s\"\n", format)
+		flags.Usage()
+		return eACTExitUsage
+	}
+
+	var validation ComponentValidation
+	component, err := ReadComponentDefinition(positional[0], ACTVersion)
+	if err != nil {
+		diagnostic, ok := err.(ComponentDiagnostic)
+		if !ok {
+			diagnostic = ComponentDiagnostic{Severity: eDiagnosticSeverityError, FileName: positional[0], Message: err.Error()}
 		}
-		log.Printf("Checking Component Description B")
-		err = componentB.CheckComponentDefinition()
+		validation.Diagnostics = append(validation.Diagnostics, diagnostic)
+	} else {
+		validation = component.ValidateComponentDefinition()
+	}
+
+	if format == "json" {
+		err = validation.WriteJSON(os.Stdout)
 		if err != nil {
-			log.Fatal(err)
+			log.Println(err)
+			return eACTExitFailure
 		}
-		diff, err := DiffComponentDefinitions(component, componentB)
+	} else {
+		validation.WriteText(os.Stdout)
+	}
+
+	if validation.ErrorCount() > 0 {
+		return eACTExitFailure
+	}
+	return eACTExitSuccess
+}
+
+func runDiffCommand(args []string) int {
+	flags := newCommandFlagSet("diff", "IDL_FILE_A IDL_FILE_B",
+		"Computes the difference D = B - A between two IDL files, classifies every change as breaking,\n"+
+			"additive or cosmetic and checks whether the version bump between A and B is sufficient.")
+	format := "xml"
+	outputFileName := ""
+	flags.StringVar(&format, "format", "xml", "output `format` of the diff: xml, json, markdown or text")
+	flags.StringVar(&outputFileName, "output", "", "write the diff to `file` instead of the standard output")
+	positional, exitCode, ok := parseCommandFlags(flags, args, 2)
+	if !ok {
+		return exitCode
+	}
+	var writeDiff func(diff ComponentDiff, w io.Writer) error
+	switch format {
+	case "xml":
+		writeDiff = ComponentDiff.WriteXML
+	case "json":
+		writeDiff = ComponentDiff.WriteJSON
+	case "markdown":
+		writeDiff = ComponentDiff.WriteMarkdown
+	case "text":
+		writeDiff = ComponentDiff.WriteText
+	default:
+		fmt.Fprintf(os.Stderr, "act diff: invalid format \"%s\"\n", format)
+		flags.Usage()
+		return eACTExitUsage
+	}
+
+	componentA, err := loadComponent(positional[0])
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+	componentB, err := loadComponent(positional[1])
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+
+	diff, err := DiffComponentDefinitions(componentA, componentB)
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+	log.Printf("Changes: %d breaking, %d additive, %d cosmetic",
+		diff.CountClassification(eDiffClassificationBreaking),
+		diff.CountClassification(eDiffClassificationAdditive),
+		diff.CountClassification(eDiffClassificationCosmetic))
+	check := diff.VersionCheck
+	if check.Sufficient {
+		log.Printf("Version bump from %s to %s (%s) is sufficient for %s changes", check.OldVersion, check.NewVersion, check.ActualBump, check.Classification)
+	} else {
+		log.Printf("Version bump from %s to %s (%s) is NOT sufficient for %s changes: a %s bump is required", check.OldVersion, check.NewVersion, check.ActualBump, check.Classification, check.RequiredBump)
+	}
+
+	if outputFileName == "" {
+		err = writeDiff(diff, os.Stdout)
+	} else {
+		var outputFile *os.File
+		outputFile, err = os.Create(outputFileName)
 		if err != nil {
-			log.Fatal(err)
+			log.Println(err)
+			return eACTExitFailure
 		}
+		err = writeDiff(diff, outputFile)
+		closeErr := outputFile.Close()
+		if err == nil {
+			err = closeErr
+		}
+		log.Printf("Diff written to \"%s\"", outputFileName)
+	}
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+	return eACTExitSuccess
+}
 
-		output, err := xml.MarshalIndent(diff, "", "\t")
+func runConvertCommand(args []string) int {
+	flags := newCommandFlagSet("convert", "IDL_FILE",
+		"Translates an IDL file between the XML, JSON and YAML formats. The format of IDL_FILE is\n"+
+			"derived from its extension. Imports are not followed, their references are kept as they are.")
+	format := ""
+	outputFileName := ""
+	flags.StringVar(&format, "format", "", "output `format`: xml, json or yaml (default: derived from the extension of --output)")
+	flags.StringVar(&outputFileName, "output", "", "write the converted IDL to `file` instead of the standard output")
+	positional, exitCode, ok := parseCommandFlags(flags, args, 1)
+	if !ok {
+		return exitCode
+	}
+	if format == "" && outputFileName != "" {
+		format = idlFormatOfFile(outputFileName)
+	}
+	if format != eIDLFormatXML && format != eIDLFormatJSON && format != eIDLFormatYAML {
+		if format == "" {
+			fmt.Fprintf(os.Stderr, "act convert: either --format or --output is required\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "act convert: invalid format \"%s\"\n", format)
+		}
+		flags.Usage()
+		return eACTExitUsage
+	}
+
+	var component ComponentDefinition
+	err := component.readDocument(positional[0])
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+
+	if outputFileName == "" {
+		err = WriteComponentDefinition(component, format, os.Stdout)
+	} else {
+		var outputFile *os.File
+		outputFile, err = os.Create(outputFileName)
 		if err != nil {
-			log.Fatal(err)
+			log.Println(err)
+			return eACTExitFailure
 		}
+		err = WriteComponentDefinition(component, format, outputFile)
+		closeErr := outputFile.Close()
+		if err == nil {
+			err = closeErr
+		}
+		log.Printf("Converted IDL written to \"%s\"", outputFileName)
+	}
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+	return eACTExitSuccess
+}
+
+func runReplayCommand(args []string) int {
+	flags := newCommandFlagSet("replay", "IDL_FILE",
+		"Generates a C++ program that replays a journal written by the C++ implementation of IDL_FILE.\n"+
+			"The program re-issues the recorded calls against the library, remaps the recorded handles\n"+
+			"and reports the first call whose error code or results diverge from the journal.")
+	outfolderBase := ""
+	flags.StringVar(&outfolderBase, "o", "", "output `folder` for the generated source code (default: current directory)")
+	flags.StringVar(&outfolderBase, "output", "", "output `folder` for the generated source code (alias for -o)")
+	positional, exitCode, ok := parseCommandFlags(flags, args, 1)
+	if !ok {
+		return exitCode
+	}
 
-		writer, err := os.Create("diff.xml")
+	if outfolderBase == "" {
+		workingDirectory, err := os.Getwd()
 		if err != nil {
-			log.Fatal(err)
+			log.Println(err)
+			return eACTExitFailure
 		}
-		os.Stdout.Write(output)
-		writer.Write(output)
-
-		return
-	}
-
-	// This needs to go into a "preparation function"
-	// baseClass, err := setupBaseClassDefinition(true)
-	// if (err != nil) {
-	// 	log.Fatal (err);
-	// }
-	// component.Classes = append([]ComponentDefinitionClass{baseClass}, component.Classes...)
-	// for i := 0; i < len(component.Classes); i++ {
-	// 	if (!component.Classes[i].isBaseClass()) {
-	// 		if (component.Classes[i].ParentClass == "") {
-	// 			component.Classes[i].ParentClass = "BaseClass";
-	// 		}
-	// 	}
-	// }
-
-	err = createComponent(component, outfolderBase)
+		outfolderBase = workingDirectory
+	}
+
+	component, err := loadComponent(positional[0])
+	if err != nil {
+		log.Println(err)
+		return eACTExitFailure
+	}
+
+	outputFolder := path.Join(outfolderBase, component.NameSpace+"_component", "Replay", "Cpp")
+	log.Printf("Output directory: " + outputFolder)
+	err = CreateOutputFolder(outputFolder)
+	if err == nil {
+		err = BuildReplayCpp(component, outputFolder, "  ")
+	}
 	if err != nil {
 		log.Println("Fatal error")
-		log.Fatal(err)
-	} else {
-		log.Println("Success")
+		log.Println(err)
+		return eACTExitFailure
 	}
+	log.Println("Success")
+	return eACTExitSuccess
+}
 
+func runVersionCommand(args []string) int {
+	flags := newCommandFlagSet("version", "", "Prints the version of ACT.")
+	_, exitCode, ok := parseCommandFlags(flags, args, 0)
+	if !ok {
+		return exitCode
+	}
+	fmt.Fprintln(os.Stdout, "Version: "+ACTVersion)
+	return eACTExitSuccess
+}
+
+func runHelpCommand(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return eACTExitSuccess
+	}
+	command, ok := findACTCommand(args[0])
+	if !ok || command.Name == "help" {
+		printUsage()
+		return eACTExitUsage
+	}
+	return command.Run([]string{"--help"})
+}
+
+// legacyCommandLine translates the positional command line of ACT 1.6 and earlier
+// ("act IDL_FILE [-o FOLDER | -d OTHER_IDL_FILE]") into a subcommand invocation
+func legacyCommandLine(args []string) []string {
+	if len(args) >= 3 && args[1] == "-d" {
+		return []string{"diff", "--output", "diff.xml", args[0], args[2]}
+	}
+	return append([]string{"generate"}, args...)
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return eACTExitUsage
+	}
+
+	switch args[0] {
+	case "-v", "-version", "--version":
+		return runVersionCommand(nil)
+	case "-h", "-help", "--help":
+		printUsage()
+		return eACTExitSuccess
+	}
+
+	command, ok := findACTCommand(args[0])
+	if !ok {
+		if strings.HasPrefix(args[0], "-") {
+			fmt.Fprintf(os.Stderr, "act: unknown flag \"%s\"\n\n", args[0])
+			printUsage()
+			return eACTExitUsage
+		}
+		args = legacyCommandLine(args)
+		command, _ = findACTCommand(args[0])
+	}
+	return command.Run(args[1:])
+}
+
+func main() {
+	fmt.Fprintln(os.Stderr, "Automatic Component Toolkit v"+ACTVersion)
+	os.Exit(run(os.Args[1:]))
 }
@@ -40,6 +40,7 @@ import (
 	"log"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -482,6 +483,7 @@ func buildDynamicCImplementation(component ComponentDefinition, w LanguageWriter
 
 	w.Writeln("#else // _WIN32")
 	w.Writeln("#include <dlfcn.h>")
+	w.Writeln("#include <stddef.h>")
 	w.Writeln("#endif // _WIN32")
 
 	w.Writeln("")
@@ -518,9 +520,61 @@ func buildDynamicCImplementation(component ComponentDefinition, w LanguageWriter
 	return nil
 }
 
+// getCppDeprecatedAttribute returns the attribute that marks a deprecated class, method or enum option
+// with the qualified name of the element
+func getCppDeprecatedAttribute(deprecation ComponentDefinitionDeprecation, name string) string {
+	if !deprecation.IsDeprecated() {
+		return ""
+	}
+	return fmt.Sprintf("[[deprecated(%s)]] ", strconv.Quote(deprecation.DeprecationMessage(name)))
+}
+
+// writeCppDeprecationWarningsDisable suppresses the warnings that the binding itself would cause by using
+// deprecated classes and methods. Code that includes the binding still gets them.
+func writeCppDeprecationWarningsDisable(w LanguageWriter) {
+	w.Writeln("#if defined(__GNUC__) || defined(__clang__)")
+	w.Writeln("#pragma GCC diagnostic push")
+	w.Writeln("#pragma GCC diagnostic ignored \"-Wdeprecated-declarations\"")
+	w.Writeln("#elif defined(_MSC_VER)")
+	w.Writeln("#pragma warning(push)")
+	w.Writeln("#pragma warning(disable: 4996)")
+	w.Writeln("#endif")
+	w.Writeln("")
+}
+
+// writeCppDeprecationWarningsRestore restores the warnings suppressed by writeCppDeprecationWarningsDisable
+func writeCppDeprecationWarningsRestore(w LanguageWriter) {
+	w.Writeln("#if defined(__GNUC__) || defined(__clang__)")
+	w.Writeln("#pragma GCC diagnostic pop")
+	w.Writeln("#elif defined(_MSC_VER)")
+	w.Writeln("#pragma warning(pop)")
+	w.Writeln("#endif")
+	w.Writeln("")
+}
+
+// getBindingCppDefaultValue returns the C++ literal of the default of an input parameter
+func getBindingCppDefaultValue(param ComponentDefinitionParam, cppParamType string) string {
+	switch param.ParamType {
+	case "string":
+		return strconv.Quote(param.DefaultValue())
+	case "enum":
+		return cppParamType + "::" + param.DefaultValue()
+	}
+	return param.DefaultValue()
+}
+
 func writeDynamicCPPMethodDeclaration(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassIdentifier string, ClassName string) error {
 	parameters := ""
 	returntype := "void"
+	commentcodeLines := []string{}
+	staticSpecifier := ""
+
+	if method.IsStatic {
+		// Static methods have no instance whose wrapper they could call the library with
+		staticSpecifier = "static "
+		parameters = fmt.Sprintf("C%sWrapper * pWrapper", ClassIdentifier)
+		commentcodeLines = append(commentcodeLines, "* @param[in] pWrapper - Wrapper of the library")
+	}
 
 	for k := 0; k < len(method.Params); k++ {
 
@@ -533,6 +587,7 @@ func writeDynamicCPPMethodDeclaration(method ComponentDefinitionMethod, w Langua
 				parameters = parameters + ", "
 			}
 			cppParamType := getBindingCppParamType(param.ParamType, param.ParamClass, NameSpace, ClassIdentifier, true)
+			commentcodeLines = append(commentcodeLines, fmt.Sprintf("* @param[in] %s - %s", variableName, param.ParamDescription))
 
 			switch param.ParamType {
 			case "string":
@@ -546,20 +601,30 @@ func writeDynamicCPPMethodDeclaration(method ComponentDefinitionMethod, w Langua
 			default:
 				parameters = parameters + fmt.Sprintf("const %s %s", cppParamType, variableName)
 			}
+			if param.HasDefault() {
+				parameters = parameters + " = " + getBindingCppDefaultValue(param, cppParamType)
+			}
 		case "out":
 			cppParamType := getBindingCppParamType(param.ParamType, param.ParamClass, NameSpace, ClassIdentifier, false)
+			commentcodeLines = append(commentcodeLines, fmt.Sprintf("* @param[out] %s - %s", variableName, param.ParamDescription))
 			if parameters != "" {
 				parameters = parameters + ", "
 			}
 			parameters = parameters + fmt.Sprintf("%s & %s", cppParamType, variableName)
 		case "return":
 			returntype = getBindingCppParamType(param.ParamType, param.ParamClass, NameSpace, ClassIdentifier, false)
+			commentcodeLines = append(commentcodeLines, fmt.Sprintf("* @return %s", param.ParamDescription))
 		default:
 			return fmt.Errorf("invalid method parameter passing \"%s\" for %s.%s(%s)", param.ParamPass, ClassName, method.MethodName, param.ParamName)
 		}
 	}
 
-	w.Writeln("  inline %s %s(%s);", returntype, method.MethodName, parameters)
+	w.Writeln("  ")
+	w.Writeln("  /**")
+	w.Writeln("  * %s", method.MethodDescription)
+	w.Writelns("  ", commentcodeLines)
+	w.Writeln("  */")
+	w.Writeln("  %s%sinline %s %s(%s);", getCppDeprecatedAttribute(method.ComponentDefinitionDeprecation, ClassName+"."+method.MethodName), staticSpecifier, returntype, method.MethodName, parameters)
 
 	return nil
 }
@@ -583,6 +648,14 @@ func writeDynamicCPPMethod(method ComponentDefinitionMethod, w LanguageWriter, N
 		}
 		checkErrorCodeBegin = "CheckError(nullptr,"
 		makeSharedParameter = "this"
+	} else if method.IsStatic {
+		if ExplicitLinking {
+			CMethodName = fmt.Sprintf("pWrapper->m_WrapperTable.m_%s_%s", ClassName, method.MethodName)
+		} else {
+			CMethodName = fmt.Sprintf("%s_%s_%s", strings.ToLower(NameSpace), strings.ToLower(ClassName), strings.ToLower(method.MethodName))
+		}
+		checkErrorCodeBegin = "pWrapper->CheckError(nullptr,"
+		makeSharedParameter = "pWrapper"
 	} else {
 		if ExplicitLinking {
 			CMethodName = fmt.Sprintf("m_pWrapper->m_WrapperTable.m_%s_%s", ClassName, method.MethodName)
@@ -613,6 +686,10 @@ func writeDynamicCPPMethod(method ComponentDefinitionMethod, w LanguageWriter, N
 		cppClassPrefix += NameSpace
 	}
 	cppClassName := cppClassPrefix + ClassIdentifier + ClassName
+	if method.IsStatic {
+		parameters = fmt.Sprintf("%s%sWrapper * pWrapper", cppClassPrefix, ClassIdentifier)
+		commentcodeLines = append(commentcodeLines, "* @param[in] pWrapper - Wrapper of the library")
+	}
 
 	for k := 0; k < len(method.Params); k++ {
 		param := method.Params[k]
@@ -1128,6 +1205,9 @@ func buildCppHeader(component ComponentDefinition, w LanguageWriter, NameSpace s
 
 	w.Writeln("namespace %s {", NameSpace)
 	w.Writeln("")
+	if component.hasDeprecations() {
+		writeCppDeprecationWarningsDisable(w)
+	}
 
 	buildBindingCPPAllForwardDeclarations(component, w, NameSpace, cppClassPrefix, ClassIdentifier)
 
@@ -1244,7 +1324,6 @@ func buildCppHeader(component ComponentDefinition, w LanguageWriter, NameSpace s
 
 	w.Writeln("  ")
 	w.Writeln("  inline void CheckError(%s * pBaseClass, %sResult nResult);", cppBaseClassName, NameSpace)
-	w.Writeln("")
 
 	for j := 0; j < len(global.Methods); j++ {
 		method := global.Methods[j]
@@ -1318,7 +1397,12 @@ func buildCppHeader(component ComponentDefinition, w LanguageWriter, NameSpace s
 		w.Writeln("/*************************************************************************************************************************")
 		w.Writeln(" Class %s ", cppClassName)
 		w.Writeln("**************************************************************************************************************************/")
-		w.Writeln("class %s %s{", cppClassName, inheritanceSpecifier)
+		if class.ClassDescription != "" {
+			w.Writeln("/**")
+			w.Writeln("* %s", class.ClassDescription)
+			w.Writeln("*/")
+		}
+		w.Writeln("class %s%s %s{", getCppDeprecatedAttribute(class.ComponentDefinitionDeprecation, class.ClassName), cppClassName, inheritanceSpecifier)
 		w.Writeln("public:")
 		w.Writeln("  ")
 		if !component.isBaseClass(class) {
@@ -1331,7 +1415,6 @@ func buildCppHeader(component ComponentDefinition, w LanguageWriter, NameSpace s
 			}
 			w.Writeln("  {")
 			w.Writeln("  }")
-			w.Writeln("  ")
 		} else {
 			err = writeDynamicCppBaseClassMethods(component, baseClass, w, NameSpace, BaseName, cppClassPrefix, ClassIdentifier)
 			if err != nil {
@@ -1341,7 +1424,7 @@ func buildCppHeader(component ComponentDefinition, w LanguageWriter, NameSpace s
 
 		for j := 0; j < len(class.Methods); j++ {
 			method := class.Methods[j]
-			err = writeDynamicCPPMethodDeclaration(method, w, NameSpace, ClassIdentifier, cppClassName)
+			err = writeDynamicCPPMethodDeclaration(method, w, NameSpace, ClassIdentifier, class.ClassName)
 			if err != nil {
 				return err
 			}
@@ -1459,6 +1542,9 @@ func buildCppHeader(component ComponentDefinition, w LanguageWriter, NameSpace s
 	}
 
 	w.Writeln("")
+	if component.hasDeprecations() {
+		writeCppDeprecationWarningsRestore(w)
+	}
 
 	w.Writeln("} // namespace %s", NameSpace)
 	w.Writeln("")
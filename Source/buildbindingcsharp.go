@@ -35,11 +35,10 @@ SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package main
 
 import (
-	"crypto/rand"
 	"fmt"
-	"io"
 	"log"
 	"path"
+	"strconv"
 	"strings"
 )
 
@@ -77,21 +76,6 @@ func BuildBindingCSharp(component ComponentDefinition, outputFolder string, outp
 			log.Printf("Omitting recreation of CSharp example \"%s\"", csharpExample)
 		}
 
-		csharpExampleSolution := path.Join(outputFolderExample, namespace+"_Example"+".sln")
-		if forceRecreation || !FileExists(csharpExampleSolution) {
-			log.Printf("Creating \"%s\"", csharpExampleSolution)
-			csharpExampleSolutionFile, err := CreateLanguageFile(csharpExampleSolution, indentString)
-			if err != nil {
-				return err
-			}
-			err = buildCSharpExampleSolution(component, csharpExampleSolutionFile, outputFolder)
-			if err != nil {
-				return err
-			}
-		} else {
-			log.Printf("Omitting recreation of CSharp example \"%s\"", csharpExample)
-		}
-
 		csharpExampleProject := path.Join(outputFolderExample, namespace+"_Example"+".csproj")
 		if forceRecreation || !FileExists(csharpExampleProject) {
 			log.Printf("Creating \"%s\"", csharpExampleProject)
@@ -101,7 +85,7 @@ func BuildBindingCSharp(component ComponentDefinition, outputFolder string, outp
 			}
 			buildCSharpExampleProject(component, csharpExampleProjectFile, outputFolder)
 		} else {
-			log.Printf("Omitting recreation of CSharp example \"%s\"", csharpExample)
+			log.Printf("Omitting recreation of CSharp example \"%s\"", csharpExampleProject)
 		}
 	}
 
@@ -266,6 +250,24 @@ func getCSharpPlainParameters(method ComponentDefinitionMethod, NameSpace string
 	return parameters, nil
 }
 
+// getCSharpDefaultValue returns the C# literal of the default of an optional input parameter
+func getCSharpDefaultValue(param ComponentDefinitionParam, ParamTypeName string) string {
+	return getCSharpLiteral(param.DefaultValue(), param.ParamType, ParamTypeName)
+}
+
+// getCSharpLiteral returns the C# literal of a value of a scalar type, a string or an enum
+func getCSharpLiteral(value string, valueType string, ValueTypeName string) string {
+	switch valueType {
+	case "string":
+		return strconv.Quote(value)
+	case "enum":
+		return ValueTypeName + "." + value
+	case "single":
+		return value + "f"
+	}
+	return value
+}
+
 func getCSharpClassParameters(method ComponentDefinitionMethod, NameSpace string, ClassName string, isGlobal bool) (string, string, error) {
 	parameters := ""
 	returnType := ""
@@ -283,6 +285,9 @@ func getCSharpClassParameters(method ComponentDefinitionMethod, NameSpace string
 				parameters = parameters + ", "
 			}
 			parameters = parameters + ParamTypeName + " A" + param.ParamName
+			if param.HasDefault() {
+				parameters = parameters + " = " + getCSharpDefaultValue(param, ParamTypeName)
+			}
 
 		case "out":
 			if parameters != "" {
@@ -305,6 +310,39 @@ func getCSharpClassParameters(method ComponentDefinitionMethod, NameSpace string
 	return parameters, returnType, nil
 }
 
+// csharpXMLEscaper escapes text in XML doc comments
+var csharpXMLEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// writeCSharpSummary writes a description as XML doc comment
+func writeCSharpSummary(w LanguageWriter, indent string, description string) {
+	if description == "" {
+		return
+	}
+	w.Writeln(indent + "/// <summary>")
+	w.Writeln(indent+"/// %s", csharpXMLEscaper.Replace(description))
+	w.Writeln(indent + "/// </summary>")
+}
+
+// writeCSharpMethodDocComment writes the XML doc comment of a method with its parameters and return value
+func writeCSharpMethodDocComment(method ComponentDefinitionMethod, w LanguageWriter, indent string) {
+	writeCSharpSummary(w, indent, method.MethodDescription)
+	for _, param := range method.Params {
+		switch param.ParamPass {
+		case "in", "out":
+			w.Writeln(indent+"/// <param name=\"A%s\">%s</param>", param.ParamName, csharpXMLEscaper.Replace(param.ParamDescription))
+		case "return":
+			w.Writeln(indent+"/// <returns>%s</returns>", csharpXMLEscaper.Replace(param.ParamDescription))
+		}
+	}
+}
+
+// writeCSharpObsoleteAttribute marks a deprecated class, method, enum or option with the given qualified name as obsolete
+func writeCSharpObsoleteAttribute(w LanguageWriter, indent string, deprecation ComponentDefinitionDeprecation, name string) {
+	if deprecation.IsDeprecated() {
+		w.Writeln(indent+"[Obsolete(%s)]", strconv.Quote(deprecation.DeprecationMessage(name)))
+	}
+}
+
 func writeCSharpClassMethodImplementation(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassName string, isGlobal bool, spacing string) error {
 
 	defineCommands := make([]string, 0)
@@ -318,12 +356,16 @@ func writeCSharpClassMethodImplementation(method ComponentDefinitionMethod, w La
 	callFunctionName := ""
 	callFunctionParameters := ""
 	initCallParameters := ""
+	checkErrorName := "CheckError"
 
 	if isGlobal {
 		callFunctionName = fmt.Sprintf("%s", method.MethodName)
+	} else if method.IsStatic {
+		callFunctionName = fmt.Sprintf("%s_%s", ClassName, method.MethodName)
+		checkErrorName = "CheckStaticError"
 	} else {
 		callFunctionName = fmt.Sprintf("%s_%s", ClassName, method.MethodName)
-		callFunctionParameters = "Handle"
+		callFunctionParameters = "this"
 	}
 
 	initCallParameters = callFunctionParameters
@@ -611,14 +653,14 @@ func writeCSharpClassMethodImplementation(method ComponentDefinitionMethod, w La
 	}
 
 	if doInitCall {
-		w.Writeln(spacing+"  CheckError(Internal.%sWrapper.%s (%s));", NameSpace, callFunctionName, initCallParameters)
+		w.Writeln(spacing+"  %s(Internal.%sWrapper.%s (%s));", checkErrorName, NameSpace, callFunctionName, initCallParameters)
 	}
 
 	w.Writelns(spacing, postInitCommands)
 
 	w.Writeln("")
 
-	w.Writeln(spacing+"  CheckError(Internal.%sWrapper.%s (%s));", NameSpace, callFunctionName, callFunctionParameters)
+	w.Writeln(spacing+"  %s(Internal.%sWrapper.%s (%s));", checkErrorName, NameSpace, callFunctionName, callFunctionParameters)
 
 	w.Writelns(spacing, resultCommands)
 	w.Writelns(spacing, returnCodeLines)
@@ -634,14 +676,21 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 	CSharpBaseClassName := "C" + component.Global.BaseClassName
 	w.Writeln("using System;")
 	w.Writeln("using System.Text;")
+	w.Writeln("using System.Reflection;")
 	w.Writeln("using System.Runtime.InteropServices;")
 	w.Writeln("")
+	if component.hasDeprecations() {
+		// The binding itself uses its obsolete classes and methods
+		w.Writeln("#pragma warning disable 612, 618")
+		w.Writeln("")
+	}
 
 	w.Writeln("namespace %s {", NameSpace)
 	w.Writeln("")
 
 	for i := 0; i < len(component.Enums); i++ {
 		enum := component.Enums[i]
+		writeCSharpObsoleteAttribute(w, "  ", enum.ComponentDefinitionDeprecation, enum.Name)
 		w.Writeln("  public enum e%s {", enum.Name)
 
 		for j := 0; j < len(enum.Options); j++ {
@@ -651,6 +700,7 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 				commavalue = ","
 			}
 
+			writeCSharpObsoleteAttribute(w, "    ", option.ComponentDefinitionDeprecation, enum.Name+"."+option.Name)
 			w.Writeln("    %s = %d%s", option.Name, option.Value, commavalue)
 		}
 
@@ -799,8 +849,85 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 
 	w.Writeln("")
 
+	w.Writeln("    // Owns the reference of a class instance that has been returned by the library. The class wrappers derive")
+	w.Writeln("    // from it, so that disposing or finalizing them releases this reference. The references added by %s", component.Global.AcquireMethod)
+	w.Writeln("    // belong to the caller and are not released with the instance: %s gives them back.", component.Global.ReleaseMethod)
+	w.Writeln("    public class %sHandle : SafeHandle", NameSpace)
+	w.Writeln("    {")
+	w.Writeln("      private int AcquiredReferences = 0;")
+	w.Writeln("")
+	w.Writeln("      public %sHandle (IntPtr NewHandle) : base (IntPtr.Zero, true)", NameSpace)
+	w.Writeln("      {")
+	w.Writeln("        SetHandle (NewHandle);")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      public override bool IsInvalid")
+	w.Writeln("      {")
+	w.Writeln("        get { return handle == IntPtr.Zero; }")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      public void AddAcquiredReference ()")
+	w.Writeln("      {")
+	w.Writeln("        System.Threading.Interlocked.Increment (ref AcquiredReferences);")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      // Returns false if there is no acquired reference left, i.e. only the reference of the instance itself remains")
+	w.Writeln("      public bool RemoveAcquiredReference ()")
+	w.Writeln("      {")
+	w.Writeln("        while (true) {")
+	w.Writeln("          int references = AcquiredReferences;")
+	w.Writeln("          if (references == 0)")
+	w.Writeln("            return false;")
+	w.Writeln("          if (System.Threading.Interlocked.CompareExchange (ref AcquiredReferences, references - 1, references) == references)")
+	w.Writeln("            return true;")
+	w.Writeln("        }")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      protected override bool ReleaseHandle ()")
+	w.Writeln("      {")
+	w.Writeln("        return %sWrapper.%s (handle) == 0;", NameSpace, component.Global.ReleaseMethod)
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("")
 	w.Writeln("    public class %sWrapper", NameSpace)
 	w.Writeln("    {")
+	w.Writeln("      public const string LibraryName = \"%s\";", baseName)
+	w.Writeln("      private static string LibraryPath = null;")
+	w.Writeln("")
+	w.Writeln("      static %sWrapper ()", NameSpace)
+	w.Writeln("      {")
+	w.Writeln("        try {")
+	w.Writeln("          NativeLibrary.SetDllImportResolver (typeof (%sWrapper).Assembly, ResolveLibrary);", NameSpace)
+	w.Writeln("        } catch (InvalidOperationException) {")
+	w.Writeln("          // The assembly has a resolver already, so the runtime's default probing is used for %s", baseName)
+	w.Writeln("        }")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      public static void SetLibraryPath (string Path)")
+	w.Writeln("      {")
+	w.Writeln("        LibraryPath = Path;")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      private static IntPtr ResolveLibrary (string Name, Assembly CallingAssembly, DllImportSearchPath? SearchPath)")
+	w.Writeln("      {")
+	w.Writeln("        if (Name != LibraryName)")
+	w.Writeln("          return IntPtr.Zero;")
+	w.Writeln("")
+	w.Writeln("        if (LibraryPath != null)")
+	w.Writeln("          return NativeLibrary.Load (LibraryPath);")
+	w.Writeln("")
+	w.Writeln("        string FileName = LibraryName + \".so\";")
+	w.Writeln("        if (RuntimeInformation.IsOSPlatform (OSPlatform.Windows))")
+	w.Writeln("          FileName = LibraryName + \".dll\";")
+	w.Writeln("        else if (RuntimeInformation.IsOSPlatform (OSPlatform.OSX))")
+	w.Writeln("          FileName = LibraryName + \".dylib\";")
+	w.Writeln("")
+	w.Writeln("        IntPtr LibraryHandle;")
+	w.Writeln("        if (NativeLibrary.TryLoad (FileName, CallingAssembly, SearchPath, out LibraryHandle))")
+	w.Writeln("          return LibraryHandle;")
+	w.Writeln("        return IntPtr.Zero;")
+	w.Writeln("      }")
+	w.Writeln("")
 
 	for i := 0; i < len(component.Classes); i++ {
 		class := component.Classes[i]
@@ -813,12 +940,14 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 				return err
 			}
 
-			w.Writeln("      [DllImport(\"%s.dll\", EntryPoint = \"%s_%s_%s\", CallingConvention=CallingConvention.Cdecl)]", baseName, strings.ToLower(NameSpace), strings.ToLower(class.ClassName), strings.ToLower(method.MethodName))
+			w.Writeln("      [DllImport(LibraryName, EntryPoint = \"%s_%s_%s\", CallingConvention=CallingConvention.Cdecl)]", strings.ToLower(NameSpace), strings.ToLower(class.ClassName), strings.ToLower(method.MethodName))
 
-			if parameters == "" {
-				parameters = "IntPtr Handle"
-			} else {
-				parameters = "IntPtr Handle, " + parameters
+			if !method.IsStatic {
+				if parameters == "" {
+					parameters = NameSpace + "Handle Handle"
+				} else {
+					parameters = NameSpace + "Handle Handle, " + parameters
+				}
 			}
 
 			w.Writeln("      public unsafe extern static Int32 %s_%s (%s);", class.ClassName, method.MethodName, parameters)
@@ -836,7 +965,7 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 			return err
 		}
 
-		w.Writeln("      [DllImport(\"%s.dll\", EntryPoint = \"%s_%s\", CharSet = CharSet.Ansi, CallingConvention=CallingConvention.Cdecl)]", baseName, strings.ToLower(NameSpace), strings.ToLower(method.MethodName))
+		w.Writeln("      [DllImport(LibraryName, EntryPoint = \"%s_%s\", CharSet = CharSet.Ansi, CallingConvention=CallingConvention.Cdecl)]", strings.ToLower(NameSpace), strings.ToLower(method.MethodName))
 		w.Writeln("      public extern static Int32 %s (%s);", method.MethodName, parameters)
 		w.Writeln("")
 	}
@@ -979,37 +1108,45 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 			}
 		}
 
+		if component.isBaseClass(class) {
+			// SafeHandle implements IDisposable and releases the handle in its finalizer
+			CSharpParentClassName = ": Internal." + NameSpace + "Handle"
+		}
+
+		writeCSharpSummary(w, "  ", class.ClassDescription)
+		writeCSharpObsoleteAttribute(w, "  ", class.ComponentDefinitionDeprecation, class.ClassName)
 		w.Writeln("  class C%s %s", class.ClassName, CSharpParentClassName)
 		w.Writeln("  {")
 
 		if component.isBaseClass(class) {
-			w.Writeln("    protected IntPtr Handle;")
-			w.Writeln("")
-			w.Writeln("    public C%s (IntPtr NewHandle)", class.ClassName)
-			w.Writeln("    {")
-			w.Writeln("      Handle = NewHandle;")
-			w.Writeln("    }")
-			w.Writeln("")
-			w.Writeln("    ~C%s ()", class.ClassName)
+			w.Writeln("    public C%s (IntPtr NewHandle) : base (NewHandle)", class.ClassName)
 			w.Writeln("    {")
-			w.Writeln("      if (Handle != IntPtr.Zero) {")
-			w.Writeln("        Internal.%sWrapper.%s (Handle);", NameSpace, component.Global.ReleaseMethod)
-			w.Writeln("        Handle = IntPtr.Zero;")
-			w.Writeln("      }")
 			w.Writeln("    }")
 			w.Writeln("")
 
 			w.Writeln("    protected void CheckError (Int32 errorCode)")
 			w.Writeln("    {")
 			w.Writeln("      if (errorCode != 0) {")
-			w.Writeln("        Internal.%sWrapper.ThrowError (Handle, errorCode);", NameSpace)
+			w.Writeln("        Internal.%sWrapper.ThrowError (DangerousGetHandle (), errorCode);", NameSpace)
 			w.Writeln("      }")
 			w.Writeln("    }")
 			w.Writeln("")
 
+			if component.hasStaticMethods() {
+				w.Writeln("    protected static void CheckStaticError (Int32 errorCode)")
+				w.Writeln("    {")
+				w.Writeln("      if (errorCode != 0) {")
+				w.Writeln("        Internal.%sWrapper.ThrowError (IntPtr.Zero, errorCode);", NameSpace)
+				w.Writeln("      }")
+				w.Writeln("    }")
+				w.Writeln("")
+			}
+
 			w.Writeln("    public IntPtr GetHandle ()")
 			w.Writeln("    {")
-			w.Writeln("      return Handle;")
+			w.Writeln("      if (IsClosed)")
+			w.Writeln("        return IntPtr.Zero;")
+			w.Writeln("      return DangerousGetHandle ();")
 			w.Writeln("    }")
 			w.Writeln("")
 
@@ -1028,7 +1165,13 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 				return err
 			}
 
-			w.Writeln("    public %s %s (%s)", returnType, method.MethodName, parameters)
+			writeCSharpMethodDocComment(method, w, "    ")
+			writeCSharpObsoleteAttribute(w, "    ", method.ComponentDefinitionDeprecation, class.ClassName+"."+method.MethodName)
+			staticModifier := ""
+			if method.IsStatic {
+				staticModifier = "static "
+			}
+			w.Writeln("    public %s%s %s (%s)", staticModifier, returnType, method.MethodName, parameters)
 			w.Writeln("    {")
 
 			writeCSharpClassMethodImplementation(method, w, NameSpace, class.ClassName, false, "    ")
@@ -1037,6 +1180,23 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 			w.Writeln("")
 		}
 
+		for _, property := range class.Properties {
+			_, PropertyTypeName, err := getCSharpClassParameters(property.Getter(), NameSpace, class.ClassName, false)
+			if err != nil {
+				return err
+			}
+
+			writeCSharpSummary(w, "    ", property.Description)
+			w.Writeln("    public %s %s", PropertyTypeName, property.Name)
+			w.Writeln("    {")
+			w.Writeln("      get { return %s (); }", property.GetterName())
+			if !property.IsReadOnly() {
+				w.Writeln("      set { %s (value); }", property.SetterName())
+			}
+			w.Writeln("    }")
+			w.Writeln("")
+		}
+
 		w.Writeln("  }")
 		w.Writeln("")
 	}
@@ -1044,6 +1204,27 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 	w.Writeln("  class Wrapper")
 	w.Writeln("  {")
 
+	for _, constant := range component.Constants {
+		ConstantTypeName, err := getCSharpParameterType(constant.Type, NameSpace, "", false)
+		if err != nil {
+			return err
+		}
+		if constant.Type == "int8" {
+			ConstantTypeName = "SByte"
+		}
+		writeCSharpSummary(w, "    ", constant.Description)
+		w.Writeln("    public const %s %s = %s;", ConstantTypeName, constant.Name, getCSharpLiteral(constant.Value, constant.Type, ConstantTypeName))
+	}
+	if len(component.Constants) > 0 {
+		w.Writeln("")
+	}
+
+	w.Writeln("    public static void SetLibraryPath (string Path)")
+	w.Writeln("    {")
+	w.Writeln("      Internal.%sWrapper.SetLibraryPath (Path);", NameSpace)
+	w.Writeln("    }")
+	w.Writeln("")
+
 	w.Writeln("    private static void CheckError (Int32 errorCode)")
 	w.Writeln("    {")
 	w.Writeln("      if (errorCode != 0) {")
@@ -1060,6 +1241,8 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 			return err
 		}
 
+		writeCSharpMethodDocComment(method, w, "    ")
+		writeCSharpObsoleteAttribute(w, "    ", method.ComponentDefinitionDeprecation, "Wrapper."+method.MethodName)
 		w.Writeln("    public static %s %s (%s)", returnType, method.MethodName, parameters)
 		w.Writeln("    {")
 
@@ -1067,9 +1250,22 @@ func buildBindingCSharpImplementation(component ComponentDefinition, w LanguageW
 		if err != nil {
 			return err
 		}
-		if isSpecialFunction == eSpecialMethodInjection {
+		switch isSpecialFunction {
+		case eSpecialMethodInjection:
 			w.Writeln("    throw new Exception(\"Component injection is not supported in CSharp.\");")
-		} else {
+		case eSpecialMethodRelease:
+			// Acquired references are given back, the reference of the instance itself is released by disposing it,
+			// so that it is not released again
+			instanceName := "A" + method.Params[0].ParamName
+			w.Writeln("      if (!%s.RemoveAcquiredReference ()) {", instanceName)
+			w.Writeln("        %s.Dispose ();", instanceName)
+			w.Writeln("        return;")
+			w.Writeln("      }")
+			writeCSharpClassMethodImplementation(method, w, NameSpace, "Wrapper", true, "    ")
+		case eSpecialMethodAcquire:
+			writeCSharpClassMethodImplementation(method, w, NameSpace, "Wrapper", true, "    ")
+			w.Writeln("      A%s.AddAcquiredReference ();", method.Params[0].ParamName)
+		default:
 			writeCSharpClassMethodImplementation(method, w, NameSpace, "Wrapper", true, "    ")
 		}
 
@@ -1100,9 +1296,8 @@ func buildCSharpExample(componentdefinition ComponentDefinition, w LanguageWrite
 	w.Writeln("      try")
 	w.Writeln("      {")
 	w.Writeln("        UInt32 nMajor, nMinor, nMicro;")
-	//w.Writeln("        SetDllDirectory(\"\"); // TODO add the location of the shared library binary here")
+	w.Writeln("        // %s.Wrapper.SetLibraryPath(\"\"); // TODO add the location of the shared library binary here, if it is not next to the application", NameSpace)
 	w.Writeln("        %s.Wrapper.%s(out nMajor, out nMinor, out nMicro);", NameSpace, componentdefinition.Global.VersionMethod)
-	//w.Writeln("        SetDllDirectory(null);")
 	w.Writeln("        string versionString = string.Format(\"%s.version = {0}.{1}.{2}\", nMajor, nMinor, nMicro);", NameSpace)
 	if len(global.PrereleaseMethod) > 0 {
 		w.Writeln("        string sPreReleaseInfo;")
@@ -1128,67 +1323,6 @@ func buildCSharpExample(componentdefinition ComponentDefinition, w LanguageWrite
 	w.Writeln("")
 }
 
-// newUUID generates a random UUID according to RFC 4122
-// taken from https://play.golang.org/p/4FkNSiUDMg to not rely on external dependencies
-func newUUID() (string, error) {
-	uuid := make([]byte, 16)
-	n, err := io.ReadFull(rand.Reader, uuid)
-	if n != len(uuid) || err != nil {
-		return "", err
-	}
-	// variant bits; see section 4.1.1
-	uuid[8] = uuid[8]&^0xc0 | 0x80
-	// version 4 (pseudo-random); see section 4.1.3
-	uuid[6] = uuid[6]&^0xf0 | 0x40
-	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]), nil
-}
-
-func buildCSharpExampleSolution(componentdefinition ComponentDefinition, w LanguageWriter, outputFolder string) error {
-	NameSpace := componentdefinition.NameSpace
-
-	exampleName := NameSpace + "_Example"
-
-	uuid1, err := newUUID()
-	if err != nil {
-		return err
-	}
-	uuid2, err := newUUID()
-	if err != nil {
-		return err
-	}
-	solutionGUID, err := newUUID()
-	if err != nil {
-		return err
-	}
-	w.Writeln("")
-	w.Writeln("Microsoft Visual Studio Solution File, Format Version 12.00")
-	w.Writeln("# Visual Studio 15")
-	w.Writeln("VisualStudioVersion = 15.0.28307.539")
-	w.Writeln("MinimumVisualStudioVersion = 10.0.40219.1")
-	w.Writeln("Project(\"%s\") = \"%s\", \"%s.csproj\", \"%s\"", uuid1, exampleName, exampleName, uuid2)
-	w.Writeln("EndProject")
-	w.Writeln("Global")
-	w.Writeln("  GlobalSection(SolutionConfigurationPlatforms) = preSolution")
-	w.Writeln("    Debug|x64 = Debug|x64")
-	w.Writeln("    Release|x64 = Release|x64")
-	w.Writeln("  EndGlobalSection")
-	w.Writeln("  GlobalSection(ProjectConfigurationPlatforms) = postSolution")
-	w.Writeln("    %s.Debug|x64.ActiveCfg = Debug|x64", uuid2)
-	w.Writeln("    %s.Debug|x64.Build.0 = Debug|x64", uuid2)
-	w.Writeln("    %s.Release|x64.ActiveCfg = Release|x64", uuid2)
-	w.Writeln("    %s.Release|x64.Build.0 = Release|x64", uuid2)
-	w.Writeln("  EndGlobalSection")
-	w.Writeln("  GlobalSection(SolutionProperties) = preSolution")
-	w.Writeln("    HideSolutionNode = FALSE")
-	w.Writeln("  EndGlobalSection")
-	w.Writeln("  GlobalSection(ExtensibilityGlobals) = postSolution")
-	w.Writeln("    SolutionGuid = %s", solutionGUID)
-	w.Writeln("  EndGlobalSection")
-	w.Writeln("EndGlobal")
-
-	return nil
-}
-
 func buildCSharpExampleProject(componentdefinition ComponentDefinition, w LanguageWriter, outputFolder string) {
 	NameSpace := componentdefinition.NameSpace
 	exampleName := NameSpace + "_Example"
@@ -1197,19 +1331,12 @@ func buildCSharpExampleProject(componentdefinition ComponentDefinition, w Langua
 	w.Writeln("<Project Sdk=\"Microsoft.NET.Sdk\">")
 	w.Writeln("  <PropertyGroup>")
 	w.Writeln("    <OutputType>Exe</OutputType>")
-	w.Writeln("    <TargetFramework>netcoreapp2.0</TargetFramework>")
+	w.Writeln("    <TargetFramework>net8.0</TargetFramework>")
 	w.Writeln("    <StartupObject>%s.%s</StartupObject>", exampleName, exampleName)
-	w.Writeln("    <ApplicationIcon />")
-	w.Writeln("    <Platforms>x64</Platforms>")
-	w.Writeln("  </PropertyGroup>")
-	w.Writeln("  <PropertyGroup Condition=\"'$(Configuration)|$(Platform)'=='Debug|x64'\">")
-	w.Writeln("    <AllowUnsafeBlocks>true</AllowUnsafeBlocks>")
-	w.Writeln("  </PropertyGroup>")
-	w.Writeln("  <PropertyGroup Condition=\"'$(Configuration)|$(Platform)'=='Release|x64'\">")
 	w.Writeln("    <AllowUnsafeBlocks>true</AllowUnsafeBlocks>")
 	w.Writeln("  </PropertyGroup>")
 	w.Writeln("  <ItemGroup>")
-	w.Writeln("    <Compile Include=\"..\\..\\Bindings\\CSharp\\%s.cs\" Link=\"%s.cs\" />", NameSpace, NameSpace)
+	w.Writeln("    <Compile Include=\"../../Bindings/CSharp/%s.cs\" Link=\"%s.cs\" />", NameSpace, NameSpace)
 	w.Writeln("  </ItemGroup>")
 	w.Writeln("</Project>")
 }
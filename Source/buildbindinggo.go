@@ -38,6 +38,7 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"strconv"
 	"strings"
 )
 
@@ -145,6 +146,37 @@ func buildGoExample(component ComponentDefinition, w LanguageWriter, outputFolde
 	w.Writeln("")
 }
 
+func buildGoConstants(component ComponentDefinition, w LanguageWriter) error {
+	if len(component.Constants) <= 0 {
+		return nil
+	}
+	NameSpace := component.NameSpace
+
+	w.Writeln("")
+	w.Writeln("/*************************************************************************************************************************")
+	w.Writeln(" Declaration of constants")
+	w.Writeln("**************************************************************************************************************************/")
+	w.Writeln("")
+	w.Writeln("const (")
+	for _, constant := range component.Constants {
+		if constant.Description != "" {
+			w.Writelns("    ", []string{getGoDocComment(fmt.Sprintf("%s%s - %s", NameSpace, constant.Name, constant.Description))})
+		}
+		if constant.Type == "string" {
+			w.Writeln("    %s%s string = %s", NameSpace, constant.Name, strconv.Quote(constant.Value))
+			continue
+		}
+		goType, err := getGoBasicType(constant.Type)
+		if err != nil {
+			return err
+		}
+		w.Writeln("    %s%s %s = %s", NameSpace, constant.Name, goType, constant.Value)
+	}
+	w.Writeln(")")
+	w.Writeln("")
+	return nil
+}
+
 func buildGoEnums(component ComponentDefinition, w LanguageWriter) {
 	if len(component.Enums) <= 0 {
 		return
@@ -158,6 +190,7 @@ func buildGoEnums(component ComponentDefinition, w LanguageWriter) {
 	w.Writeln("")
 	for i := 0; i < len(component.Enums); i++ {
 		enum := component.Enums[i]
+		w.Writelns("", getGoDeprecatedComment(enum.ComponentDefinitionDeprecation, true))
 		w.Writeln("type E%s%s int", NameSpace, enum.Name)
 
 		w.Writeln("const (")
@@ -165,6 +198,7 @@ func buildGoEnums(component ComponentDefinition, w LanguageWriter) {
 		for j := 0; j < len(enum.Options); j++ {
 
 			option := enum.Options[j]
+			w.Writelns("    ", getGoDeprecatedComment(option.ComponentDefinitionDeprecation, true))
 			w.Writeln("    e%s_%s = %d", enum.Name, option.Name, option.Value)
 		}
 		w.Writeln(")")
@@ -259,7 +293,7 @@ func buildGoImplementation(component ComponentDefinition, implw LanguageWriter)
 
 	implw.Writeln("type %sImplementation struct {", NameSpace)
 	implw.Writeln("  Initialized bool")
-	implw.Writeln("  DLLHandle syscall.Handle")
+	implw.Writeln("  DLLHandle uintptr")
 
 	for i := 0; i < len(component.Classes); i++ {
 		class := component.Classes[i]
@@ -301,7 +335,7 @@ func buildGoImplementationHandle(component ComponentDefinition, implw LanguageWr
 	implw.Writeln("    dllhandle := handle.DLLhandle")
 	implw.Writeln("    handle.DLLhandle = 0;")
 	implw.Writeln("    ")
-	implw.Writeln("    return handle.Implementation.CallFunction(handle.Implementation.%s_%s, dllhandle)", NameSpace, strings.ToLower(component.Global.ReleaseMethod))
+	implw.Writeln("    return handle.Implementation.CallFunction(handle.Implementation.%s_%s, \"p\", dllhandle)", NameSpace, strings.ToLower(component.Global.ReleaseMethod))
 	implw.Writeln("  }")
 	implw.Writeln("  ")
 	implw.Writeln("  return nil")
@@ -345,7 +379,7 @@ func buildGoHelperFunctions(implw LanguageWriter) {
 			implw.Writeln("}")
 
 			implw.Writeln("func %s%sInValue(value %s%s) uintptr {", theFloatType, theWidth, strings.ToLower(theFloatType), theWidth)
-			implw.Writeln("  return uintptr(value)")
+			implw.Writeln("  return uintptr(math.%s%sbits(value))", theFloatType, theWidth)
 			implw.Writeln("}")
 		}
 	}
@@ -384,28 +418,196 @@ func buildGoErrorHandling(component ComponentDefinition, implw LanguageWriter) {
 	implw.Writeln("")
 }
 
+// getGoABISignature returns how the parameters of a method are passed in the C-ABI, with one letter per parameter:
+// "f" for a single, "d" for a double and "p" for all integers and pointers, which are passed as uintptr_t
+func getGoABISignature(method ComponentDefinitionMethod, isGlobal bool) string {
+	signature := ""
+	if !isGlobal && !method.IsStatic {
+		signature += "p"
+	}
+	for _, param := range method.Params {
+		switch param.ParamPass {
+		case "in":
+			switch param.ParamType {
+			case "single":
+				signature += "f"
+			case "double":
+				signature += "d"
+			case "basicarray", "structarray":
+				signature += "pp"
+			default:
+				signature += "p"
+			}
+		default:
+			if (param.ParamType == "string") || (param.ParamType == "basicarray") || (param.ParamType == "structarray") {
+				signature += "ppp"
+			} else {
+				signature += "p"
+			}
+		}
+	}
+	return signature
+}
+
+// getGoABISignatures returns the distinct C-ABI signatures of all methods of a component
+func getGoABISignatures(component ComponentDefinition) []string {
+	signatures := []string{}
+	knownSignatures := make(map[string]bool)
+	addSignature := func(signature string) {
+		if !knownSignatures[signature] {
+			knownSignatures[signature] = true
+			signatures = append(signatures, signature)
+		}
+	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			addSignature(getGoABISignature(method, false))
+		}
+	}
+	for _, method := range component.Global.Methods {
+		addSignature(getGoABISignature(method, true))
+	}
+	return signatures
+}
+
+// getGoCallFunctionName returns the name of the C function that calls a function pointer of a C-ABI signature
+func getGoCallFunctionName(NameSpace string, signature string) string {
+	if signature == "" {
+		return NameSpace + "Call_void"
+	}
+	return NameSpace + "Call_" + signature
+}
+
+func buildGoCgoPreamble(component ComponentDefinition, implw LanguageWriter) {
+	NameSpace := component.NameSpace
+
+	implw.Writeln("/*")
+	implw.Writeln("#cgo linux LDFLAGS: -ldl")
+	implw.Writeln("#include <stdint.h>")
+	implw.Writeln("#include <stdlib.h>")
+	implw.Writeln("#include <string.h>")
+	implw.Writeln("")
+	implw.Writeln("#ifdef _WIN32")
+	implw.Writeln("#include <windows.h>")
+	implw.Writeln("#else")
+	implw.Writeln("#include <dlfcn.h>")
+	implw.Writeln("#endif")
+	implw.Writeln("")
+	implw.Writeln("static void * %sLoadLibrary(const char * pFileName)", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("#ifdef _WIN32")
+	implw.Writeln("  return (void *) LoadLibraryA(pFileName);")
+	implw.Writeln("#else")
+	implw.Writeln("  return dlopen(pFileName, RTLD_LAZY);")
+	implw.Writeln("#endif")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("static void * %sGetProcAddress(void * pLibrary, const char * pSymbolName)", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("#ifdef _WIN32")
+	implw.Writeln("  return (void *) GetProcAddress((HMODULE) pLibrary, pSymbolName);")
+	implw.Writeln("#else")
+	implw.Writeln("  return dlsym(pLibrary, pSymbolName);")
+	implw.Writeln("#endif")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("static const char * %sGetLoaderError()", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("#ifdef _WIN32")
+	implw.Writeln("  return \"Windows error\";")
+	implw.Writeln("#else")
+	implw.Writeln("  const char * pError = dlerror();")
+	implw.Writeln("  if (pError == NULL)")
+	implw.Writeln("    return \"unknown error\";")
+	implw.Writeln("  return pError;")
+	implw.Writeln("#endif")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("static float %sSingleValue(uintptr_t nValue)", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  uint32_t nBits = (uint32_t) nValue;")
+	implw.Writeln("  float fValue;")
+	implw.Writeln("  memcpy(&fValue, &nBits, sizeof(fValue));")
+	implw.Writeln("  return fValue;")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("static double %sDoubleValue(uintptr_t nValue)", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  uint64_t nBits = (uint64_t) nValue;")
+	implw.Writeln("  double dValue;")
+	implw.Writeln("  memcpy(&dValue, &nBits, sizeof(dValue));")
+	implw.Writeln("  return dValue;")
+	implw.Writeln("}")
+	implw.Writeln("")
+	for _, signature := range getGoABISignatures(component) {
+		types := []string{}
+		values := []string{}
+		for i, kind := range signature {
+			switch kind {
+			case 'f':
+				types = append(types, "float")
+				values = append(values, fmt.Sprintf("%sSingleValue(pParameters[%d])", NameSpace, i))
+			case 'd':
+				types = append(types, "double")
+				values = append(values, fmt.Sprintf("%sDoubleValue(pParameters[%d])", NameSpace, i))
+			default:
+				types = append(types, "uintptr_t")
+				values = append(values, fmt.Sprintf("pParameters[%d]", i))
+			}
+		}
+		if len(types) == 0 {
+			types = []string{"void"}
+		}
+		implw.Writeln("static int32_t %s(void * pFunction, uintptr_t * pParameters)", getGoCallFunctionName(NameSpace, signature))
+		implw.Writeln("{")
+		implw.Writeln("  return ((int32_t (*)(%s)) pFunction)(%s);", strings.Join(types, ", "), strings.Join(values, ", "))
+		implw.Writeln("}")
+		implw.Writeln("")
+	}
+	implw.Writeln("*/")
+	implw.Writeln("import \"C\"")
+	implw.Writeln("")
+}
+
 func buildGoInitialize(component ComponentDefinition, implw LanguageWriter) {
 	NameSpace := component.NameSpace
 	global := component.Global
 
+	implw.Writeln("func (implementation *%sImplementation) getProcAddress(dllHandle unsafe.Pointer, symbolName string) (uintptr, error) {", NameSpace)
+	implw.Writeln("  cSymbolName := C.CString(symbolName)")
+	implw.Writeln("  defer C.free(unsafe.Pointer(cSymbolName))")
+	implw.Writeln("")
+	implw.Writeln("  address := C.%sGetProcAddress(dllHandle, cSymbolName)", NameSpace)
+	implw.Writeln("  if (address == nil) {")
+	implw.Writeln("    return 0, errors.New(\"Could not get function \" + symbolName + \": \" + C.GoString(C.%sGetLoaderError()))", NameSpace)
+	implw.Writeln("  }")
+	implw.Writeln("  return uintptr(address), nil")
+	implw.Writeln("}")
+	implw.Writeln("")
+
 	implw.Writeln("func (implementation *%sImplementation) Initialize(DLLFileName string) error {", NameSpace)
 	implw.Writeln("  implementation.Initialized = false;")
 	implw.Writeln("  implementation.DLLHandle = 0;")
 	implw.Writeln("")
-	implw.Writeln("  dllHandle, err := syscall.LoadLibrary(DLLFileName);")
-	implw.Writeln("  if (err != nil) {")
-	implw.Writeln("    return err;")
+	implw.Writeln("  cDLLFileName := C.CString(DLLFileName)")
+	implw.Writeln("  defer C.free(unsafe.Pointer(cDLLFileName))")
+	implw.Writeln("")
+	implw.Writeln("  dllHandle := C.%sLoadLibrary(cDLLFileName)", NameSpace)
+	implw.Writeln("  if (dllHandle == nil) {")
+	implw.Writeln("    return errors.New(\"Could not load library \" + DLLFileName + \": \" + C.GoString(C.%sGetLoaderError()))", NameSpace)
 	implw.Writeln("  }")
 	implw.Writeln("")
+	implw.Writeln("  var err error")
+	implw.Writeln("")
 
 	for i := 0; i < len(component.Classes); i++ {
 		class := component.Classes[i]
 		for j := 0; j < len(class.Methods); j++ {
 			method := class.Methods[j]
 			functionName := fmt.Sprintf("%s_%s_%s", strings.ToLower(NameSpace), strings.ToLower(class.ClassName), strings.ToLower(method.MethodName))
-			implw.Writeln("  implementation.%s_%s_%s, err = syscall.GetProcAddress(dllHandle, \"%s\")", NameSpace, strings.ToLower(class.ClassName), strings.ToLower(method.MethodName), functionName)
+			implw.Writeln("  implementation.%s_%s_%s, err = implementation.getProcAddress(dllHandle, \"%s\")", NameSpace, strings.ToLower(class.ClassName), strings.ToLower(method.MethodName), functionName)
 			implw.Writeln("  if (err != nil) {")
-			implw.Writeln("    return errors.New(\"Could not get function %s: \" + err.Error())", functionName)
+			implw.Writeln("    return err")
 			implw.Writeln("  }")
 			implw.Writeln("  ")
 		}
@@ -415,14 +617,14 @@ func buildGoInitialize(component ComponentDefinition, implw LanguageWriter) {
 		method := global.Methods[j]
 
 		functionName := fmt.Sprintf("%s_%s", strings.ToLower(NameSpace), strings.ToLower(method.MethodName))
-		implw.Writeln("  implementation.%s_%s, err = syscall.GetProcAddress(dllHandle, \"%s\")", NameSpace, strings.ToLower(method.MethodName), functionName)
+		implw.Writeln("  implementation.%s_%s, err = implementation.getProcAddress(dllHandle, \"%s\")", NameSpace, strings.ToLower(method.MethodName), functionName)
 		implw.Writeln("  if (err != nil) {")
-		implw.Writeln("    return errors.New(\"Could not get function %s: \" + err.Error())", functionName)
+		implw.Writeln("    return err")
 		implw.Writeln("  }")
 		implw.Writeln("  ")
 	}
 
-	implw.Writeln("  implementation.DLLHandle =  dllHandle")
+	implw.Writeln("  implementation.DLLHandle = uintptr(dllHandle)")
 	implw.Writeln("  implementation.Initialized = true")
 	implw.Writeln("  return nil")
 	implw.Writeln("}")
@@ -431,28 +633,26 @@ func buildGoInitialize(component ComponentDefinition, implw LanguageWriter) {
 func buildGoCallFunction(component ComponentDefinition, implw LanguageWriter) {
 	NameSpace := component.NameSpace
 
-	implw.Writeln("func (implementation *%sImplementation) CallFunction(funcptr uintptr, parameters ... uintptr) (error) {", NameSpace)
-	implw.Writeln("  var ret uintptr;")
+	implw.Writeln("func (implementation *%sImplementation) CallFunction(funcptr uintptr, signature string, parameters ... uintptr) (error) {", NameSpace)
 	implw.Writeln("  if (!implementation.Initialized) {")
 	implw.Writeln("    return errors.New(\"%s Implementation has not been initialized!\")", NameSpace)
 	implw.Writeln("  }")
+	implw.Writeln("  if (len(parameters) != len(signature)) {")
+	implw.Writeln("    return errors.New(\"Invalid DLL function parameter count!\");")
+	implw.Writeln("  }")
 	implw.Writeln("  ")
-	implw.Writeln("  switch len(parameters) { ")
-	implw.Writeln("    case 0: ret, _, _ = syscall.Syscall(funcptr, 0, 0, 0, 0)")
-	implw.Writeln("    case 1: ret, _, _ = syscall.Syscall(funcptr, 1, uintptr(parameters[0]), 0, 0)")
-	implw.Writeln("    case 2: ret, _, _ = syscall.Syscall(funcptr, 2, uintptr(parameters[0]), uintptr(parameters[1]), 0)")
-	implw.Writeln("    case 3: ret, _, _ = syscall.Syscall(funcptr, 3, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]))")
-	implw.Writeln("    case 4: ret, _, _ = syscall.Syscall6(funcptr, 4, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), 0, 0)")
-	implw.Writeln("    case 5: ret, _, _ = syscall.Syscall6(funcptr, 5, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), 0)")
-	implw.Writeln("    case 6: ret, _, _ = syscall.Syscall6(funcptr, 6, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]))")
-	implw.Writeln("    case 7: ret, _, _ = syscall.Syscall9(funcptr, 7, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]), uintptr(parameters[6]), 0, 0)")
-	implw.Writeln("    case 8: ret, _, _ = syscall.Syscall9(funcptr, 8, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]), uintptr(parameters[6]), uintptr(parameters[7]), 0)")
-	implw.Writeln("    case 9: ret, _, _ = syscall.Syscall9(funcptr, 9, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]), uintptr(parameters[6]), uintptr(parameters[7]), uintptr(parameters[8]))")
-	implw.Writeln("    case 10: ret, _, _ = syscall.Syscall12(funcptr, 10, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]), uintptr(parameters[6]), uintptr(parameters[7]), uintptr(parameters[8]), uintptr(parameters[9]), 0, 0)")
-	implw.Writeln("    case 11: ret, _, _ = syscall.Syscall12(funcptr, 11, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]), uintptr(parameters[6]), uintptr(parameters[7]), uintptr(parameters[8]), uintptr(parameters[9]), uintptr(parameters[10]), 0)")
-	implw.Writeln("    case 12: ret, _, _ = syscall.Syscall12(funcptr, 12, uintptr(parameters[0]), uintptr(parameters[1]), uintptr(parameters[2]), uintptr(parameters[3]), uintptr(parameters[4]), uintptr(parameters[5]), uintptr(parameters[6]), uintptr(parameters[7]), uintptr(parameters[8]), uintptr(parameters[9]), uintptr(parameters[10]), uintptr(parameters[11]))")
-	implw.Writeln("    default: ")
-	implw.Writeln("      return errors.New(\"Invalid DLL function parameter count!\");")
+	implw.Writeln("  var cParameters *C.uintptr_t = nil")
+	implw.Writeln("  if (len(parameters) > 0) {")
+	implw.Writeln("    cParameters = (*C.uintptr_t)(unsafe.Pointer(&parameters[0]))")
+	implw.Writeln("  }")
+	implw.Writeln("  var ret C.int32_t")
+	implw.Writeln("  switch (signature) {")
+	for _, signature := range getGoABISignatures(component) {
+		implw.Writeln("  case \"%s\":", signature)
+		implw.Writeln("    ret = C.%s(unsafe.Pointer(funcptr), cParameters)", getGoCallFunctionName(NameSpace, signature))
+	}
+	implw.Writeln("  default:")
+	implw.Writeln("    return errors.New(\"Invalid DLL function signature!\");")
 	implw.Writeln("  }")
 	implw.Writeln("  ")
 	implw.Writeln("  if (int(ret) != 0) {")
@@ -470,6 +670,10 @@ func buildGoClass(component ComponentDefinition, class ComponentDefinitionClass,
 	*classdefinitions = append(*classdefinitions, fmt.Sprintf("Class definition %s%s", NameSpace, class.ClassName))
 	*classdefinitions = append(*classdefinitions, fmt.Sprintf("**************************************************************************************************************************/"))
 	*classdefinitions = append(*classdefinitions, fmt.Sprintf(""))
+	if class.ClassDescription != "" {
+		*classdefinitions = append(*classdefinitions, getGoDocComment(fmt.Sprintf("%s%s - %s", NameSpace, class.ClassName, class.ClassDescription)))
+	}
+	*classdefinitions = append(*classdefinitions, getGoDeprecatedComment(class.ComponentDefinitionDeprecation, class.ClassDescription == "")...)
 	*classdefinitions = append(*classdefinitions, fmt.Sprintf("type %s%s struct {", NameSpace, class.ClassName))
 
 	if component.Global.BaseClassName == class.ClassName {
@@ -510,8 +714,12 @@ func buildGoWrapper(component ComponentDefinition, w LanguageWriter, implw Langu
 	w.Writeln("package %s", packageName)
 	w.Writeln("")
 
+	err := buildGoConstants(component, w)
+	if err != nil {
+		return err
+	}
 	buildGoEnums(component, w)
-	err := buildGoStructs(component, w)
+	err = buildGoStructs(component, w)
 	if err != nil {
 		return err
 	}
@@ -520,12 +728,11 @@ func buildGoWrapper(component ComponentDefinition, w LanguageWriter, implw Langu
 	implw.Writeln("")
 	implw.Writeln("package %s", packageName)
 	implw.Writeln("")
-	implw.Writeln("// #include <string.h>")
-	implw.Writeln("import \"C\"")
-	implw.Writeln("")
+	buildGoCgoPreamble(component, implw)
 	implw.Writeln("import (")
 	implw.Writeln("    \"fmt\"")
 	implw.Writeln("    \"errors\"")
+	implw.Writeln("    \"math\"")
 	implw.Writeln("    \"syscall\"")
 	implw.Writeln("    \"unsafe\"")
 	implw.Writeln(")")
@@ -695,6 +902,35 @@ func paramFunction(paramType string, paramPass string) (string, error) {
 	return paramFunctionStr, nil
 }
 
+// getGoDocComment returns a line of text as Go comment, escaped for Writelns
+func getGoDocComment(text string) string {
+	return "// " + strings.Replace(text, "%", "%%", -1)
+}
+
+// getGoDeprecatedComment returns the "Deprecated:" paragraph of the GoDoc comment of a deprecated element
+func getGoDeprecatedComment(deprecation ComponentDefinitionDeprecation, isFirstParagraph bool) []string {
+	if !deprecation.IsDeprecated() {
+		return []string{}
+	}
+	lines := []string{}
+	if !isFirstParagraph {
+		lines = append(lines, "//")
+	}
+	return append(lines, getGoDocComment("Deprecated: "+strings.TrimPrefix(deprecation.DeprecationNotice(), "Deprecated ")+"."))
+}
+
+// getGoMethodDocComment returns the GoDoc comment of a method of a class, with a list of its parameters
+func getGoMethodDocComment(method ComponentDefinitionMethod) []string {
+	lines := []string{getGoDocComment(fmt.Sprintf("%s - %s", method.MethodName, method.MethodDescription))}
+	if len(method.Params) > 0 {
+		lines = append(lines, "//")
+		for _, param := range method.Params {
+			lines = append(lines, getGoDocComment(fmt.Sprintf("  - %s (%s): %s", param.ParamName, param.ParamPass, param.ParamDescription)))
+		}
+	}
+	return append(lines, getGoDeprecatedComment(method.ComponentDefinitionDeprecation, false)...)
+}
+
 func writeGoMethod(method ComponentDefinitionMethod, w LanguageWriter, implw LanguageWriter, NameSpace string, ClassName string, isGlobal bool, classdefinitions *[]string) error {
 
 	parameters := ""
@@ -746,7 +982,8 @@ func writeGoMethod(method ComponentDefinitionMethod, w LanguageWriter, implw Lan
 	}
 	errorReturn = errorReturn + "err"
 
-	if !isGlobal {
+	hasInstance := !isGlobal && !method.IsStatic
+	if hasInstance {
 		implCasts = append(implCasts, fmt.Sprintf(""))
 		implCasts = append(implCasts, fmt.Sprintf("implementation_%s, err := implementation.GetWrapperHandle(%s)", strings.ToLower(ClassName), ClassName))
 		implCasts = append(implCasts, fmt.Sprintf("if (err != nil) {"))
@@ -1087,9 +1324,11 @@ func writeGoMethod(method ComponentDefinitionMethod, w LanguageWriter, implw Lan
 	if isGlobal {
 		w.Writeln("  %s(%s) (%serror)\n", method.MethodName, parameters, returnvalues)
 	} else {
-		handleparameter = fmt.Sprintf("%s %sHandle", ClassName, NameSpace)
-		if parameters != "" {
-			handleparameter = handleparameter + ", "
+		if hasInstance {
+			handleparameter = fmt.Sprintf("%s %sHandle", ClassName, NameSpace)
+			if parameters != "" {
+				handleparameter = handleparameter + ", "
+			}
 		}
 		w.Writeln("  %s_%s(%s%s) (%serror)\n", ClassName, method.MethodName, handleparameter, parameters, returnvalues)
 	}
@@ -1099,6 +1338,8 @@ func writeGoMethod(method ComponentDefinitionMethod, w LanguageWriter, implw Lan
 	implGetHandleFunction := ""
 	if !isGlobal {
 		implmethodname += strings.ToLower(ClassName) + "_"
+	}
+	if hasInstance {
 		implGetHandleFunction = fmt.Sprintf(", implementation_%s.GetDLLInHandle()", strings.ToLower(ClassName))
 	}
 	implmethodname += strings.ToLower(method.MethodName)
@@ -1111,14 +1352,15 @@ func writeGoMethod(method ComponentDefinitionMethod, w LanguageWriter, implw Lan
 	implw.Writelns("  ", implDeclarations)
 	implw.Writelns("  ", implCasts)
 	implw.Writeln("")
+	signature := getGoABISignature(method, isGlobal)
 	if requiresInitCall {
-		implw.Writeln("  err = implementation.CallFunction(%s%s%s)", implmethodname, implGetHandleFunction, implInitCallParameters)
+		implw.Writeln("  err = implementation.CallFunction(%s, \"%s\"%s%s)", implmethodname, signature, implGetHandleFunction, implInitCallParameters)
 		implw.Writeln("  if (err != nil) {")
 		implw.Writeln("    return %s", errorReturn)
 		implw.Writeln("  }")
 		implw.Writelns("  ", implInitCallLines)
 	}
-	implw.Writeln("  err = implementation.CallFunction(%s%s%s)", implmethodname, implGetHandleFunction, implCallParameters)
+	implw.Writeln("  err = implementation.CallFunction(%s, \"%s\"%s%s)", implmethodname, signature, implGetHandleFunction, implCallParameters)
 
 	implw.Writeln("  if (err != nil) {")
 	implw.Writeln("    return %s", errorReturn)
@@ -1128,9 +1370,20 @@ func writeGoMethod(method ComponentDefinitionMethod, w LanguageWriter, implw Lan
 	implw.Writeln("}")
 	implw.Writeln("")
 
+	if method.IsStatic {
+		// Static methods are called without an instance, so they are methods of the wrapper named after their class
+		wrapperMethod := method
+		wrapperMethod.MethodName = ClassName + method.MethodName
+		*classdefinitions = append(*classdefinitions, getGoMethodDocComment(wrapperMethod)...)
+	} else {
+		*classdefinitions = append(*classdefinitions, getGoMethodDocComment(method)...)
+	}
 	if isGlobal {
 		*classdefinitions = append(*classdefinitions, fmt.Sprintf("func (instance *%s%s) %s(%s) (%serror) {", NameSpace, ClassName, method.MethodName, parameters, classReturnTypes))
 		*classdefinitions = append(*classdefinitions, fmt.Sprintf("  %serror := instance.Interface.%s(%s)", classReturnVariables, method.MethodName, callparameters))
+	} else if method.IsStatic {
+		*classdefinitions = append(*classdefinitions, fmt.Sprintf("func (instance *%sWrapper) %s%s(%s) (%serror) {", NameSpace, ClassName, method.MethodName, parameters, classReturnTypes))
+		*classdefinitions = append(*classdefinitions, fmt.Sprintf("  %serror := instance.Interface.%s_%s(%s)", classReturnVariables, ClassName, method.MethodName, callparameters))
 	} else {
 		if callparameters != "" {
 			callparameters = ", " + callparameters
@@ -0,0 +1,1613 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// buildbindingjava.go
+// functions to generate Java-bindings of a library's API, based on the Foreign Function and Memory
+// API of Java 22.
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+)
+
+// javaKeywords contains all identifiers that are reserved in Java
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true, "catch": true,
+	"char": true, "class": true, "const": true, "continue": true, "default": true, "do": true, "double": true,
+	"else": true, "enum": true, "extends": true, "final": true, "finally": true, "float": true, "for": true,
+	"goto": true, "if": true, "implements": true, "import": true, "instanceof": true, "int": true,
+	"interface": true, "long": true, "native": true, "new": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "short": true, "static": true, "strictfp": true,
+	"super": true, "switch": true, "synchronized": true, "this": true, "throw": true, "throws": true,
+	"transient": true, "try": true, "void": true, "volatile": true, "while": true, "true": true, "false": true,
+	"null": true, "var": true, "record": true, "yield": true,
+}
+
+// javaABIParameter is a parameter of an exported function as it is passed through the Foreign Function API
+type javaABIParameter struct {
+	Layout  string
+	Carrier string
+}
+
+// javaMethodCode collects the code fragments of a method of the Java bindings
+type javaMethodCode struct {
+	Signature    []string
+	ReturnTypes  []string
+	ReturnNames  []string
+	ReturnValues []string
+	PreCall      []string
+	CheckArgs    []string
+	CallArgs     []string
+	CheckCall    []string
+	PostCall     []string
+	DoCheckCall  bool
+}
+
+// BuildBindingJava builds Java-bindings of a library's API. Every class becomes an interface and an
+// AutoCloseable implementation that loads the library through the Foreign Function and Memory API.
+func BuildBindingJava(component ComponentDefinition, outputFolder string, outputFolderExample string, indentString string) error {
+	forceRecreation := false
+
+	NameSpace := component.NameSpace
+	LibraryName := component.LibraryName
+	packageName := javaPackageName(component)
+
+	outputFolderPackage := path.Join(outputFolder, packageName)
+	err := CreateOutputFolder(outputFolderPackage)
+	if err != nil {
+		return err
+	}
+
+	createJavaFile := func(typeName string, abstract string) (LanguageWriter, error) {
+		fileName := path.Join(outputFolderPackage, typeName+".java")
+		log.Printf("Creating \"%s\"", fileName)
+		javafile, err := CreateLanguageFile(fileName, indentString)
+		if err != nil {
+			return javafile, err
+		}
+		javafile.WriteCLicenseHeader(component, abstract, true)
+		javafile.Writeln("package %s;", packageName)
+		javafile.Writeln("")
+		return javafile, nil
+	}
+
+	exceptionfile, err := createJavaFile(NameSpace+"Exception", fmt.Sprintf("This is an autogenerated Java file that defines the exception of %s", LibraryName))
+	if err != nil {
+		return err
+	}
+	buildJavaException(component, exceptionfile)
+
+	for _, enum := range component.Enums {
+		enumfile, err := createJavaFile(enum.Name, fmt.Sprintf("This is an autogenerated Java file that defines the enum %s of %s", enum.Name, LibraryName))
+		if err != nil {
+			return err
+		}
+		buildJavaEnum(enum, enumfile)
+	}
+
+	for _, structinfo := range component.Structs {
+		structfile, err := createJavaFile(structinfo.Name, fmt.Sprintf("This is an autogenerated Java file that defines the struct %s of %s", structinfo.Name, LibraryName))
+		if err != nil {
+			return err
+		}
+		err = buildJavaStruct(component, structinfo, structfile)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, functiontype := range component.Functions {
+		functionfile, err := createJavaFile(functiontype.FunctionName, fmt.Sprintf("This is an autogenerated Java file that defines the callback %s of %s", functiontype.FunctionName, LibraryName))
+		if err != nil {
+			return err
+		}
+		err = buildJavaFunctionType(component, functiontype, functionfile)
+		if err != nil {
+			return err
+		}
+	}
+
+	wrapperfile, err := createJavaFile(NameSpace+"Wrapper", fmt.Sprintf("This is an autogenerated Java file in order to allow an easy\n use of %s", LibraryName))
+	if err != nil {
+		return err
+	}
+	err = buildJavaWrapper(component, wrapperfile)
+	if err != nil {
+		return err
+	}
+
+	for _, class := range component.Classes {
+		interfacefile, err := createJavaFile(class.ClassName, fmt.Sprintf("This is an autogenerated Java file that defines the interface of the class %s of %s", class.ClassName, LibraryName))
+		if err != nil {
+			return err
+		}
+		err = buildJavaClassInterface(component, class, interfacefile)
+		if err != nil {
+			return err
+		}
+
+		implementationfile, err := createJavaFile(class.ClassName+"Impl", fmt.Sprintf("This is an autogenerated Java file that implements the class %s of %s", class.ClassName, LibraryName))
+		if err != nil {
+			return err
+		}
+		err = buildJavaClassImplementation(component, class, implementationfile)
+		if err != nil {
+			return err
+		}
+	}
+
+	buildScript := path.Join(outputFolder, "build.sh")
+	log.Printf("Creating \"%s\"", buildScript)
+	buildfile, err := CreateLanguageFile(buildScript, indentString)
+	if err != nil {
+		return err
+	}
+	buildJavaBuildScript(component, buildfile, false, "", "")
+
+	buildBatch := path.Join(outputFolder, "build.bat")
+	log.Printf("Creating \"%s\"", buildBatch)
+	batchfile, err := CreateLanguageFile(buildBatch, indentString)
+	if err != nil {
+		return err
+	}
+	buildJavaBuildScript(component, batchfile, true, "", "")
+
+	if len(outputFolderExample) > 0 {
+		exampleName := NameSpace + "_Example"
+		JavaExample := path.Join(outputFolderExample, exampleName+".java")
+		if forceRecreation || !FileExists(JavaExample) {
+			log.Printf("Creating \"%s\"", JavaExample)
+			examplefile, err := CreateLanguageFile(JavaExample, indentString)
+			if err != nil {
+				return err
+			}
+			examplefile.WriteCLicenseHeader(component,
+				fmt.Sprintf("This is an autogenerated Java application that demonstrates the\n usage of the Java bindings of %s", LibraryName),
+				true)
+			buildJavaExample(component, examplefile)
+		} else {
+			log.Printf("Omitting recreation of Java example \"%s\"", JavaExample)
+		}
+
+		for _, isBatch := range []bool{false, true} {
+			exampleScript := path.Join(outputFolderExample, "build.sh")
+			if isBatch {
+				exampleScript = path.Join(outputFolderExample, "build.bat")
+			}
+			if forceRecreation || !FileExists(exampleScript) {
+				log.Printf("Creating \"%s\"", exampleScript)
+				scriptfile, err := CreateLanguageFile(exampleScript, indentString)
+				if err != nil {
+					return err
+				}
+				buildJavaBuildScript(component, scriptfile, isBatch, path.Join("..", "..", "Bindings", "Java"), exampleName)
+			} else {
+				log.Printf("Omitting recreation of Java example build script \"%s\"", exampleScript)
+			}
+		}
+	}
+
+	return nil
+}
+
+// javaPackageName returns the name of the Java package of a component
+func javaPackageName(component ComponentDefinition) string {
+	return strings.ToLower(component.NameSpace)
+}
+
+// javaIdentifier converts the name of a method, parameter or member into a camel case Java identifier.
+// Names that clash with keywords or with local variables of the generated code get a trailing underscore.
+func javaIdentifier(name string) string {
+	identifier := name
+	if len(identifier) > 0 {
+		identifier = strings.ToLower(identifier[0:1]) + identifier[1:]
+	}
+	if javaKeywords[identifier] || identifier == "arena" || identifier == "wrapper" || identifier == "handle" || identifier == "close" {
+		identifier = identifier + "_"
+	}
+	return identifier
+}
+
+// javaResolveClass splits a param class into the package prefix that qualifies it and its name
+func javaResolveClass(component ComponentDefinition, paramClass string) (string, string, error) {
+	namespace, className, err := decomposeParamClassName(paramClass)
+	if err != nil {
+		return "", "", err
+	}
+	if len(namespace) == 0 || namespace == component.NameSpace {
+		return "", className, nil
+	}
+	subComponent, ok := component.ImportedComponentDefinitions[namespace]
+	if !ok {
+		return "", "", fmt.Errorf("unknown namespace \"%s\" of class \"%s\"", namespace, paramClass)
+	}
+	return javaPackageName(subComponent) + ".", className, nil
+}
+
+// getJavaScalarType returns the Java type and the memory layout of a scalar type. Unsigned
+// integers are represented by the signed Java type of the same size.
+func getJavaScalarType(paramType string) (string, string, int, error) {
+	switch paramType {
+	case "uint8", "int8":
+		return "byte", "JAVA_BYTE", 1, nil
+	case "uint16", "int16":
+		return "short", "JAVA_SHORT", 2, nil
+	case "uint32", "int32":
+		return "int", "JAVA_INT", 4, nil
+	case "uint64", "int64":
+		return "long", "JAVA_LONG", 8, nil
+	case "bool":
+		return "boolean", "JAVA_BOOLEAN", 1, nil
+	case "single":
+		return "float", "JAVA_FLOAT", 4, nil
+	case "double":
+		return "double", "JAVA_DOUBLE", 8, nil
+	case "pointer":
+		return "MemorySegment", "ADDRESS", 0, nil
+	}
+	return "", "", 0, fmt.Errorf("invalid scalar type \"%s\" for Java", paramType)
+}
+
+// getJavaScalarBoxedType returns the boxed type of a primitive Java type
+func getJavaScalarBoxedType(javaType string) string {
+	switch javaType {
+	case "byte":
+		return "Byte"
+	case "short":
+		return "Short"
+	case "int":
+		return "Integer"
+	case "long":
+		return "Long"
+	case "boolean":
+		return "Boolean"
+	case "float":
+		return "Float"
+	case "double":
+		return "Double"
+	}
+	return javaType
+}
+
+// generateJavaABIParameters returns how a parameter is passed to an exported function of the C ABI
+func generateJavaABIParameters(param ComponentDefinitionParam, className string, methodName string) ([]javaABIParameter, error) {
+	switch param.ParamPass {
+	case "in":
+		switch param.ParamType {
+		case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "bool", "single", "double", "pointer":
+			javaType, layout, _, err := getJavaScalarType(param.ParamType)
+			if err != nil {
+				return nil, err
+			}
+			return []javaABIParameter{{layout, javaType}}, nil
+		case "enum":
+			return []javaABIParameter{{"JAVA_INT", "int"}}, nil
+		case "string", "struct", "class", "optionalclass", "functiontype":
+			return []javaABIParameter{{"ADDRESS", "MemorySegment"}}, nil
+		case "basicarray", "structarray":
+			return []javaABIParameter{{"JAVA_LONG", "long"}, {"ADDRESS", "MemorySegment"}}, nil
+		}
+
+	case "out", "return":
+		switch param.ParamType {
+		case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "bool", "single", "double", "pointer", "enum", "struct", "class", "optionalclass":
+			return []javaABIParameter{{"ADDRESS", "MemorySegment"}}, nil
+		case "string":
+			return []javaABIParameter{{"JAVA_INT", "int"}, {"ADDRESS", "MemorySegment"}, {"ADDRESS", "MemorySegment"}}, nil
+		case "basicarray", "structarray":
+			return []javaABIParameter{{"JAVA_LONG", "long"}, {"ADDRESS", "MemorySegment"}, {"ADDRESS", "MemorySegment"}}, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid method parameter passing \"%s\" for %s.%s (%s)", param.ParamPass, className, methodName, param.ParamName)
+	}
+
+	return nil, fmt.Errorf("invalid method parameter type \"%s\" for %s.%s (%s)", param.ParamType, className, methodName, param.ParamName)
+}
+
+// getJavaFunctionDescriptor returns the function descriptor of an exported function or a function type
+func getJavaFunctionDescriptor(params []ComponentDefinitionParam, className string, methodName string, hasInstance bool, isCallback bool) (string, error) {
+	layouts := []string{}
+	if hasInstance {
+		layouts = append(layouts, "ValueLayout.ADDRESS")
+	}
+	for _, param := range params {
+		abiParams, err := generateJavaABIParameters(param, className, methodName)
+		if err != nil {
+			return "", err
+		}
+		for _, abiParam := range abiParams {
+			layouts = append(layouts, "ValueLayout."+abiParam.Layout)
+		}
+	}
+	if isCallback {
+		return fmt.Sprintf("FunctionDescriptor.ofVoid(%s)", strings.Join(layouts, ", ")), nil
+	}
+	return fmt.Sprintf("FunctionDescriptor.of(%s)", strings.Join(append([]string{"ValueLayout.JAVA_INT"}, layouts...), ", ")), nil
+}
+
+// getJavaMethodHandleName returns the name of the field that holds the method handle of an exported function
+func getJavaMethodHandleName(NameSpace string, ClassName string, method ComponentDefinitionMethod, isGlobal bool) string {
+	return GetCExportName(NameSpace, ClassName, method, isGlobal)
+}
+
+// writeJavaDocComment writes a description as a Javadoc comment
+func writeJavaDocComment(w LanguageWriter, indent string, description string) {
+	if len(description) > 0 {
+		w.Writeln(indent+"/** %s */", description)
+	}
+}
+
+func buildJavaException(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+
+	w.Writeln("/** Exception that is thrown when a call into %s fails */", NameSpace)
+	w.Writeln("public class %sException extends RuntimeException {", NameSpace)
+	w.Writeln("")
+	w.Writeln("  private static final long serialVersionUID = 1L;")
+	w.Writeln("")
+	w.Writeln("  public static final int SUCCESS = 0;")
+	for _, merror := range component.Errors.Errors {
+		writeJavaDocComment(w, "  ", merror.Description)
+		w.Writeln("  public static final int %s = %d;", merror.Name, merror.Code)
+	}
+	w.Writeln("")
+	w.Writeln("  private final int errorCode;")
+	w.Writeln("  private final String errorMessage;")
+	w.Writeln("")
+	w.Writeln("  public %sException(int errorCode, String errorMessage) {", NameSpace)
+	w.Writeln("    super(\"%sException \" + errorCode + \" (\" + getErrorName(errorCode) + \")\" + ((errorMessage != null && !errorMessage.isEmpty()) ? \": \" + errorMessage : \"\"));", NameSpace)
+	w.Writeln("    this.errorCode = errorCode;")
+	w.Writeln("    this.errorMessage = (errorMessage != null) ? errorMessage : \"\";")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  public %sException(int errorCode) {", NameSpace)
+	w.Writeln("    this(errorCode, \"\");")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the error code of the library */")
+	w.Writeln("  public int getErrorCode() {")
+	w.Writeln("    return errorCode;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the error message the library recorded, which may be empty */")
+	w.Writeln("  public String getErrorMessage() {")
+	w.Writeln("    return errorMessage;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the name of the error code */")
+	w.Writeln("  public String getErrorName() {")
+	w.Writeln("    return getErrorName(errorCode);")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the description of the error code */")
+	w.Writeln("  public String getErrorDescription() {")
+	w.Writeln("    return getErrorDescription(errorCode);")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the name of an error code */")
+	w.Writeln("  public static String getErrorName(int errorCode) {")
+	w.Writeln("    switch (errorCode) {")
+	w.Writeln("      case SUCCESS: return \"SUCCESS\";")
+	for _, merror := range component.Errors.Errors {
+		w.Writeln("      case %s: return \"%s\";", merror.Name, merror.Name)
+	}
+	w.Writeln("      default: return \"UNKNOWN\";")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the description of an error code */")
+	w.Writeln("  public static String getErrorDescription(int errorCode) {")
+	w.Writeln("    switch (errorCode) {")
+	w.Writeln("      case SUCCESS: return \"success\";")
+	for _, merror := range component.Errors.Errors {
+		w.Writeln("      case %s: return %q;", merror.Name, merror.Description)
+	}
+	w.Writeln("      default: return \"unknown error\";")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("}")
+}
+
+func buildJavaEnum(enum ComponentDefinitionEnum, w LanguageWriter) {
+	w.Writeln("public enum %s {", enum.Name)
+	if len(enum.Options) == 0 {
+		w.Writeln("  ;")
+	}
+	for i, option := range enum.Options {
+		separator := ","
+		if i == len(enum.Options)-1 {
+			separator = ";"
+		}
+		w.Writeln("  %s(%d)%s", option.Name, option.Value, separator)
+	}
+	w.Writeln("")
+	w.Writeln("  private final int value;")
+	w.Writeln("")
+	w.Writeln("  %s(int value) {", enum.Name)
+	w.Writeln("    this.value = value;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the value of the option in the library */")
+	w.Writeln("  public int getValue() {")
+	w.Writeln("    return value;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Returns the option of a value of the library, or null if the value is unknown */")
+	w.Writeln("  public static %s fromValue(int value) {", enum.Name)
+	w.Writeln("    for (%s option : values()) {", enum.Name)
+	w.Writeln("      if (option.value == value) {")
+	w.Writeln("        return option;")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("    return null;")
+	w.Writeln("  }")
+	w.Writeln("}")
+}
+
+// getJavaUnalignedLayout returns the layout of a scalar type inside a packed struct
+func getJavaUnalignedLayout(layout string) string {
+	if layout == "JAVA_BYTE" || layout == "JAVA_BOOLEAN" {
+		return layout
+	}
+	return layout + "_UNALIGNED"
+}
+
+func buildJavaStruct(component ComponentDefinition, structinfo ComponentDefinitionStruct, w LanguageWriter) error {
+	w.Writeln("import java.lang.foreign.MemorySegment;")
+	w.Writeln("import java.lang.foreign.ValueLayout;")
+	w.Writeln("")
+
+	type javaMember struct {
+		Name        string
+		ElementType string
+		Layout      string
+		ElementSize string
+		Offset      string
+		IsEnum      bool
+	}
+	members := []javaMember{}
+
+	constantBytes := 0
+	pointerCount := 0
+	offsetExpression := func() string {
+		if pointerCount == 0 {
+			return fmt.Sprintf("%d", constantBytes)
+		}
+		return fmt.Sprintf("%d + %d * ValueLayout.ADDRESS.byteSize()", constantBytes, pointerCount)
+	}
+
+	for _, member := range structinfo.Members {
+		javaMember := javaMember{Name: javaIdentifier(member.Name), Offset: offsetExpression()}
+		elementBytes := 0
+		elementPointers := 0
+		switch member.Type {
+		case "enum":
+			prefix, enumName, err := javaResolveClass(component, member.Class)
+			if err != nil {
+				return err
+			}
+			javaMember.ElementType = prefix + enumName
+			javaMember.Layout = "JAVA_INT_UNALIGNED"
+			javaMember.IsEnum = true
+			elementBytes = 4
+		default:
+			javaType, layout, size, err := getJavaScalarType(member.Type)
+			if err != nil {
+				return fmt.Errorf("invalid type \"%s\" of member %s.%s", member.Type, structinfo.Name, member.Name)
+			}
+			javaMember.ElementType = javaType
+			javaMember.Layout = getJavaUnalignedLayout(layout)
+			elementBytes = size
+			if member.Type == "pointer" {
+				elementPointers = 1
+			}
+		}
+		if elementPointers > 0 {
+			javaMember.ElementSize = "ValueLayout.ADDRESS.byteSize()"
+		} else {
+			javaMember.ElementSize = fmt.Sprintf("%d", elementBytes)
+		}
+
+		count := 1
+		if member.Rows > 0 {
+			count = member.Rows
+			if member.Columns > 0 {
+				count = member.Rows * member.Columns
+			}
+		}
+		constantBytes += count * elementBytes
+		pointerCount += count * elementPointers
+
+		members = append(members, javaMember)
+	}
+
+	w.Writeln("/** The struct %s, which is laid out without padding in the memory of the library */", structinfo.Name)
+	w.Writeln("public final class %s {", structinfo.Name)
+	w.Writeln("")
+	w.Writeln("  /** Size of the struct in the memory of the library */")
+	w.Writeln("  public static final long SIZE = %s;", offsetExpression())
+	w.Writeln("")
+	for i, member := range structinfo.Members {
+		w.Writeln("  private static final long OFFSET_%s = %s;", strings.ToUpper(member.Name), members[i].Offset)
+	}
+	if len(structinfo.Members) > 0 {
+		w.Writeln("")
+	}
+	for i, member := range structinfo.Members {
+		javaMember := members[i]
+		if member.Rows > 0 && member.Columns > 0 {
+			w.Writeln("  public %s[][] %s = new %s[%d][%d];", javaMember.ElementType, javaMember.Name, javaMember.ElementType, member.Columns, member.Rows)
+		} else if member.Rows > 0 {
+			w.Writeln("  public %s[] %s = new %s[%d];", javaMember.ElementType, javaMember.Name, javaMember.ElementType, member.Rows)
+		} else if member.Type == "pointer" {
+			w.Writeln("  public %s %s = MemorySegment.NULL;", javaMember.ElementType, javaMember.Name)
+		} else {
+			w.Writeln("  public %s %s;", javaMember.ElementType, javaMember.Name)
+		}
+	}
+	if len(structinfo.Members) > 0 {
+		w.Writeln("")
+	}
+
+	// readElement and writeElement return the code that accesses a single element of a member
+	readElement := func(javaMember javaMember, offset string) string {
+		value := fmt.Sprintf("segment.get(ValueLayout.%s, %s)", javaMember.Layout, offset)
+		if javaMember.IsEnum {
+			return fmt.Sprintf("%s.fromValue(%s)", javaMember.ElementType, value)
+		}
+		return value
+	}
+	writeElement := func(javaMember javaMember, offset string, value string) string {
+		if javaMember.IsEnum {
+			value = fmt.Sprintf("(%s != null) ? %s.getValue() : 0", value, value)
+		} else if javaMember.ElementType == "MemorySegment" {
+			value = fmt.Sprintf("(%s != null) ? %s : MemorySegment.NULL", value, value)
+		}
+		return fmt.Sprintf("segment.set(ValueLayout.%s, %s, %s);", javaMember.Layout, offset, value)
+	}
+
+	w.Writeln("  /** Reads a struct from the memory of the library */")
+	w.Writeln("  public static %s read(MemorySegment segment, long offset) {", structinfo.Name)
+	w.Writeln("    %s instance = new %s();", structinfo.Name, structinfo.Name)
+	for i, member := range structinfo.Members {
+		javaMember := members[i]
+		baseOffset := "offset + OFFSET_" + strings.ToUpper(member.Name)
+		if member.Rows > 0 && member.Columns > 0 {
+			w.Writeln("    for (int column = 0; column < %d; column++) {", member.Columns)
+			w.Writeln("      for (int row = 0; row < %d; row++) {", member.Rows)
+			w.Writeln("        instance.%s[column][row] = %s;", javaMember.Name, readElement(javaMember, fmt.Sprintf("%s + (column * %d + row) * %s", baseOffset, member.Rows, javaMember.ElementSize)))
+			w.Writeln("      }")
+			w.Writeln("    }")
+		} else if member.Rows > 0 {
+			w.Writeln("    for (int row = 0; row < %d; row++) {", member.Rows)
+			w.Writeln("      instance.%s[row] = %s;", javaMember.Name, readElement(javaMember, fmt.Sprintf("%s + row * %s", baseOffset, javaMember.ElementSize)))
+			w.Writeln("    }")
+		} else {
+			w.Writeln("    instance.%s = %s;", javaMember.Name, readElement(javaMember, baseOffset))
+		}
+	}
+	w.Writeln("    return instance;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Writes the struct into the memory of the library */")
+	w.Writeln("  public void write(MemorySegment segment, long offset) {")
+	for i, member := range structinfo.Members {
+		javaMember := members[i]
+		baseOffset := "offset + OFFSET_" + strings.ToUpper(member.Name)
+		if member.Rows > 0 && member.Columns > 0 {
+			w.Writeln("    for (int column = 0; column < %d; column++) {", member.Columns)
+			w.Writeln("      for (int row = 0; row < %d; row++) {", member.Rows)
+			w.Writeln("        %s", writeElement(javaMember, fmt.Sprintf("%s + (column * %d + row) * %s", baseOffset, member.Rows, javaMember.ElementSize), fmt.Sprintf("this.%s[column][row]", javaMember.Name)))
+			w.Writeln("      }")
+			w.Writeln("    }")
+		} else if member.Rows > 0 {
+			w.Writeln("    for (int row = 0; row < %d; row++) {", member.Rows)
+			w.Writeln("      %s", writeElement(javaMember, fmt.Sprintf("%s + row * %s", baseOffset, javaMember.ElementSize), fmt.Sprintf("this.%s[row]", javaMember.Name)))
+			w.Writeln("    }")
+		} else {
+			w.Writeln("    %s", writeElement(javaMember, baseOffset, "this."+javaMember.Name))
+		}
+	}
+	w.Writeln("  }")
+	w.Writeln("}")
+
+	return nil
+}
+
+// javaCallbackCode collects the code fragments that adapt the native parameters of a function type
+// to the parameters of its Java interface
+type javaCallbackCode struct {
+	InterfaceParams []string
+	NativeParams    []string
+	NativeCarriers  []string
+	PreInvoke       []string
+	InvokeArgs      []string
+	PostInvoke      []string
+}
+
+// generateJavaCallbackCode returns how the parameters of a function type are passed to its Java interface.
+// Scalars, enums, strings and structs that are passed in are converted, scalars that are passed out are
+// represented by arrays of length one. All other parameters are passed on as they are.
+func generateJavaCallbackCode(component ComponentDefinition, functiontype ComponentDefinitionFunctionType) (javaCallbackCode, error) {
+	code := javaCallbackCode{}
+	for _, param := range functiontype.Params {
+		name := javaIdentifier(param.ParamName)
+		abiParams, err := generateJavaABIParameters(param, functiontype.FunctionName, "")
+		if err != nil {
+			return code, err
+		}
+
+		addRaw := func() {
+			suffixes := []string{""}
+			if len(abiParams) == 3 {
+				suffixes = []string{"Size", "Needed", "Buffer"}
+			} else if len(abiParams) == 2 {
+				suffixes = []string{"Count", "Buffer"}
+			}
+			for i, abiParam := range abiParams {
+				code.InterfaceParams = append(code.InterfaceParams, fmt.Sprintf("%s %s%s", abiParam.Carrier, name, suffixes[i]))
+				code.NativeParams = append(code.NativeParams, fmt.Sprintf("%s %s%s", abiParam.Carrier, name, suffixes[i]))
+				code.NativeCarriers = append(code.NativeCarriers, abiParam.Carrier+".class")
+				code.InvokeArgs = append(code.InvokeArgs, name+suffixes[i])
+			}
+		}
+		addNative := func(javaType string) {
+			code.InterfaceParams = append(code.InterfaceParams, fmt.Sprintf("%s %s", javaType, name))
+			code.NativeParams = append(code.NativeParams, fmt.Sprintf("%s %s", abiParams[0].Carrier, name))
+			code.NativeCarriers = append(code.NativeCarriers, abiParams[0].Carrier+".class")
+		}
+
+		switch param.ParamPass {
+		case "in":
+			switch param.ParamType {
+			case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool":
+				javaType, _, _, err := getJavaScalarType(param.ParamType)
+				if err != nil {
+					return code, err
+				}
+				addNative(javaType)
+				code.InvokeArgs = append(code.InvokeArgs, name)
+			case "enum":
+				prefix, enumName, err := javaResolveClass(component, param.ParamClass)
+				if err != nil {
+					return code, err
+				}
+				addNative(prefix + enumName)
+				code.InvokeArgs = append(code.InvokeArgs, fmt.Sprintf("%s%s.fromValue(%s)", prefix, enumName, name))
+			case "string":
+				addNative("String")
+				code.InvokeArgs = append(code.InvokeArgs, fmt.Sprintf("(%s.address() != 0) ? %s.reinterpret(Long.MAX_VALUE).getString(0) : null", name, name))
+			case "struct":
+				prefix, structName, err := javaResolveClass(component, param.ParamClass)
+				if err != nil {
+					return code, err
+				}
+				addNative(prefix + structName)
+				code.InvokeArgs = append(code.InvokeArgs, fmt.Sprintf("(%s.address() != 0) ? %s%s.read(%s.reinterpret(%s%s.SIZE), 0) : null", name, prefix, structName, name, prefix, structName))
+			default:
+				addRaw()
+			}
+
+		case "out":
+			switch param.ParamType {
+			case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool":
+				javaType, layout, size, err := getJavaScalarType(param.ParamType)
+				if err != nil {
+					return code, err
+				}
+				addNative(javaType + "[]")
+				code.PreInvoke = append(code.PreInvoke, fmt.Sprintf("%s[] %sValue = new %s[1];", javaType, name, javaType))
+				code.PreInvoke = append(code.PreInvoke, fmt.Sprintf("if (%s.address() != 0) {", name))
+				code.PreInvoke = append(code.PreInvoke, fmt.Sprintf("  %sValue[0] = %s.reinterpret(%d).get(ValueLayout.%s, 0);", name, name, size, layout))
+				code.PreInvoke = append(code.PreInvoke, "}")
+				code.InvokeArgs = append(code.InvokeArgs, name+"Value")
+				code.PostInvoke = append(code.PostInvoke, fmt.Sprintf("if (%s.address() != 0) {", name))
+				code.PostInvoke = append(code.PostInvoke, fmt.Sprintf("  %s.reinterpret(%d).set(ValueLayout.%s, 0, %sValue[0]);", name, size, layout, name))
+				code.PostInvoke = append(code.PostInvoke, "}")
+			default:
+				addRaw()
+			}
+
+		default:
+			return code, fmt.Errorf("invalid parameter passing \"%s\" of %s (%s)", param.ParamPass, functiontype.FunctionName, param.ParamName)
+		}
+	}
+	return code, nil
+}
+
+func buildJavaFunctionType(component ComponentDefinition, functiontype ComponentDefinitionFunctionType, w LanguageWriter) error {
+	code, err := generateJavaCallbackCode(component, functiontype)
+	if err != nil {
+		return err
+	}
+
+	w.Writeln("import java.lang.foreign.MemorySegment;")
+	w.Writeln("")
+	description := functiontype.FunctionDescription
+	if len(description) == 0 {
+		description = "The callback " + functiontype.FunctionName
+	}
+	writeJavaDocComment(w, "", description)
+	w.Writeln("@FunctionalInterface")
+	w.Writeln("public interface %s {", functiontype.FunctionName)
+	w.Writeln("  void invoke(%s);", strings.Join(code.InterfaceParams, ", "))
+	w.Writeln("}")
+	return nil
+}
+
+// writeJavaCallbackFactory writes the method that turns an implementation of a function type into a
+// function pointer, and the static method that the function pointer calls
+func writeJavaCallbackFactory(component ComponentDefinition, functiontype ComponentDefinitionFunctionType, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	FunctionName := functiontype.FunctionName
+
+	code, err := generateJavaCallbackCode(component, functiontype)
+	if err != nil {
+		return err
+	}
+	descriptor, err := getJavaFunctionDescriptor(functiontype.Params, FunctionName, "", false, true)
+	if err != nil {
+		return err
+	}
+
+	w.Writeln("  private static void invoke%s(%s) {", FunctionName, strings.Join(append([]string{FunctionName + " callback"}, code.NativeParams...), ", "))
+	w.Writeln("    try {")
+	w.Writelns("      ", code.PreInvoke)
+	w.Writeln("      callback.invoke(%s);", strings.Join(code.InvokeArgs, ", "))
+	w.Writelns("      ", code.PostInvoke)
+	w.Writeln("    } catch (Throwable exception) {")
+	w.Writeln("      // Exceptions must not propagate into the library")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Creates a function pointer that calls a %s. It remains valid as long as it is referenced. */", FunctionName)
+	w.Writeln("  public static MemorySegment create%s(%s callback) {", FunctionName, FunctionName)
+	w.Writeln("    if (callback == null) {")
+	w.Writeln("      return MemorySegment.NULL;")
+	w.Writeln("    }")
+	w.Writeln("    try {")
+	w.Writeln("      MethodHandle target = MethodHandles.lookup().findStatic(%sWrapper.class, \"invoke%s\",", NameSpace, FunctionName)
+	w.Writeln("        MethodType.methodType(%s));", strings.Join(append([]string{"void.class", FunctionName + ".class"}, code.NativeCarriers...), ", "))
+	w.Writeln("      return LINKER.upcallStub(MethodHandles.insertArguments(target, 0, callback), %s, Arena.ofAuto());", descriptor)
+	w.Writeln("    } catch (ReflectiveOperationException exception) {")
+	w.Writeln("      throw new %sException(%sException.GENERICEXCEPTION, exception.toString());", NameSpace, NameSpace)
+	w.Writeln("    }")
+	w.Writeln("  }")
+	return nil
+}
+
+// javaWrapperClassName returns the qualified name of the wrapper class that a param class belongs to
+func javaWrapperClassName(component ComponentDefinition, paramClass string) (string, error) {
+	namespace, _, err := decomposeParamClassName(paramClass)
+	if err != nil {
+		return "", err
+	}
+	if len(namespace) == 0 || namespace == component.NameSpace {
+		return component.NameSpace + "Wrapper", nil
+	}
+	subComponent, ok := component.ImportedComponentDefinitions[namespace]
+	if !ok {
+		return "", fmt.Errorf("unknown namespace \"%s\" of class \"%s\"", namespace, paramClass)
+	}
+	return javaPackageName(subComponent) + "." + subComponent.NameSpace + "Wrapper", nil
+}
+
+// getJavaMethodName returns the Java name of a method. Static methods are written into the wrapper and are
+// prefixed with the name of their class.
+func getJavaMethodName(ClassName string, method ComponentDefinitionMethod) string {
+	if method.IsStatic {
+		return javaIdentifier(ClassName + method.MethodName)
+	}
+	return javaIdentifier(method.MethodName)
+}
+
+// getJavaResultRecordName returns the name of the record that holds the results of a method with several outputs
+func getJavaResultRecordName(ClassName string, method ComponentDefinitionMethod) string {
+	if method.IsStatic {
+		return ClassName + method.MethodName + "Result"
+	}
+	return method.MethodName + "Result"
+}
+
+// getJavaReturnType returns the Java return type of a method
+func getJavaReturnType(ClassName string, method ComponentDefinitionMethod, code javaMethodCode) string {
+	switch len(code.ReturnTypes) {
+	case 0:
+		return "void"
+	case 1:
+		return code.ReturnTypes[0]
+	}
+	return getJavaResultRecordName(ClassName, method)
+}
+
+// generateJavaMethodCode generates the code fragments of a method of a class or of the wrapper
+func generateJavaMethodCode(component ComponentDefinition, method ComponentDefinitionMethod, ClassName string, isGlobal bool) (javaMethodCode, error) {
+	NameSpace := component.NameSpace
+	code := javaMethodCode{}
+
+	wrapperPrefix := "wrapper."
+	wrapperReference := "wrapper"
+	if isGlobal || method.IsStatic {
+		wrapperPrefix = ""
+		wrapperReference = "this"
+	}
+
+	for _, param := range method.Params {
+		name := javaIdentifier(param.ParamName)
+
+		typeName := ""
+		layout := ""
+		prefix := ""
+		if param.ParamType == "basicarray" {
+			javaType, scalarLayout, _, err := getJavaScalarType(param.ParamClass)
+			if err != nil {
+				return code, err
+			}
+			typeName = javaType
+			layout = scalarLayout
+		} else if isScalarType(param.ParamType) {
+			javaType, scalarLayout, _, err := getJavaScalarType(param.ParamType)
+			if err != nil {
+				return code, err
+			}
+			typeName = javaType
+			layout = scalarLayout
+		} else if param.ParamType != "string" {
+			classPrefix, className, err := javaResolveClass(component, param.ParamClass)
+			if err != nil {
+				return code, err
+			}
+			prefix = classPrefix
+			typeName = classPrefix + className
+		}
+
+		switch param.ParamPass {
+		case "in":
+			code.Signature = append(code.Signature, fmt.Sprintf("%s %s", typeName, name))
+			switch param.ParamType {
+			case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool":
+				code.CallArgs = append(code.CallArgs, name)
+
+			case "pointer":
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("(%s != null) ? %s : MemorySegment.NULL", name, name))
+
+			case "enum":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("if (%s == null) {", name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("  throw new %sException(%sException.INVALIDPARAM, \"%s must not be null\");", NameSpace, NameSpace, name))
+				code.PreCall = append(code.PreCall, "}")
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s.getValue()", name))
+
+			case "string":
+				code.Signature[len(code.Signature)-1] = fmt.Sprintf("String %s", name)
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocateFrom(%s);", name, name))
+				code.CallArgs = append(code.CallArgs, name+"Segment")
+
+			case "struct":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(%s.SIZE);", name, typeName))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("%s.write(%sSegment, 0);", name, name))
+				code.CallArgs = append(code.CallArgs, name+"Segment")
+
+			case "basicarray":
+				code.Signature[len(code.Signature)-1] = fmt.Sprintf("%s[] %s", typeName, name)
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(ValueLayout.%s, %s.length);", name, layout, name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("for (int index = 0; index < %s.length; index++) {", name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("  %sSegment.setAtIndex(ValueLayout.%s, index, %s[index]);", name, layout, name))
+				code.PreCall = append(code.PreCall, "}")
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("(long) %s.length", name), name+"Segment")
+
+			case "structarray":
+				code.Signature[len(code.Signature)-1] = fmt.Sprintf("%s[] %s", typeName, name)
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(%s.SIZE * %s.length);", name, typeName, name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("for (int index = 0; index < %s.length; index++) {", name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("  %s[index].write(%sSegment, index * %s.SIZE);", name, name, typeName))
+				code.PreCall = append(code.PreCall, "}")
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("(long) %s.length", name), name+"Segment")
+
+			case "class":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("if (%s == null) {", name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("  throw new %sException(%sException.INVALIDPARAM, \"%s must not be null\");", NameSpace, NameSpace, name))
+				code.PreCall = append(code.PreCall, "}")
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s.handle()", name))
+
+			case "optionalclass":
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("(%s != null) ? %s.handle() : MemorySegment.NULL", name, name))
+
+			case "functiontype":
+				wrapperClass, err := javaWrapperClassName(component, param.ParamClass)
+				if err != nil {
+					return code, err
+				}
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sCallback = %s.create%s(%s);", name, wrapperClass, typeName[len(prefix):], name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("retainCallback(%sCallback);", name))
+				code.CallArgs = append(code.CallArgs, name+"Callback")
+
+			default:
+				return code, fmt.Errorf("invalid method parameter type \"%s\" for %s.%s (%s)", param.ParamType, ClassName, method.MethodName, param.ParamName)
+			}
+
+		case "out", "return":
+			code.ReturnNames = append(code.ReturnNames, name)
+			code.ReturnTypes = append(code.ReturnTypes, typeName)
+			code.ReturnValues = append(code.ReturnValues, name)
+			switch param.ParamType {
+			case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool", "pointer":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(ValueLayout.%s);", name, layout))
+				code.CallArgs = append(code.CallArgs, name+"Segment")
+				code.CheckArgs = append(code.CheckArgs, name+"Segment")
+				code.PostCall = append(code.PostCall, fmt.Sprintf("%s %s = %sSegment.get(ValueLayout.%s, 0);", typeName, name, name, layout))
+
+			case "enum":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(ValueLayout.JAVA_INT);", name))
+				code.CallArgs = append(code.CallArgs, name+"Segment")
+				code.CheckArgs = append(code.CheckArgs, name+"Segment")
+				code.PostCall = append(code.PostCall, fmt.Sprintf("%s %s = %s.fromValue(%sSegment.get(ValueLayout.JAVA_INT, 0));", typeName, name, typeName, name))
+				code.PostCall = append(code.PostCall, fmt.Sprintf("if (%s == null) {", name))
+				code.PostCall = append(code.PostCall, fmt.Sprintf("  throw new %sException(%sException.INVALIDCAST, \"invalid value of %s\");", NameSpace, NameSpace, name))
+				code.PostCall = append(code.PostCall, "}")
+
+			case "struct":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(%s.SIZE);", name, typeName))
+				code.CallArgs = append(code.CallArgs, name+"Segment")
+				code.CheckArgs = append(code.CheckArgs, name+"Segment")
+				code.PostCall = append(code.PostCall, fmt.Sprintf("%s %s = %s.read(%sSegment, 0);", typeName, name, typeName, name))
+
+			case "string":
+				code.ReturnTypes[len(code.ReturnTypes)-1] = "String"
+				code.DoCheckCall = true
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sNeeded = arena.allocate(ValueLayout.JAVA_INT);", name))
+				code.CheckArgs = append(code.CheckArgs, "0", name+"Needed", "MemorySegment.NULL")
+				code.CheckCall = append(code.CheckCall, fmt.Sprintf("int %sSize = %sNeeded.get(ValueLayout.JAVA_INT, 0);", name, name))
+				code.CheckCall = append(code.CheckCall, fmt.Sprintf("MemorySegment %sBuffer = arena.allocate(Math.max(%sSize, 1));", name, name))
+				code.CallArgs = append(code.CallArgs, name+"Size", name+"Needed", name+"Buffer")
+				code.PostCall = append(code.PostCall, fmt.Sprintf("String %s = %sBuffer.getString(0);", name, name))
+
+			case "basicarray", "structarray":
+				code.ReturnTypes[len(code.ReturnTypes)-1] = typeName + "[]"
+				code.DoCheckCall = true
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sNeeded = arena.allocate(ValueLayout.JAVA_LONG);", name))
+				code.CheckArgs = append(code.CheckArgs, "0L", name+"Needed", "MemorySegment.NULL")
+				code.CheckCall = append(code.CheckCall, fmt.Sprintf("long %sCount = %sNeeded.get(ValueLayout.JAVA_LONG, 0);", name, name))
+				if param.ParamType == "basicarray" {
+					code.CheckCall = append(code.CheckCall, fmt.Sprintf("MemorySegment %sBuffer = arena.allocate(ValueLayout.%s, Math.max(%sCount, 1));", name, layout, name))
+				} else {
+					code.CheckCall = append(code.CheckCall, fmt.Sprintf("MemorySegment %sBuffer = arena.allocate(%s.SIZE * Math.max(%sCount, 1));", name, typeName, name))
+				}
+				code.CallArgs = append(code.CallArgs, name+"Count", name+"Needed", name+"Buffer")
+				code.PostCall = append(code.PostCall, fmt.Sprintf("%s[] %s = new %s[(int) Math.min(%sCount, %sNeeded.get(ValueLayout.JAVA_LONG, 0))];", typeName, name, typeName, name, name))
+				code.PostCall = append(code.PostCall, fmt.Sprintf("for (int index = 0; index < %s.length; index++) {", name))
+				if param.ParamType == "basicarray" {
+					code.PostCall = append(code.PostCall, fmt.Sprintf("  %s[index] = %sBuffer.getAtIndex(ValueLayout.%s, index);", name, name, layout))
+				} else {
+					code.PostCall = append(code.PostCall, fmt.Sprintf("  %s[index] = %s.read(%sBuffer, index * %s.SIZE);", name, typeName, name, typeName))
+				}
+				code.PostCall = append(code.PostCall, "}")
+
+			case "class", "optionalclass":
+				implName := typeName + "Impl"
+				classWrapper := wrapperReference
+				if prefix != "" {
+					namespace, _, err := decomposeParamClassName(param.ParamClass)
+					if err != nil {
+						return code, err
+					}
+					wrapperClass, err := javaWrapperClassName(component, param.ParamClass)
+					if err != nil {
+						return code, err
+					}
+					classWrapper = name + "Wrapper"
+					code.PreCall = append(code.PreCall, fmt.Sprintf("%s %s = %sget%sWrapper();", wrapperClass, classWrapper, wrapperPrefix, namespace))
+				}
+				code.PreCall = append(code.PreCall, fmt.Sprintf("MemorySegment %sSegment = arena.allocate(ValueLayout.ADDRESS);", name))
+				code.CallArgs = append(code.CallArgs, name+"Segment")
+				code.CheckArgs = append(code.CheckArgs, name+"Segment")
+				code.PostCall = append(code.PostCall, fmt.Sprintf("MemorySegment %sHandle = %sSegment.get(ValueLayout.ADDRESS, 0);", name, name))
+				if param.ParamType == "class" {
+					code.PostCall = append(code.PostCall, fmt.Sprintf("if (%sHandle.address() == 0) {", name))
+					code.PostCall = append(code.PostCall, fmt.Sprintf("  throw new %sException(%sException.INVALIDCAST, \"%s is null\");", NameSpace, NameSpace, name))
+					code.PostCall = append(code.PostCall, "}")
+					code.PostCall = append(code.PostCall, fmt.Sprintf("%s %s = new %s(%sHandle, %s);", typeName, name, implName, name, classWrapper))
+				} else {
+					code.PostCall = append(code.PostCall, fmt.Sprintf("%s %s = (%sHandle.address() != 0) ? new %s(%sHandle, %s) : null;", typeName, name, name, implName, name, classWrapper))
+				}
+
+			default:
+				return code, fmt.Errorf("invalid method parameter type \"%s\" for %s.%s (%s)", param.ParamType, ClassName, method.MethodName, param.ParamName)
+			}
+
+		default:
+			return code, fmt.Errorf("invalid method parameter passing \"%s\" for %s.%s (%s)", param.ParamPass, ClassName, method.MethodName, param.ParamName)
+		}
+
+		if param.ParamPass == "in" {
+			abiParams, err := generateJavaABIParameters(param, ClassName, method.MethodName)
+			if err != nil {
+				return code, err
+			}
+			code.CheckArgs = append(code.CheckArgs, code.CallArgs[len(code.CallArgs)-len(abiParams):]...)
+		}
+	}
+
+	return code, nil
+}
+
+// writeJavaMethod writes the implementation of a method of a class or of the wrapper
+func writeJavaMethod(component ComponentDefinition, method ComponentDefinitionMethod, w LanguageWriter, ClassName string, isGlobal bool, postCallLines []string) error {
+	NameSpace := component.NameSpace
+	code, err := generateJavaMethodCode(component, method, ClassName, isGlobal)
+	if err != nil {
+		return err
+	}
+
+	// Global and static methods are written into the wrapper and do not pass a handle
+	isWrapperMethod := isGlobal || method.IsStatic
+	wrapperPrefix := "wrapper."
+	instance := "this"
+	callPrefix := []string{"handle()"}
+	if isWrapperMethod {
+		wrapperPrefix = ""
+		instance = "null"
+		callPrefix = []string{}
+	}
+	methodHandle := getJavaMethodHandleName(NameSpace, ClassName, method, isGlobal)
+
+	if isWrapperMethod {
+		writeJavaDocComment(w, "  ", method.MethodDescription)
+	} else {
+		w.Writeln("  @Override")
+	}
+	w.Writeln("  public %s %s(%s) {", getJavaReturnType(ClassName, method, code), getJavaMethodName(ClassName, method), strings.Join(code.Signature, ", "))
+	w.Writeln("    try (Arena arena = Arena.ofConfined()) {")
+	w.Writelns("      ", code.PreCall)
+	if code.DoCheckCall {
+		w.Writeln("      %scheckError(%s, %scall(%s%s));", wrapperPrefix, instance, wrapperPrefix, wrapperPrefix, strings.Join(append([]string{methodHandle}, append(callPrefix, code.CheckArgs...)...), ", "))
+		w.Writelns("      ", code.CheckCall)
+	}
+	w.Writeln("      %scheckError(%s, %scall(%s%s));", wrapperPrefix, instance, wrapperPrefix, wrapperPrefix, strings.Join(append([]string{methodHandle}, append(callPrefix, code.CallArgs...)...), ", "))
+	w.Writelns("      ", code.PostCall)
+	w.Writelns("      ", postCallLines)
+	switch len(code.ReturnValues) {
+	case 0:
+	case 1:
+		w.Writeln("      return %s;", code.ReturnValues[0])
+	default:
+		w.Writeln("      return new %s(%s);", getJavaResultRecordName(ClassName, method), strings.Join(code.ReturnValues, ", "))
+	}
+	w.Writeln("    }")
+	w.Writeln("  }")
+	return nil
+}
+
+// writeJavaResultRecord writes the record that holds the results of a method with several outputs
+func writeJavaResultRecord(component ComponentDefinition, method ComponentDefinitionMethod, w LanguageWriter, ClassName string, isGlobal bool) error {
+	code, err := generateJavaMethodCode(component, method, ClassName, isGlobal)
+	if err != nil {
+		return err
+	}
+	if len(code.ReturnTypes) < 2 {
+		return nil
+	}
+	components := []string{}
+	for i := range code.ReturnTypes {
+		components = append(components, fmt.Sprintf("%s %s", code.ReturnTypes[i], code.ReturnNames[i]))
+	}
+	modifier := ""
+	if isGlobal || method.IsStatic {
+		modifier = "public "
+	}
+	w.Writeln("  /** The results of %s */", getJavaMethodName(ClassName, method))
+	w.Writeln("  %srecord %s(%s) {}", modifier, getJavaResultRecordName(ClassName, method), strings.Join(components, ", "))
+	w.Writeln("")
+	return nil
+}
+
+func buildJavaWrapper(component ComponentDefinition, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	global := component.Global
+	namespaces := component.importedNameSpaces()
+
+	w.Writeln("import java.lang.foreign.Arena;")
+	w.Writeln("import java.lang.foreign.FunctionDescriptor;")
+	w.Writeln("import java.lang.foreign.Linker;")
+	w.Writeln("import java.lang.foreign.MemorySegment;")
+	w.Writeln("import java.lang.foreign.SymbolLookup;")
+	w.Writeln("import java.lang.foreign.ValueLayout;")
+	w.Writeln("import java.lang.invoke.MethodHandle;")
+	w.Writeln("import java.lang.invoke.MethodHandles;")
+	w.Writeln("import java.lang.invoke.MethodType;")
+	w.Writeln("import java.util.ArrayList;")
+	w.Writeln("import java.util.List;")
+	w.Writeln("import java.util.function.Function;")
+	w.Writeln("")
+	w.Writeln("/** Loads %s and provides its global methods */", NameSpace)
+	w.Writeln("public class %sWrapper {", NameSpace)
+	w.Writeln("")
+	w.Writeln("  /** Version of the interface the bindings have been generated for */")
+	w.Writeln("  public static final int BINDING_VERSION_MAJOR = %d;", majorVersion(component.Version))
+	w.Writeln("  public static final int BINDING_VERSION_MINOR = %d;", minorVersion(component.Version))
+	w.Writeln("  public static final int BINDING_VERSION_MICRO = %d;", microVersion(component.Version))
+	w.Writeln("")
+	w.Writeln("  private static final Linker LINKER = Linker.nativeLinker();")
+	w.Writeln("")
+
+	type javaExport struct {
+		Name       string
+		Descriptor string
+	}
+	exports := []javaExport{}
+	for _, method := range global.Methods {
+		descriptor, err := getJavaFunctionDescriptor(method.Params, "", method.MethodName, false, false)
+		if err != nil {
+			return err
+		}
+		exports = append(exports, javaExport{getJavaMethodHandleName(NameSpace, "", method, true), descriptor})
+	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			descriptor, err := getJavaFunctionDescriptor(method.Params, class.ClassName, method.MethodName, !method.IsStatic, false)
+			if err != nil {
+				return err
+			}
+			exports = append(exports, javaExport{getJavaMethodHandleName(NameSpace, class.ClassName, method, false), descriptor})
+		}
+	}
+
+	for _, export := range exports {
+		w.Writeln("  final MethodHandle %s;", export.Name)
+	}
+	w.Writeln("")
+	w.Writeln("  // Keeps the library loaded as long as the wrapper is referenced")
+	w.Writeln("  private final Function<String, MemorySegment> symbolLookup;")
+	w.Writeln("  private final List<MemorySegment> callbacks = new ArrayList<>();")
+	for _, namespace := range namespaces {
+		subComponent := component.ImportedComponentDefinitions[namespace]
+		w.Writeln("  private %s.%sWrapper %sWrapper;", javaPackageName(subComponent), namespace, javaIdentifier(namespace))
+	}
+	w.Writeln("")
+	w.Writeln("  /** Loads the library from a shared library file */")
+	w.Writeln("  public %sWrapper(String libraryPath) {", NameSpace)
+	w.Writeln("    this(libraryLookup(libraryPath));")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  private %sWrapper(Function<String, MemorySegment> symbolLookup) {", NameSpace)
+	w.Writeln("    this.symbolLookup = symbolLookup;")
+	for _, export := range exports {
+		w.Writeln("    %s = resolve(symbolLookup, \"%s\", %s);", export.Name, export.Name, export.Descriptor)
+	}
+	w.Writeln("    checkBinaryVersion();")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Uses a library that has already been loaded, through the address of its symbol lookup method */")
+	w.Writeln("  public static %sWrapper fromSymbolLookupMethod(MemorySegment symbolLookupMethod) {", NameSpace)
+	w.Writeln("    if (symbolLookupMethod == null || symbolLookupMethod.address() == 0) {")
+	w.Writeln("      throw new %sException(%sException.INVALIDPARAM, \"the symbol lookup method is null\");", NameSpace, NameSpace)
+	w.Writeln("    }")
+	w.Writeln("    MethodHandle lookupMethod = LINKER.downcallHandle(symbolLookupMethod,")
+	w.Writeln("      FunctionDescriptor.of(ValueLayout.JAVA_INT, ValueLayout.ADDRESS, ValueLayout.ADDRESS));")
+	w.Writeln("    return new %sWrapper(name -> {", NameSpace)
+	w.Writeln("      try (Arena arena = Arena.ofConfined()) {")
+	w.Writeln("        MemorySegment address = arena.allocate(ValueLayout.ADDRESS);")
+	w.Writeln("        int errorCode = (int) lookupMethod.invokeExact(arena.allocateFrom(name), address);")
+	w.Writeln("        if (errorCode != %sException.SUCCESS) {", NameSpace)
+	w.Writeln("          return MemorySegment.NULL;")
+	w.Writeln("        }")
+	w.Writeln("        return address.get(ValueLayout.ADDRESS, 0);")
+	w.Writeln("      } catch (Throwable exception) {")
+	w.Writeln("        return MemorySegment.NULL;")
+	w.Writeln("      }")
+	w.Writeln("    });")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  private static Function<String, MemorySegment> libraryLookup(String libraryPath) {")
+	w.Writeln("    SymbolLookup library;")
+	w.Writeln("    try {")
+	w.Writeln("      library = SymbolLookup.libraryLookup(libraryPath, Arena.ofAuto());")
+	w.Writeln("    } catch (IllegalArgumentException exception) {")
+	w.Writeln("      throw new %sException(%sException.COULDNOTLOADLIBRARY, exception.getMessage());", NameSpace, NameSpace)
+	w.Writeln("    }")
+	w.Writeln("    return name -> library.find(name).orElse(MemorySegment.NULL);")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  private static MethodHandle resolve(Function<String, MemorySegment> symbolLookup, String name, FunctionDescriptor descriptor) {")
+	w.Writeln("    MemorySegment address = symbolLookup.apply(name);")
+	w.Writeln("    if (address.address() == 0) {")
+	w.Writeln("      throw new %sException(%sException.COULDNOTFINDLIBRARYEXPORT, name);", NameSpace, NameSpace)
+	w.Writeln("    }")
+	w.Writeln("    return LINKER.downcallHandle(address, descriptor);")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Calls an exported function of the library and returns its error code */")
+	w.Writeln("  int call(MethodHandle function, Object... arguments) {")
+	w.Writeln("    try {")
+	w.Writeln("      return (int) function.invokeWithArguments(arguments);")
+	w.Writeln("    } catch (Throwable exception) {")
+	w.Writeln("      throw new %sException(%sException.GENERICEXCEPTION, exception.toString());", NameSpace, NameSpace)
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+
+	errorMethod := ComponentDefinitionMethod{MethodName: global.ErrorMethod}
+	releaseMethod := ComponentDefinitionMethod{MethodName: global.ReleaseMethod}
+	w.Writeln("  /** Throws an exception that carries the last error message of an instance if an error code is not SUCCESS */")
+	w.Writeln("  void checkError(%s instance, int errorCode) {", global.BaseClassName)
+	w.Writeln("    if (errorCode == %sException.SUCCESS) {", NameSpace)
+	w.Writeln("      return;")
+	w.Writeln("    }")
+	w.Writeln("    String message = \"\";")
+	w.Writeln("    if (instance != null) {")
+	w.Writeln("      try (Arena arena = Arena.ofConfined()) {")
+	w.Writeln("        MemorySegment neededChars = arena.allocate(ValueLayout.JAVA_INT);")
+	w.Writeln("        MemorySegment hasError = arena.allocate(ValueLayout.JAVA_BOOLEAN);")
+	w.Writeln("        MemorySegment handle = instance.handle();")
+	w.Writeln("        if (call(%s, handle, 0, neededChars, MemorySegment.NULL, hasError) == %sException.SUCCESS && hasError.get(ValueLayout.JAVA_BOOLEAN, 0)) {", getJavaMethodHandleName(NameSpace, "", errorMethod, true), NameSpace)
+	w.Writeln("          int size = neededChars.get(ValueLayout.JAVA_INT, 0);")
+	w.Writeln("          MemorySegment buffer = arena.allocate(Math.max(size, 1));")
+	w.Writeln("          if (call(%s, handle, size, neededChars, buffer, hasError) == %sException.SUCCESS) {", getJavaMethodHandleName(NameSpace, "", errorMethod, true), NameSpace)
+	w.Writeln("            message = buffer.getString(0);")
+	w.Writeln("          }")
+	w.Writeln("        }")
+	w.Writeln("      } catch (%sException exception) {", NameSpace)
+	w.Writeln("        // The error code is more important than a missing message")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("    throw new %sException(errorCode, message);", NameSpace)
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Releases a handle that is owned by an instance */")
+	w.Writeln("  void releaseHandle(MemorySegment handle) {")
+	w.Writeln("    call(%s, handle);", getJavaMethodHandleName(NameSpace, "", releaseMethod, true))
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /** Keeps a function pointer alive as long as the wrapper is referenced */")
+	w.Writeln("  void retainCallback(MemorySegment callback) {")
+	w.Writeln("    callbacks.add(callback);")
+	w.Writeln("  }")
+
+	for _, namespace := range namespaces {
+		subComponent := component.ImportedComponentDefinitions[namespace]
+		field := javaIdentifier(namespace) + "Wrapper"
+		w.Writeln("")
+		w.Writeln("  /** Returns the wrapper of %s, which has to be injected first */", namespace)
+		w.Writeln("  public %s.%sWrapper get%sWrapper() {", javaPackageName(subComponent), namespace, namespace)
+		w.Writeln("    if (%s == null) {", field)
+		w.Writeln("      throw new %sException(%sException.COULDNOTLOADLIBRARY, \"%s has not been injected\");", NameSpace, NameSpace, namespace)
+		w.Writeln("    }")
+		w.Writeln("    return %s;", field)
+		w.Writeln("  }")
+	}
+
+	versionCheck := false
+	for _, method := range global.Methods {
+		isSpecialFunction, err := CheckHeaderSpecialFunction(method, global)
+		if err != nil {
+			return err
+		}
+		if isSpecialFunction == eSpecialMethodRelease || isSpecialFunction == eSpecialMethodAcquire {
+			// Ownership of instances is handled by close
+			continue
+		}
+		if isSpecialFunction == eSpecialMethodVersion {
+			versionCheck = true
+			code, err := generateJavaMethodCode(component, method, "", true)
+			if err != nil {
+				return err
+			}
+			w.Writeln("")
+			w.Writeln("  private void checkBinaryVersion() {")
+			w.Writeln("    %s version = %s();", getJavaResultRecordName("", method), javaIdentifier(method.MethodName))
+			w.Writeln("    if (version.%s() != BINDING_VERSION_MAJOR || version.%s() < BINDING_VERSION_MINOR) {", code.ReturnNames[0], code.ReturnNames[1])
+			w.Writeln("      throw new %sException(%sException.INCOMPATIBLEBINARYVERSION);", NameSpace, NameSpace)
+			w.Writeln("    }")
+			w.Writeln("  }")
+		}
+
+		postCallLines := []string{}
+		if isSpecialFunction == eSpecialMethodInjection {
+			nameSpaceParam := javaIdentifier(method.Params[0].ParamName)
+			addressParam := javaIdentifier(method.Params[1].ParamName)
+			postCallLines = append(postCallLines, fmt.Sprintf("switch (%s) {", nameSpaceParam))
+			for _, namespace := range namespaces {
+				subComponent := component.ImportedComponentDefinitions[namespace]
+				subPackage := javaPackageName(subComponent)
+				field := javaIdentifier(namespace) + "Wrapper"
+				postCallLines = append(postCallLines, fmt.Sprintf("  case \"%s\":", namespace))
+				postCallLines = append(postCallLines, fmt.Sprintf("    if (%s != null) {", field))
+				postCallLines = append(postCallLines, fmt.Sprintf("      throw new %sException(%sException.COULDNOTLOADLIBRARY, \"%s has already been injected\");", NameSpace, NameSpace, namespace))
+				postCallLines = append(postCallLines, "    }")
+				postCallLines = append(postCallLines, "    try {")
+				postCallLines = append(postCallLines, fmt.Sprintf("      %s = %s.%sWrapper.fromSymbolLookupMethod(%s);", field, subPackage, namespace, addressParam))
+				postCallLines = append(postCallLines, fmt.Sprintf("    } catch (%s.%sException exception) {", subPackage, namespace))
+				postCallLines = append(postCallLines, fmt.Sprintf("      throw new %sException(exception.getErrorCode(), exception.getErrorMessage());", NameSpace))
+				postCallLines = append(postCallLines, "    }")
+				postCallLines = append(postCallLines, "    break;")
+			}
+			postCallLines = append(postCallLines, "  default:")
+			postCallLines = append(postCallLines, fmt.Sprintf("    throw new %sException(%sException.COULDNOTLOADLIBRARY, \"unknown namespace \" + %s);", NameSpace, NameSpace, nameSpaceParam))
+			postCallLines = append(postCallLines, "}")
+		}
+
+		w.Writeln("")
+		err = writeJavaResultRecord(component, method, w, "", true)
+		if err != nil {
+			return err
+		}
+		err = writeJavaMethod(component, method, w, "", true, postCallLines)
+		if err != nil {
+			return err
+		}
+	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			if !method.IsStatic {
+				continue
+			}
+			w.Writeln("")
+			err := writeJavaResultRecord(component, method, w, class.ClassName, false)
+			if err != nil {
+				return err
+			}
+			err = writeJavaMethod(component, method, w, class.ClassName, false, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if !versionCheck {
+		w.Writeln("")
+		w.Writeln("  private void checkBinaryVersion() {")
+		w.Writeln("  }")
+	}
+
+	for _, functiontype := range component.Functions {
+		w.Writeln("")
+		err := writeJavaCallbackFactory(component, functiontype, w)
+		if err != nil {
+			return err
+		}
+	}
+	w.Writeln("}")
+	return nil
+}
+
+func buildJavaClassInterface(component ComponentDefinition, class ComponentDefinitionClass, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	ClassName := class.ClassName
+
+	w.Writeln("import java.lang.foreign.MemorySegment;")
+	w.Writeln("")
+	description := class.ClassDescription
+	if len(description) == 0 {
+		description = fmt.Sprintf("The class %s of %s", ClassName, NameSpace)
+	}
+	writeJavaDocComment(w, "", description)
+	parentName := component.parentClassName(class)
+	if parentName == "" {
+		w.Writeln("public interface %s extends AutoCloseable {", ClassName)
+		w.Writeln("")
+		w.Writeln("  /** Returns the raw handle of the instance */")
+		w.Writeln("  MemorySegment handle();")
+		w.Writeln("")
+		w.Writeln("  /** Releases the instance. Further calls of its methods fail. */")
+		w.Writeln("  @Override")
+		w.Writeln("  void close();")
+	} else {
+		w.Writeln("public interface %s extends %s {", ClassName, parentName)
+	}
+
+	for _, method := range class.Methods {
+		if method.IsStatic {
+			// Static methods are written into the wrapper
+			continue
+		}
+		w.Writeln("")
+		err := writeJavaResultRecord(component, method, w, ClassName, false)
+		if err != nil {
+			return err
+		}
+		code, err := generateJavaMethodCode(component, method, ClassName, false)
+		if err != nil {
+			return err
+		}
+		writeJavaDocComment(w, "  ", method.MethodDescription)
+		w.Writeln("  %s %s(%s);", getJavaReturnType(ClassName, method, code), javaIdentifier(method.MethodName), strings.Join(code.Signature, ", "))
+	}
+	w.Writeln("}")
+	return nil
+}
+
+func buildJavaClassImplementation(component ComponentDefinition, class ComponentDefinitionClass, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	ClassName := class.ClassName
+
+	w.Writeln("import java.lang.foreign.Arena;")
+	w.Writeln("import java.lang.foreign.MemorySegment;")
+	w.Writeln("import java.lang.foreign.ValueLayout;")
+	if component.isBaseClass(class) {
+		w.Writeln("import java.util.ArrayList;")
+		w.Writeln("import java.util.List;")
+	}
+	w.Writeln("")
+	w.Writeln("/** Implementation of %s that owns a handle of the library */", ClassName)
+
+	parentName := component.parentClassName(class)
+	if parentName == "" {
+		w.Writeln("public class %sImpl implements %s {", ClassName, ClassName)
+		w.Writeln("")
+		w.Writeln("  protected final %sWrapper wrapper;", NameSpace)
+		w.Writeln("  private MemorySegment handle;")
+		w.Writeln("  private final List<MemorySegment> callbacks = new ArrayList<>();")
+		w.Writeln("")
+		w.Writeln("  /** Takes ownership of a handle that has been returned by the library */")
+		w.Writeln("  public %sImpl(MemorySegment handle, %sWrapper wrapper) {", ClassName, NameSpace)
+		w.Writeln("    this.handle = handle;")
+		w.Writeln("    this.wrapper = wrapper;")
+		w.Writeln("  }")
+		w.Writeln("")
+		w.Writeln("  @Override")
+		w.Writeln("  public MemorySegment handle() {")
+		w.Writeln("    if (handle == null) {")
+		w.Writeln("      throw new %sException(%sException.INVALIDPARAM, \"the instance has been closed\");", NameSpace, NameSpace)
+		w.Writeln("    }")
+		w.Writeln("    return handle;")
+		w.Writeln("  }")
+		w.Writeln("")
+		w.Writeln("  @Override")
+		w.Writeln("  public void close() {")
+		w.Writeln("    if (handle != null) {")
+		w.Writeln("      MemorySegment releasedHandle = handle;")
+		w.Writeln("      handle = null;")
+		w.Writeln("      wrapper.releaseHandle(releasedHandle);")
+		w.Writeln("    }")
+		w.Writeln("  }")
+		w.Writeln("")
+		w.Writeln("  /** Keeps a function pointer alive as long as the instance is referenced */")
+		w.Writeln("  protected void retainCallback(MemorySegment callback) {")
+		w.Writeln("    callbacks.add(callback);")
+		w.Writeln("  }")
+	} else {
+		w.Writeln("public class %sImpl extends %sImpl implements %s {", ClassName, parentName, ClassName)
+		w.Writeln("")
+		w.Writeln("  /** Takes ownership of a handle that has been returned by the library */")
+		w.Writeln("  public %sImpl(MemorySegment handle, %sWrapper wrapper) {", ClassName, NameSpace)
+		w.Writeln("    super(handle, wrapper);")
+		w.Writeln("  }")
+	}
+
+	for _, method := range class.Methods {
+		if method.IsStatic {
+			continue
+		}
+		w.Writeln("")
+		err := writeJavaMethod(component, method, w, ClassName, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+	w.Writeln("}")
+	return nil
+}
+
+// buildJavaBuildScript writes a shell script or a batch file that compiles the bindings, or an example
+// that uses them, with plain javac
+func buildJavaBuildScript(component ComponentDefinition, w LanguageWriter, isBatch bool, bindingFolder string, exampleName string) {
+	separator := ":"
+	if isBatch {
+		separator = ";"
+	}
+
+	sourcePaths := []string{"."}
+	if len(bindingFolder) > 0 {
+		sourcePaths = []string{bindingFolder}
+	}
+	for _, namespace := range component.importedNameSpaces() {
+		sourcePaths = append(sourcePaths, path.Join("..", "..", "..", namespace+"_component", "Bindings", "Java"))
+	}
+	sources := path.Join(javaPackageName(component), "*.java")
+	if len(exampleName) > 0 {
+		sources = exampleName + ".java"
+	}
+	sourcePath := strings.Join(sourcePaths, separator)
+	if isBatch {
+		sourcePath = strings.Replace(sourcePath, "/", "\\", -1)
+		sources = strings.Replace(sources, "/", "\\", -1)
+	}
+
+	if isBatch {
+		w.Writeln("@echo off")
+		w.Writeln("REM Compiles the Java bindings of %s with javac of JDK 22 or later", component.LibraryName)
+		w.Writeln("cd /d \"%s\"", "%~dp0")
+	} else {
+		w.Writeln("#!/bin/sh")
+		w.Writeln("# Compiles the Java bindings of %s with javac of JDK 22 or later", component.LibraryName)
+		w.Writeln("cd \"$(dirname \"$0\")\"")
+	}
+	w.Writeln("javac -d classes -sourcepath \"%s\" %s", sourcePath, sources)
+	if len(exampleName) > 0 {
+		w.Writeln("echo Run the example with: java --enable-native-access=ALL-UNNAMED -cp classes %s [path to the library]", exampleName)
+	}
+}
+
+func buildJavaExample(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+	BaseName := component.BaseName
+
+	w.Writeln("import %s.*;", javaPackageName(component))
+	w.Writeln("")
+	w.Writeln("public class %s_Example {", NameSpace)
+	w.Writeln("")
+	w.Writeln("  public static void main(String[] args) {")
+	w.Writeln("    // TODO: pass the location of the shared library binary as argument or add it here")
+	w.Writeln("    String operatingSystem = System.getProperty(\"os.name\").toLowerCase();")
+	w.Writeln("    String ending = operatingSystem.contains(\"win\") ? \".dll\" : (operatingSystem.contains(\"mac\") ? \".dylib\" : \".so\");")
+	w.Writeln("    String libraryPath = (args.length > 0) ? args[0] : \"%s\" + ending;", BaseName)
+	w.Writeln("")
+	w.Writeln("    try {")
+	w.Writeln("      %sWrapper wrapper = new %sWrapper(libraryPath);", NameSpace, NameSpace)
+	for _, method := range component.Global.Methods {
+		isSpecialFunction, err := CheckHeaderSpecialFunction(method, component.Global)
+		if err != nil || isSpecialFunction != eSpecialMethodVersion {
+			continue
+		}
+		code, err := generateJavaMethodCode(component, method, "", true)
+		if err != nil || len(code.ReturnNames) < 3 {
+			continue
+		}
+		w.Writeln("      %sWrapper.%s version = wrapper.%s();", NameSpace, getJavaResultRecordName("", method), javaIdentifier(method.MethodName))
+		w.Writeln("      String versionString = version.%s() + \".\" + version.%s() + \".\" + version.%s();", code.ReturnNames[0], code.ReturnNames[1], code.ReturnNames[2])
+	}
+	for _, method := range component.Global.Methods {
+		isSpecialFunction, err := CheckHeaderSpecialFunction(method, component.Global)
+		if err != nil || (isSpecialFunction != eSpecialMethodPrerelease && isSpecialFunction != eSpecialMethodBuildinfo) {
+			continue
+		}
+		code, err := generateJavaMethodCode(component, method, "", true)
+		if err != nil || len(code.ReturnNames) < 2 {
+			continue
+		}
+		separator := "-"
+		if isSpecialFunction == eSpecialMethodBuildinfo {
+			separator = "+"
+		}
+		w.Writeln("      %sWrapper.%s %s = wrapper.%s();", NameSpace, getJavaResultRecordName("", method), javaIdentifier(method.MethodName), javaIdentifier(method.MethodName))
+		w.Writeln("      if (%s.%s()) {", javaIdentifier(method.MethodName), code.ReturnNames[0])
+		w.Writeln("        versionString += \"%s\" + %s.%s();", separator, javaIdentifier(method.MethodName), code.ReturnNames[1])
+		w.Writeln("      }")
+	}
+	w.Writeln("      System.out.println(\"%s version: \" + versionString);", component.LibraryName)
+	w.Writeln("    } catch (%sException exception) {", NameSpace)
+	w.Writeln("      System.out.println(exception.getMessage());")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("}")
+}
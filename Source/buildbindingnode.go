@@ -37,11 +37,13 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"path"
 	"strings"
 )
 
+// nodeAPIVersion is the Node-API version the generated addon is compiled against
+const nodeAPIVersion = 3
+
 // BuildBindingNode builds NodeJS-bindings of a library's API
 func BuildBindingNode(component ComponentDefinition, outputFolder string, indentString string) error {
 	namespace := component.NameSpace
@@ -50,9 +52,9 @@ func BuildBindingNode(component ComponentDefinition, outputFolder string, indent
 
 	NodeAddOnImplName := path.Join(outputFolder, baseName+"_nodeaddon.cc")
 	log.Printf("Creating \"%s\"", NodeAddOnImplName)
-	nodeaddonfile, err := os.Create(NodeAddOnImplName)
+	nodeaddonfile, err := CreateOutputFile(NodeAddOnImplName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	WriteLicenseHeader(nodeaddonfile, component,
 		fmt.Sprintf("This is an autogenerated C++ Implementation file for the Node addon class \n of %s", libraryname),
@@ -60,9 +62,9 @@ func BuildBindingNode(component ComponentDefinition, outputFolder string, indent
 
 	NodeWrapperHeaderName := path.Join(outputFolder, baseName+"_nodewrapper.h")
 	log.Printf("Creating \"%s\"", NodeWrapperHeaderName)
-	nodewrapperhfile, err := os.Create(NodeWrapperHeaderName)
+	nodewrapperhfile, err := CreateOutputFile(NodeWrapperHeaderName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	WriteLicenseHeader(nodewrapperhfile, component,
 		fmt.Sprintf("This is an autogenerated C++ Header file for the Node wrapper class \n of %s", libraryname),
@@ -70,9 +72,9 @@ func BuildBindingNode(component ComponentDefinition, outputFolder string, indent
 
 	NodeWrapperImplName := path.Join(outputFolder, baseName+"_nodewrapper.cc")
 	log.Printf("Creating \"%s\"", NodeWrapperImplName)
-	nodewrapperccfile, err := os.Create(NodeWrapperImplName)
+	nodewrapperccfile, err := CreateOutputFile(NodeWrapperImplName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	WriteLicenseHeader(nodewrapperccfile, component,
 		fmt.Sprintf("This is an autogenerated C++ Implementation file for the Node wrapper class \n of %s", libraryname),
@@ -80,52 +82,120 @@ func BuildBindingNode(component ComponentDefinition, outputFolder string, indent
 
 	err = buildNodeAddOnImplementation(component, nodeaddonfile, namespace, baseName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	NodeBindingGypName := path.Join(outputFolder, "binding.gyp")
 	log.Printf("Creating \"%s\"", NodeBindingGypName)
-	bindinggypfile, err := os.Create(NodeBindingGypName)
+	bindinggypfile, err := CreateOutputFile(NodeBindingGypName)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	buildNodeBindingGyp(component, bindinggypfile, indentString)
 
+	NodeTypeDeclarationName := path.Join(outputFolder, baseName+"_nodeaddon.d.ts")
+	log.Printf("Creating \"%s\"", NodeTypeDeclarationName)
+	typedeclarationfile, err := CreateLanguageFile(NodeTypeDeclarationName, indentString)
+	if err != nil {
+		return err
+	}
+	typedeclarationfile.WriteCLicenseHeader(component,
+		fmt.Sprintf("This is an autogenerated TypeScript declaration file for the Node addon \n of %s", libraryname),
+		true)
+	err = buildNodeTypeDeclarations(component, typedeclarationfile)
+	if err != nil {
+		return err
+	}
+
 	return buildNodeWrapperClass(component, nodewrapperhfile, nodewrapperccfile, namespace, baseName)
 }
 
 func buildNodeAddOnImplementation(component ComponentDefinition, w io.Writer, NameSpace string, BaseName string) error {
 
 	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "#include <node.h>\n")
+	fmt.Fprintf(w, "#include <node_api.h>\n")
 	fmt.Fprintf(w, "#include \"%s_nodewrapper.h\"\n", BaseName)
 	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "using namespace v8;\n")
-	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "void Load%s (const FunctionCallbackInfo<Value>& args)\n", NameSpace)
+	fmt.Fprintf(w, "napi_value Load%s (napi_env env, napi_callback_info info)\n", NameSpace)
 	fmt.Fprintf(w, "{\n")
-	fmt.Fprintf(w, "    Isolate* isolate = args.GetIsolate();\n")
-	fmt.Fprintf(w, "    HandleScope scope(isolate);\n")
-	fmt.Fprintf(w, "    args.GetReturnValue().Set (C%sWrapper::NewInstance());\n", NameSpace)
+	fmt.Fprintf(w, "    size_t argc = 1;\n")
+	fmt.Fprintf(w, "    napi_value args[1];\n")
+	fmt.Fprintf(w, "    if (napi_get_cb_info (env, info, &argc, args, nullptr, nullptr) != napi_ok)\n")
+	fmt.Fprintf(w, "        return nullptr;\n")
+	fmt.Fprintf(w, "    return C%sWrapper::NewInstance (env, args[0]);\n", NameSpace)
 	fmt.Fprintf(w, "}\n")
 	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "void InitAll(Handle<Object> exports, Handle<Object> module)\n")
+	fmt.Fprintf(w, "napi_value InitAll (napi_env env, napi_value exports)\n")
 	fmt.Fprintf(w, "{\n")
 	for i := 0; i < len(component.Classes); i++ {
 		class := component.Classes[i]
-		fmt.Fprintf(w, "    C%s%s::Init();\n", NameSpace, class.ClassName)
+		fmt.Fprintf(w, "    C%s%s::Init (env);\n", NameSpace, class.ClassName)
+	}
+	fmt.Fprintf(w, "    C%sWrapper::Init (env);\n", NameSpace)
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "    // The module exports the load function itself\n")
+	fmt.Fprintf(w, "    napi_value loadFunction;\n")
+	fmt.Fprintf(w, "    if (napi_create_function (env, \"Load%s\", NAPI_AUTO_LENGTH, Load%s, nullptr, &loadFunction) != napi_ok)\n", NameSpace, NameSpace)
+	fmt.Fprintf(w, "        return nullptr;\n")
+	if len(component.Constants) > 0 {
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "    // The constants of the component are properties of the load function\n")
+		fmt.Fprintf(w, "    napi_value constantValue;\n")
+		for _, constant := range component.Constants {
+			fmt.Fprintf(w, "    if (%s != napi_ok)\n", getNodeConstantValueCreation(constant))
+			fmt.Fprintf(w, "        return nullptr;\n")
+			fmt.Fprintf(w, "    if (napi_set_named_property (env, loadFunction, \"%s\", constantValue) != napi_ok)\n", constant.Name)
+			fmt.Fprintf(w, "        return nullptr;\n")
+		}
 	}
-	fmt.Fprintf(w, "    C%sWrapper::Init();\n", NameSpace)
-	fmt.Fprintf(w, "    NODE_SET_METHOD(module, \"exports\", Load%s);\n", NameSpace)
+	fmt.Fprintf(w, "    return loadFunction;\n")
 	fmt.Fprintf(w, "}\n")
 	fmt.Fprintf(w, "\n")
 
-	fmt.Fprintf(w, "NODE_MODULE(%s_nodeaddon, InitAll)\n", strings.ToLower(NameSpace))
+	fmt.Fprintf(w, "NAPI_MODULE(NODE_GYP_MODULE_NAME, InitAll)\n")
 	fmt.Fprintf(w, "\n")
 
 	return nil
 }
 
+// getNodeConstantValueCreation returns the N-API call that creates the value of a constant in constantValue.
+// 64 bit integers are passed as strings, like all 64 bit values of the NodeJS binding.
+func getNodeConstantValueCreation(constant ComponentDefinitionConstant) string {
+	switch constant.Type {
+	case "uint8", "uint16", "uint32":
+		return fmt.Sprintf("napi_create_uint32 (env, %s, &constantValue)", getCConstantValue(constant))
+	case "int8", "int16", "int32":
+		return fmt.Sprintf("napi_create_int32 (env, %s, &constantValue)", getCConstantValue(constant))
+	case "single", "double":
+		return fmt.Sprintf("napi_create_double (env, %s, &constantValue)", getFloatingPointLiteral(constant.Value, constant.Type))
+	case "bool":
+		return fmt.Sprintf("napi_get_boolean (env, %s, &constantValue)", constant.Value)
+	case "uint64", "int64":
+		return fmt.Sprintf("napi_create_string_utf8 (env, \"%s\", NAPI_AUTO_LENGTH, &constantValue)", constant.Value)
+	}
+	return fmt.Sprintf("napi_create_string_utf8 (env, %s, NAPI_AUTO_LENGTH, &constantValue)", getCConstantValue(constant))
+}
+
+// getNodeWrapperMethods returns the methods of the wrapper object, which are the global methods followed by the
+// static methods of the classes prefixed with the name of their class
+func getNodeWrapperMethods(component ComponentDefinition) []ComponentDefinitionMethod {
+	methods := append([]ComponentDefinitionMethod{}, component.Global.Methods...)
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			if method.IsStatic {
+				methods = append(methods, getNodeWrapperMethod(class.ClassName, method))
+			}
+		}
+	}
+	return methods
+}
+
+// getNodeWrapperMethod returns a static method of a class as the method of the wrapper object that exposes it
+func getNodeWrapperMethod(ClassName string, method ComponentDefinitionMethod) ComponentDefinitionMethod {
+	method.MethodName = ClassName + method.MethodName
+	return method
+}
+
 func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Writer, NameSpace string, ClassName string, isGlobal bool) error {
 
 	returndeclaration := ""
@@ -150,7 +220,8 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 	}
 
 	if returnParamCount > 1 {
-		inputdeclaration = inputdeclaration + fmt.Sprintf("%sLocal<Object> outObject = Object::New(isolate);\n", spacing)
+		inputdeclaration = inputdeclaration + fmt.Sprintf("%snapi_value outObject = nullptr;\n", spacing)
+		inputdeclaration = inputdeclaration + fmt.Sprintf("%sCheckStatus (env, napi_create_object (env, &outObject));\n", spacing)
 	}
 
 	for k := 0; k < len(method.Params); k++ {
@@ -162,77 +233,42 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 		switch param.ParamPass {
 		case "in":
 
-			inputcheckfunction := ""
+			inputchecktype := ""
 
 			switch param.ParamType {
-			case "uint8":
-				inputcheckfunction = "IsUint32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sunsigned char n%s = (unsigned char) args[%d]->Uint32Value ();\n", spacing, param.ParamName, k)
-				callParameter = "n" + param.ParamName
-				initCallParameter = callParameter
-
-			case "uint16":
-				inputcheckfunction = "IsUint32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sunsigned short n%s = (unsigned short) args[%d]->Uint32Value ();\n", spacing, param.ParamName, k)
+			case "uint8", "uint16", "uint32":
+				inputchecktype = "napi_number"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_%s n%s = (%s_%s) getUInt32Value (env, args[%d]);\n", spacing, NameSpace, param.ParamType, param.ParamName, NameSpace, param.ParamType, k)
 				callParameter = "n" + param.ParamName
 				initCallParameter = callParameter
 
-			case "uint32":
-				inputcheckfunction = "IsUint32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sunsigned int n%s = (unsigned int) args[%d]->IntegerValue ();\n", spacing, param.ParamName, k)
+			case "int8", "int16", "int32":
+				inputchecktype = "napi_number"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_%s n%s = (%s_%s) getInt32Value (env, args[%d]);\n", spacing, NameSpace, param.ParamType, param.ParamName, NameSpace, param.ParamType, k)
 				callParameter = "n" + param.ParamName
 				initCallParameter = callParameter
 
 			case "uint64":
-				inputcheckfunction = "IsString"
-
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sv8::String::Utf8Value sutf8%s (args[%d]->ToString());\n", spacing, param.ParamName, k)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sstd::string s%s = *sutf8%s;\n", spacing, param.ParamName, param.ParamName)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%suint64_t n%s = stoull (s%s);\n", spacing, param.ParamName, param.ParamName)
+				inputchecktype = "napi_string"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_uint64 n%s = std::stoull (getStringValue (env, args[%d]));\n", spacing, NameSpace, param.ParamName, k)
 				callParameter = "n" + param.ParamName
 				initCallParameter = callParameter
 
-			case "pointer":
-				inputcheckfunction = "IsString"
-
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sv8::String::Utf8Value sutf8%s (args[%d]->ToString());\n", spacing, param.ParamName, k)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sstd::string s%s = *sutf8%s;\n", spacing, param.ParamName, param.ParamName)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%suint64_t n%s = stoull (s%s);\n", spacing, param.ParamName, param.ParamName)
-				callParameter = "(void*) n" + param.ParamName
-				initCallParameter = callParameter
-
-			case "int8":
-				inputcheckfunction = "IsInt32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%s char n%s = (char) args[%d]->Int32Value ();\n", spacing, param.ParamName, k)
-				callParameter = "n" + param.ParamName
-				initCallParameter = callParameter
-
-			case "int16":
-				inputcheckfunction = "IsInt32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%s short n%s = (short) args[%d]->Int32Value ();\n", spacing, param.ParamName, k)
-				callParameter = "n" + param.ParamName
-				initCallParameter = callParameter
-
-			case "int32":
-				inputcheckfunction = "IsInt32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%s int n%s = (int) args[%d]->IntegerValue ();\n", spacing, param.ParamName, k)
+			case "int64":
+				inputchecktype = "napi_string"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_int64 n%s = std::stoll (getStringValue (env, args[%d]));\n", spacing, NameSpace, param.ParamName, k)
 				callParameter = "n" + param.ParamName
 				initCallParameter = callParameter
 
-			case "int64":
-				inputcheckfunction = "IsString"
-
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sv8::String::Utf8Value sutf8%s (args[%d]->ToString());\n", spacing, param.ParamName, k)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sstd::string s%s = *sutf8%s;\n", spacing, param.ParamName, param.ParamName)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sint64_t n%s = stoll (s%s);\n", spacing, param.ParamName, param.ParamName)
-				callParameter = "n" + param.ParamName
+			case "pointer":
+				inputchecktype = "napi_string"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_uint64 n%s = std::stoull (getStringValue (env, args[%d]));\n", spacing, NameSpace, param.ParamName, k)
+				callParameter = fmt.Sprintf("(%s_pvoid) (uintptr_t) n%s", NameSpace, param.ParamName)
 				initCallParameter = callParameter
 
 			case "string":
-				inputcheckfunction = "IsString"
-
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sv8::String::Utf8Value sutf8%s (args[%d]->ToString());\n", spacing, param.ParamName, k)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sstd::string s%s = *sutf8%s;\n", spacing, param.ParamName, param.ParamName)
+				inputchecktype = "napi_string"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%sstd::string s%s = getStringValue (env, args[%d]);\n", spacing, param.ParamName, k)
 				callParameter = "s" + param.ParamName + ".c_str()"
 				initCallParameter = callParameter
 
@@ -249,46 +285,38 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 				initCallParameter = callParameter
 
 			case "bool":
-				inputcheckfunction = "IsBoolean"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sbool b%s = args[%d]->BooleanValue ();\n", spacing, param.ParamName, k)
+				inputchecktype = "napi_boolean"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%sbool b%s = getBoolValue (env, args[%d]);\n", spacing, param.ParamName, k)
 				callParameter = "b" + param.ParamName
 				initCallParameter = callParameter
 
 			case "single":
-				inputcheckfunction = "IsNumber"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sfloat f%s = (float) args[%d]->NumberValue ();\n", spacing, param.ParamName, k)
+				inputchecktype = "napi_number"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_single f%s = (%s_single) getDoubleValue (env, args[%d]);\n", spacing, NameSpace, param.ParamName, NameSpace, k)
 				callParameter = "f" + param.ParamName
 				initCallParameter = callParameter
 
 			case "double":
-				inputcheckfunction = "IsNumber"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sdouble d%s = (double) args[%d]->NumberValue ();\n", spacing, param.ParamName, k)
+				inputchecktype = "napi_number"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%s_double d%s = getDoubleValue (env, args[%d]);\n", spacing, NameSpace, param.ParamName, k)
 				callParameter = "d" + param.ParamName
 				initCallParameter = callParameter
 
 			case "enum":
-				inputcheckfunction = "IsUint32"
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sunsigned int e%s = (unsigned int) args[%d]->IntegerValue ();\n", spacing, param.ParamName, k)
-				callParameter = fmt.Sprintf("(e%s%s) e%s", NameSpace, param.ParamClass, param.ParamName)
+				inputchecktype = "napi_number"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%se%s%s e%s = (e%s%s) getInt32Value (env, args[%d]);\n", spacing, NameSpace, param.ParamClass, param.ParamName, NameSpace, param.ParamClass, k)
+				callParameter = "e" + param.ParamName
 				initCallParameter = callParameter
 
 			case "struct":
-				inputcheckfunction = "IsObject"
-
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%ss%s%s s%s = convertObjectTo%s%s(isolate, args[%d]);\n", spacing, NameSpace, param.ParamClass, param.ParamName, NameSpace, param.ParamClass, k)
-
+				inputchecktype = "napi_object"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%ss%s%s s%s = convertObjectTo%s%s (env, args[%d]);\n", spacing, NameSpace, param.ParamClass, param.ParamName, NameSpace, param.ParamClass, k)
 				callParameter = fmt.Sprintf("&s%s", param.ParamName)
 				initCallParameter = callParameter
 
 			case "class", "optionalclass":
-				inputcheckfunction = "IsObject"
-
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sLocal<Object> obj%s = args[%d]->ToObject(isolate->GetCurrentContext()).ToLocalChecked ();\n", spacing, param.ParamName, k)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sC%s%s * instance%s = ObjectWrap::Unwrap<C%s%s>(obj%s);\n", spacing, NameSpace, param.ParamClass, param.ParamName, NameSpace, param.ParamClass, param.ParamName)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%sif (instance%s == nullptr)\n", spacing, param.ParamName)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%s    throw std::runtime_error(\"Invalid Object parameter %d (%s)\");\n", spacing, k, param.ParamName)
-				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%sHandle h%s = instance%s->getHandle ( obj%s );\n", spacing, NameSpace, param.ParamName, param.ParamName, param.ParamName)
-
+				inputchecktype = "napi_object"
+				inputdeclaration = inputdeclaration + fmt.Sprintf("%s%sHandle h%s = getHandle (env, args[%d]);\n", spacing, NameSpace, param.ParamName, k)
 				callParameter = "h" + param.ParamName
 				initCallParameter = callParameter
 
@@ -297,134 +325,81 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 
 			}
 
-			if inputcheckfunction != "" {
-				inputcheck = inputcheck + fmt.Sprintf("%sif (!args[%d]->%s()) {\n", spacing, k, inputcheckfunction)
-				inputcheck = inputcheck + fmt.Sprintf("%s    throw std::runtime_error (\"Expected %s parameter %d (%s)\");\n", spacing, param.ParamType, k, param.ParamName)
-				inputcheck = inputcheck + fmt.Sprintf("%s}\n", spacing)
+			if inputchecktype != "" {
+				inputcheck = inputcheck + fmt.Sprintf("%sCheckArgument (env, args[%d], %s, \"Expected %s parameter %d (%s)\");\n", spacing, k, inputchecktype, param.ParamType, k, param.ParamName)
 			}
 
 		case "out", "return":
 
-			var argsvalue string
-			if returnParamCount > 1 {
-				argsvalue = fmt.Sprintf("outObject->Set (String::NewFromUtf8 (isolate, \"%s\"), ", param.ParamName)
-
-			} else {
-				argsvalue = "args.GetReturnValue().Set ("
-			}
+			returnvalue := ""
 
 			switch param.ParamType {
-			case "uint8":
-				returndeclaration = returndeclaration + fmt.Sprintf("%sunsigned char nReturn%s = 0;\n", spacing, param.ParamName)
+			case "uint8", "uint16", "uint32":
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_%s nReturn%s = 0;\n", spacing, NameSpace, param.ParamType, param.ParamName)
 				callParameter = "&nReturn" + param.ParamName
 				initCallParameter = callParameter
+				returnvalue = fmt.Sprintf("createUInt32Value (env, nReturn%s)", param.ParamName)
 
-				returncode = returncode + fmt.Sprintf("%s%sInteger::NewFromUnsigned (isolate, nReturn%s));\n", spacing, argsvalue, param.ParamName)
-
-			case "uint16":
-				returndeclaration = returndeclaration + fmt.Sprintf("%sunsigned short nReturn%s = 0;\n", spacing, param.ParamName)
+			case "int8", "int16", "int32":
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_%s nReturn%s = 0;\n", spacing, NameSpace, param.ParamType, param.ParamName)
 				callParameter = "&nReturn" + param.ParamName
 				initCallParameter = callParameter
+				returnvalue = fmt.Sprintf("createInt32Value (env, nReturn%s)", param.ParamName)
 
-				returncode = returncode + fmt.Sprintf("%s%sInteger::NewFromUnsigned (isolate, nReturn%s));\n", spacing, argsvalue, param.ParamName)
-
-			case "uint32":
-				returndeclaration = returndeclaration + fmt.Sprintf("%sunsigned int nReturn%s = 0;\n", spacing, param.ParamName)
+			case "uint64", "int64":
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_%s nReturn%s = 0;\n", spacing, NameSpace, param.ParamType, param.ParamName)
 				callParameter = "&nReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sInteger::NewFromUnsigned (isolate, nReturn%s));\n", spacing, argsvalue, param.ParamName)
-
-			case "uint64":
-				returndeclaration = returndeclaration + fmt.Sprintf("%suint64_t nReturn%s = 0;\n", spacing, param.ParamName)
-				callParameter = "&nReturn" + param.ParamName
-				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sString::NewFromUtf8 (isolate, std::to_string (nReturn%s).c_str()));\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("createStringValue (env, std::to_string (nReturn%s))", param.ParamName)
 
 			case "pointer":
-				returndeclaration = returndeclaration + fmt.Sprintf("%suint64_t nReturn%s = 0;\n", spacing, param.ParamName)
-				callParameter = "&nReturn" + param.ParamName
-				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sString::NewFromUtf8 (isolate, std::to_string (nReturn%s).c_str()));\n", spacing, argsvalue, param.ParamName)
-
-			case "int8":
-				returndeclaration = returndeclaration + fmt.Sprintf("%schar nReturn%s = 0;\n", spacing, param.ParamName)
-				callParameter = "&nReturn" + param.ParamName
-				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sInteger::New (isolate, nReturn%s));\n", spacing, argsvalue, param.ParamName)
-
-			case "int16":
-				returndeclaration = returndeclaration + fmt.Sprintf("%s short nReturn%s = 0;\n", spacing, param.ParamName)
-				callParameter = "&nReturn" + param.ParamName
-				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sInteger::New (isolate, nReturn%s));\n", spacing, argsvalue, param.ParamName)
-
-			case "int32":
-				returndeclaration = returndeclaration + fmt.Sprintf("%s int nReturn%s = 0;\n", spacing, param.ParamName)
-				callParameter = "&nReturn" + param.ParamName
-				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sInteger::New (isolate, nReturn%s));\n", spacing, argsvalue, param.ParamName)
-
-			case "int64":
-				returndeclaration = returndeclaration + fmt.Sprintf("%s int64_t nReturn%s = 0;\n", spacing, param.ParamName)
-				callParameter = "&nReturn" + param.ParamName
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_pvoid pReturn%s = nullptr;\n", spacing, NameSpace, param.ParamName)
+				callParameter = "&pReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sString::NewFromUtf8 (isolate, std::to_string (nReturn%s).c_str() ));\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("createStringValue (env, std::to_string ((%s_uint64) (uintptr_t) pReturn%s))", NameSpace, param.ParamName)
 
 			case "string":
 				requiresInitCall = true
 
-				returndeclaration = returndeclaration + fmt.Sprintf("%sunsigned int bytesNeeded%s = 0;\n", spacing, param.ParamName)
-				returndeclaration = returndeclaration + fmt.Sprintf("%sunsigned int bytesWritten%s = 0;\n", spacing, param.ParamName)
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_uint32 bytesNeeded%s = 0;\n", spacing, NameSpace, param.ParamName)
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_uint32 bytesWritten%s = 0;\n", spacing, NameSpace, param.ParamName)
 				initCallParameter = fmt.Sprintf("0, &bytesNeeded%s, nullptr", param.ParamName)
 
 				functioncode = functioncode + fmt.Sprintf("%sstd::vector<char> buffer%s;\n", spacing, param.ParamName)
-				functioncode = functioncode + fmt.Sprintf("%sbuffer%s.resize(bytesNeeded%s);\n", spacing, param.ParamName, param.ParamName)
-
-				callParameter = fmt.Sprintf("bytesNeeded%s, &bytesWritten%s, &buffer%s[0]", param.ParamName, param.ParamName, param.ParamName)
+				functioncode = functioncode + fmt.Sprintf("%sbuffer%s.resize (bytesNeeded%s + 1);\n", spacing, param.ParamName, param.ParamName)
 
-				returncode = returncode + fmt.Sprintf("%s%sString::NewFromUtf8 (isolate, &buffer%s[0]));\n", spacing, argsvalue, param.ParamName)
+				callParameter = fmt.Sprintf("bytesNeeded%s + 1, &bytesWritten%s, buffer%s.data ()", param.ParamName, param.ParamName, param.ParamName)
+				returnvalue = fmt.Sprintf("createStringValue (env, buffer%s.data ())", param.ParamName)
 
 			case "bool":
 				returndeclaration = returndeclaration + fmt.Sprintf("%sbool bReturn%s = false;\n", spacing, param.ParamName)
 				callParameter = "&bReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sBoolean::New (isolate, bReturn%s));\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("createBoolValue (env, bReturn%s)", param.ParamName)
 
 			case "single":
-				returndeclaration = returndeclaration + fmt.Sprintf("%sfloat fReturn%s = 0.0f;\n", spacing, param.ParamName)
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_single fReturn%s = 0.0f;\n", spacing, NameSpace, param.ParamName)
 				callParameter = "&fReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sNumber::New (isolate, (double) fReturn%s));\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("createDoubleValue (env, (double) fReturn%s)", param.ParamName)
 
 			case "double":
-				returndeclaration = returndeclaration + fmt.Sprintf("%sdouble dReturn%s = 0.0;\n", spacing, param.ParamName)
+				returndeclaration = returndeclaration + fmt.Sprintf("%s%s_double dReturn%s = 0.0;\n", spacing, NameSpace, param.ParamName)
 				callParameter = "&dReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sNumber::New (isolate, dReturn%s));\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("createDoubleValue (env, dReturn%s)", param.ParamName)
 
 			case "enum":
-				returndeclaration = returndeclaration + fmt.Sprintf("%se%s%s eReturn%s;\n", spacing, NameSpace, param.ParamClass, param.ParamName)
+				returndeclaration = returndeclaration + fmt.Sprintf("%se%s%s eReturn%s = (e%s%s) 0;\n", spacing, NameSpace, param.ParamClass, param.ParamName, NameSpace, param.ParamClass)
 				callParameter = "&eReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sInteger::New (isolate, (int) eReturn%s));\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("createInt32Value (env, (int32_t) eReturn%s)", param.ParamName)
 
 			case "struct":
 				returndeclaration = returndeclaration + fmt.Sprintf("%ss%s%s sReturn%s;\n", spacing, NameSpace, param.ParamClass, param.ParamName)
 				callParameter = "&sReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%s%sconvert%s%sToObject (isolate, sReturn%s));\n", spacing, argsvalue, NameSpace, param.ParamClass, param.ParamName)
+				returnvalue = fmt.Sprintf("convert%s%sToObject (env, sReturn%s)", NameSpace, param.ParamClass, param.ParamName)
 
 			case "basicarray":
 				callParameter = "0, nullptr, nullptr"
@@ -442,14 +417,20 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 				returndeclaration = returndeclaration + fmt.Sprintf("%s%sHandle hReturn%s = nullptr;\n", spacing, NameSpace, param.ParamName)
 				callParameter = "&hReturn" + param.ParamName
 				initCallParameter = callParameter
-
-				returncode = returncode + fmt.Sprintf("%sLocal<Object> instanceObj%s = C%s%s::NewInstance (args.Holder(), hReturn%s);\n", spacing, param.ParamName, NameSpace, param.ParamClass, param.ParamName)
-				returncode = returncode + fmt.Sprintf("%s%sinstanceObj%s);\n", spacing, argsvalue, param.ParamName)
+				returnvalue = fmt.Sprintf("C%s%s::NewInstance (env, thisObject, hReturn%s)", NameSpace, param.ParamClass, param.ParamName)
 
 			default:
 				return fmt.Errorf("invalid method parameter type \"%s\" for %s.%s (%s)", param.ParamType, ClassName, method.MethodName, param.ParamName)
 
 			}
+
+			if returnvalue != "" {
+				if returnParamCount > 1 {
+					returncode = returncode + fmt.Sprintf("%sCheckStatus (env, napi_set_named_property (env, outObject, \"%s\", %s));\n", spacing, param.ParamName, returnvalue)
+				} else {
+					returncode = returncode + fmt.Sprintf("%sreturn %s;\n", spacing, returnvalue)
+				}
+			}
 		}
 
 		if callParameters != "" {
@@ -467,80 +448,79 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 	}
 
 	if returnParamCount > 1 {
-		returncode = returncode + fmt.Sprintf("%sargs.GetReturnValue().Set (outObject);\n", spacing)
+		returncode = returncode + fmt.Sprintf("%sreturn outObject;\n", spacing)
+	} else if returncode == "" {
+		returncode = fmt.Sprintf("%sreturn nullptr;\n", spacing)
 	}
 
 	fmt.Fprintf(implw, "\n")
 
-	fmt.Fprintf(implw, "void C%s%s::%s (const FunctionCallbackInfo<Value>& args) \n", NameSpace, ClassName, method.MethodName)
+	// Static methods are exposed by the wrapper object and do not pass a handle
+	hasInstance := !isGlobal && !method.IsStatic
+	if method.IsStatic {
+		fmt.Fprintf(implw, "napi_value C%sWrapper::%s (napi_env env, napi_callback_info info)\n", NameSpace, getNodeWrapperMethod(ClassName, method).MethodName)
+	} else {
+		fmt.Fprintf(implw, "napi_value C%s%s::%s (napi_env env, napi_callback_info info)\n", NameSpace, ClassName, method.MethodName)
+	}
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    Isolate* isolate = args.GetIsolate();\n")
-	fmt.Fprintf(implw, "    HandleScope scope(isolate);\n")
 	fmt.Fprintf(implw, "    try {\n")
+	fmt.Fprintf(implw, "%snapi_value thisObject = nullptr;\n", spacing)
+	if len(method.Params) > 0 {
+		fmt.Fprintf(implw, "%ssize_t argc = %d;\n", spacing, len(method.Params))
+		fmt.Fprintf(implw, "%snapi_value args[%d];\n", spacing, len(method.Params))
+		fmt.Fprintf(implw, "%sCheckStatus (env, napi_get_cb_info (env, info, &argc, args, &thisObject, nullptr));\n", spacing)
+	} else {
+		fmt.Fprintf(implw, "%sCheckStatus (env, napi_get_cb_info (env, info, nullptr, nullptr, &thisObject, nullptr));\n", spacing)
+	}
 
 	fmt.Fprintf(implw, inputcheck)
 	fmt.Fprintf(implw, inputdeclaration)
 
 	fmt.Fprintf(implw, returndeclaration)
 
-	fmt.Fprintf(implw, "%ss%sDynamicWrapperTable * wrapperTable = C%sBaseClass::getDynamicWrapperTable (args.Holder());\n", spacing, NameSpace, NameSpace)
+	fmt.Fprintf(implw, "%ss%sDynamicWrapperTable * wrapperTable = getDynamicWrapperTable (env, thisObject);\n", spacing, NameSpace)
 	fmt.Fprintf(implw, "%sif (wrapperTable == nullptr)\n", spacing)
 	fmt.Fprintf(implw, "%s    throw std::runtime_error (\"Could not get wrapper table for %s method %s.\");\n", spacing, NameSpace, method.MethodName)
 
+	tableEntry := fmt.Sprintf("m_%s_%s", ClassName, method.MethodName)
+	errorInstance := "nullptr"
 	if isGlobal {
-		fmt.Fprintf(implw, "%sif (wrapperTable->m_%s == nullptr)\n", spacing, method.MethodName)
+		tableEntry = fmt.Sprintf("m_%s", method.MethodName)
+		fmt.Fprintf(implw, "%sif (wrapperTable->%s == nullptr)\n", spacing, tableEntry)
 		fmt.Fprintf(implw, "%s    throw std::runtime_error (\"Could not call %s method %s.\");\n", spacing, NameSpace, method.MethodName)
 	} else {
-		fmt.Fprintf(implw, "%sif (wrapperTable->m_%s_%s == nullptr)\n", spacing, ClassName, method.MethodName)
+		fmt.Fprintf(implw, "%sif (wrapperTable->%s == nullptr)\n", spacing, tableEntry)
 		fmt.Fprintf(implw, "%s    throw std::runtime_error (\"Could not call %s method %s::%s.\");\n", spacing, NameSpace, ClassName, method.MethodName)
 	}
-
-	if !isGlobal {
-		fmt.Fprintf(implw, "%s%sHandle instanceHandle = C%sBaseClass::getHandle (args.Holder());\n", spacing, NameSpace, NameSpace)
-	}
-
-	if requiresInitCall {
-
-		if isGlobal {
-			fmt.Fprintf(implw, "%s%sResult initErrorCode = wrapperTable->m_%s (%s);\n", spacing, NameSpace, method.MethodName, initCallParameters)
-
-		} else {
-			if initCallParameters != "" {
-				initCallParameters = ", " + initCallParameters
-			}
-			fmt.Fprintf(implw, "%s%sResult initErrorCode = wrapperTable->m_%s_%s (instanceHandle%s);\n", spacing, NameSpace, ClassName, method.MethodName, initCallParameters)
-		}
-
-		if isGlobal {
-			fmt.Fprintf(implw, "%sCheckError (isolate, wrapperTable, nullptr, initErrorCode);\n", spacing)
-		} else {
-			fmt.Fprintf(implw, "%sCheckError (isolate, wrapperTable, instanceHandle, initErrorCode);\n", spacing)
+	if hasInstance {
+		fmt.Fprintf(implw, "%s%sHandle instanceHandle = getHandle (env, thisObject);\n", spacing, NameSpace)
+		errorInstance = "instanceHandle"
+		if initCallParameters != "" {
+			initCallParameters = ", " + initCallParameters
 		}
-	}
-
-	fmt.Fprintf(implw, functioncode)
-
-	if isGlobal {
-		fmt.Fprintf(implw, "%s%sResult errorCode = wrapperTable->m_%s (%s);\n", spacing, NameSpace, method.MethodName, callParameters)
-
-	} else {
 		if callParameters != "" {
 			callParameters = ", " + callParameters
 		}
-		fmt.Fprintf(implw, "%s%sResult errorCode = wrapperTable->m_%s_%s (instanceHandle%s);\n", spacing, NameSpace, ClassName, method.MethodName, callParameters)
+		initCallParameters = "instanceHandle" + initCallParameters
+		callParameters = "instanceHandle" + callParameters
 	}
 
-	if isGlobal {
-		fmt.Fprintf(implw, "%sCheckError (isolate, wrapperTable, nullptr, errorCode);\n", spacing)
-	} else {
-		fmt.Fprintf(implw, "%sCheckError (isolate, wrapperTable, instanceHandle, errorCode);\n", spacing)
+	if requiresInitCall {
+		fmt.Fprintf(implw, "%s%sResult initErrorCode = wrapperTable->%s (%s);\n", spacing, NameSpace, tableEntry, initCallParameters)
+		fmt.Fprintf(implw, "%sCheckError (wrapperTable, %s, initErrorCode);\n", spacing, errorInstance)
 	}
 
+	fmt.Fprintf(implw, functioncode)
+
+	fmt.Fprintf(implw, "%s%sResult errorCode = wrapperTable->%s (%s);\n", spacing, NameSpace, tableEntry, callParameters)
+	fmt.Fprintf(implw, "%sCheckError (wrapperTable, %s, errorCode);\n", spacing, errorInstance)
+
 	fmt.Fprintf(implw, returncode)
 
 	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "    } catch (std::exception & E) {\n")
-	fmt.Fprintf(implw, "        RaiseError (isolate, E.what());\n")
+	fmt.Fprintf(implw, "        RaiseError (env, E.what());\n")
+	fmt.Fprintf(implw, "        return nullptr;\n")
 	fmt.Fprintf(implw, "    }\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
@@ -548,41 +528,34 @@ func writeNodeMethodImplementation(method ComponentDefinitionMethod, implw io.Wr
 	return nil
 }
 
-func buildNodeStructConversion(structdefinition ComponentDefinitionStruct, implw io.Writer, NameSpace string) error {
-
-	hasRowVariable := false
-	hasColumnVariable := false
-
-	for i := 0; i < len(structdefinition.Members); i++ {
-
-		member := structdefinition.Members[i]
-		if member.Rows > 0 {
-			hasRowVariable = true
-
-			if member.Columns > 0 {
-				hasColumnVariable = true
-			}
-		}
-
+func getNodeStructMemberConversion(member ComponentDefinitionMember, NameSpace string) (string, string, error) {
+	baseClass := "C" + NameSpace + "BaseClass::"
+	switch member.Type {
+	case "uint8", "uint16", "uint32", "int8", "int16", "int32", "single", "double":
+		return fmt.Sprintf("(%s_%s) %sgetNumberMember", NameSpace, member.Type, baseClass), "createDoubleValue (env, (double) %s)", nil
+	case "uint64":
+		return fmt.Sprintf("(%s_uint64) %sgetUInt64Member", NameSpace, baseClass), "createStringValue (env, std::to_string (%s))", nil
+	case "int64":
+		return fmt.Sprintf("(%s_int64) %sgetInt64Member", NameSpace, baseClass), "createStringValue (env, std::to_string (%s))", nil
+	case "pointer":
+		return fmt.Sprintf("(%s_pvoid) (uintptr_t) %sgetUInt64Member", NameSpace, baseClass), fmt.Sprintf("createStringValue (env, std::to_string ((%s_uint64) (uintptr_t) %%s))", NameSpace), nil
+	case "bool":
+		return baseClass + "getBoolMember", "createBoolValue (env, %s)", nil
+	case "enum":
+		return fmt.Sprintf("(int) %sgetNumberMember", baseClass), "createInt32Value (env, %s)", nil
 	}
+	return "", "", fmt.Errorf("invalid struct member type \"%s\" (%s)", member.Type, member.Name)
+}
+
+func buildNodeStructConversion(structdefinition ComponentDefinitionStruct, implw io.Writer, NameSpace string) error {
 
 	fmt.Fprintf(implw, "/*************************************************************************************************************************\n")
 	fmt.Fprintf(implw, " Class s%s%s Conversion\n", NameSpace, structdefinition.Name)
 	fmt.Fprintf(implw, "**************************************************************************************************************************/\n")
-
-	fmt.Fprintf(implw, "")
-	fmt.Fprintf(implw, "s%s%s convertObjectTo%s%s (Isolate* isolate, const Local<Value> & pParamValue)\n", NameSpace, structdefinition.Name, NameSpace, structdefinition.Name)
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "s%s%s convertObjectTo%s%s (napi_env env, napi_value pParamValue)\n", NameSpace, structdefinition.Name, NameSpace, structdefinition.Name)
 	fmt.Fprintf(implw, "{\n")
 	fmt.Fprintf(implw, "  s%s%s s%s;\n", NameSpace, structdefinition.Name, structdefinition.Name)
-	fmt.Fprintf(implw, "  Local<Context> context = isolate->GetCurrentContext();\n")
-
-	if hasRowVariable {
-		fmt.Fprintf(implw, "  int rowIndex;\n")
-	}
-	if hasColumnVariable {
-		fmt.Fprintf(implw, "  int columnIndex;\n")
-	}
-
 	fmt.Fprintf(implw, "\n")
 
 	for i := 0; i < len(structdefinition.Members); i++ {
@@ -600,215 +573,104 @@ func buildNodeStructConversion(structdefinition ComponentDefinitionStruct, implw
 
 		if member.Rows > 0 {
 			if member.Columns > 0 {
-				fmt.Fprintf(implw, "  for (columnIndex = 0; columnIndex < %d; columnIndex++)\n", member.Columns)
-				fmt.Fprintf(implw, "    for (rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
+				fmt.Fprintf(implw, "  for (int columnIndex = 0; columnIndex < %d; columnIndex++)\n", member.Columns)
+				fmt.Fprintf(implw, "    for (int rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
 				fmt.Fprintf(implw, "      s%s.m_%s[columnIndex][rowIndex]%s;\n", structdefinition.Name, member.Name, defaultValueAssignment)
 			} else {
-				fmt.Fprintf(implw, "  for (rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
+				fmt.Fprintf(implw, "  for (int rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
 				fmt.Fprintf(implw, "    s%s.m_%s[rowIndex]%s;\n", structdefinition.Name, member.Name, defaultValueAssignment)
 			}
 		} else {
-
 			fmt.Fprintf(implw, "  s%s.m_%s%s;\n", structdefinition.Name, member.Name, defaultValueAssignment)
 		}
 
 	}
 
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "  if (pParamValue->IsObject ()) {\n")
-	fmt.Fprintf(implw, "    MaybeLocal<Object> maybeObject = pParamValue->ToObject(context);\n")
-	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "    if (!maybeObject.IsEmpty ()) {\n")
-	fmt.Fprintf(implw, "      Local<Object> obj = maybeObject.ToLocalChecked();\n")
+	fmt.Fprintf(implw, "  C%sBaseClass::CheckArgument (env, pParamValue, napi_object, \"expected object parameter.\");\n", NameSpace)
 	fmt.Fprintf(implw, "\n")
 
 	for i := 0; i < len(structdefinition.Members); i++ {
-
 		member := structdefinition.Members[i]
-		fmt.Fprintf(implw, "      // %s Member\n", member.Name)
-
-		fmt.Fprintf(implw, "      MaybeLocal<Value> maybeVal%s = obj->Get(context, String::NewFromUtf8(isolate, \"%s\"));\n", member.Name, member.Name)
-		fmt.Fprintf(implw, "      if (!maybeVal%s.IsEmpty ()) {\n", member.Name)
-		fmt.Fprintf(implw, "        Local<Value> val%s = maybeVal%s.ToLocalChecked ();\n", member.Name, member.Name)
-
-		valueTypeCall := ""
-		assignmentOperator := " = "
-		switch member.Type {
-		case "uint8", "uint16", "uint32":
-			valueTypeCall = "Uint32Value"
-		case "int8", "int16", "int32":
-			valueTypeCall = "Int32Value"
-		case "enum":
-			valueTypeCall = "Int32Value"
-			assignmentOperator = ".m_code = "
-
-		case "uint64", "int64":
-			valueTypeCall = "IntegerValue"
-		case "pointer":
-			valueTypeCall = "IntegerValue"
-			assignmentOperator = " = (void *)"
-		case "bool":
-			valueTypeCall = "BooleanValue"
-		case "single":
-			assignmentOperator = " = (float)"
-			valueTypeCall = "NumberValue"
-		case "double":
-			valueTypeCall = "NumberValue"
+
+		readFunction, _, err := getNodeStructMemberConversion(member, NameSpace)
+		if err != nil {
+			return err
+		}
+		target := fmt.Sprintf("s%s.m_%s", structdefinition.Name, member.Name)
+		postfix := ""
+		if member.Type == "enum" {
+			postfix = ".m_code"
 		}
 
-		if member.Rows > 0 {
+		fmt.Fprintf(implw, "  napi_value val%s = C%sBaseClass::getMember (env, pParamValue, \"%s\");\n", member.Name, NameSpace, member.Name)
 
-			fmt.Fprintf(implw, "        if (val%s->IsArray ()) {\n", member.Name)
-			fmt.Fprintf(implw, "          Local<Array> array%s = Local<Array>::Cast(val%s);\n", member.Name, member.Name)
+		if member.Rows > 0 {
+			fmt.Fprintf(implw, "  C%sBaseClass::CheckArray (env, val%s, \"%s member is not an array\");\n", NameSpace, member.Name, member.Name)
 
 			if member.Columns > 0 {
-
-				fmt.Fprintf(implw, "          for (int colIndex = 0; colIndex < %d; colIndex++) {\n", member.Columns)
-				fmt.Fprintf(implw, "            MaybeLocal<Value> mlocalCol = array%s->Get(context, colIndex);\n", member.Name)
-				fmt.Fprintf(implw, "            Local<Value> localCol;\n")
-				fmt.Fprintf(implw, "            if (mlocalCol.ToLocal (&localCol)) {\n")
-				fmt.Fprintf(implw, "        	  if (localCol->IsArray ()) {\n")
-				fmt.Fprintf(implw, "                Local<Array> localColArray = Local<Array>::Cast(localCol);\n")
-				fmt.Fprintf(implw, "                for (int rowIndex = 0; rowIndex < %d; rowIndex++) {\n", member.Rows)
-				fmt.Fprintf(implw, "                  MaybeLocal<Value> mlocalValue = localColArray->Get(context, rowIndex);\n")
-				fmt.Fprintf(implw, "                  Local<Value> localValue;\n")
-				fmt.Fprintf(implw, "                  if (mlocalValue.ToLocal (&localValue)) {\n")
-				fmt.Fprintf(implw, "                    if (localValue->IsNumber ()) {\n")
-				fmt.Fprintf(implw, "                      MaybeLocal<Number> localNumber = localValue->ToNumber(context);\n")
-				fmt.Fprintf(implw, "                      s%s.m_%s[colIndex][rowIndex]%slocalNumber.ToLocalChecked()->%s ();\n", structdefinition.Name, member.Name, assignmentOperator, valueTypeCall)
-				fmt.Fprintf(implw, "                    } else {\n")
-				fmt.Fprintf(implw, "                      isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s array entry is not a number\" )));\n", member.Name)
-				fmt.Fprintf(implw, "                    }\n")
-				fmt.Fprintf(implw, "                  } else {\n")
-				fmt.Fprintf(implw, "                    isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s array entry is invalid\" )));\n", member.Name)
-				fmt.Fprintf(implw, "                  }\n")
-				fmt.Fprintf(implw, "                }\n")
-				fmt.Fprintf(implw, "              } else {\n")
-				fmt.Fprintf(implw, "                isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s array entry is not an array\" )));\n", member.Name)
-				fmt.Fprintf(implw, "              }\n")
-				fmt.Fprintf(implw, "            } else {\n")
-				fmt.Fprintf(implw, "              isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s array entry is invalid\" )));\n", member.Name)
-				fmt.Fprintf(implw, "            }\n")
-				fmt.Fprintf(implw, "          }\n")
-
+				fmt.Fprintf(implw, "  for (int columnIndex = 0; columnIndex < %d; columnIndex++) {\n", member.Columns)
+				fmt.Fprintf(implw, "    napi_value colValue = C%sBaseClass::getElement (env, val%s, columnIndex);\n", NameSpace, member.Name)
+				fmt.Fprintf(implw, "    C%sBaseClass::CheckArray (env, colValue, \"%s array entry is not an array\");\n", NameSpace, member.Name)
+				fmt.Fprintf(implw, "    for (int rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
+				fmt.Fprintf(implw, "      %s[columnIndex][rowIndex]%s = %s (env, C%sBaseClass::getElement (env, colValue, rowIndex), \"%s array entry is not a number\");\n", target, postfix, readFunction, NameSpace, member.Name)
+				fmt.Fprintf(implw, "  }\n")
 			} else {
-
-				fmt.Fprintf(implw, "          for (int rowIndex = 0; rowIndex < %d; rowIndex++) {\n", member.Rows)
-				fmt.Fprintf(implw, "            MaybeLocal<Value> mlocalValue = array%s->Get(context, rowIndex);\n", member.Name)
-				fmt.Fprintf(implw, "            Local<Value> localValue;\n")
-				fmt.Fprintf(implw, "            if (mlocalValue.ToLocal (&localValue)) {\n")
-				fmt.Fprintf(implw, "              if (localValue->IsNumber ()) {\n")
-				fmt.Fprintf(implw, "                MaybeLocal<Number> localNumber = localValue->ToNumber(context);\n")
-				fmt.Fprintf(implw, "                s%s.m_%s[rowIndex]%slocalNumber.ToLocalChecked()->%s ();\n", structdefinition.Name, member.Name, assignmentOperator, valueTypeCall)
-				fmt.Fprintf(implw, "              } else {\n")
-				fmt.Fprintf(implw, "                isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s array entry is not a number\" )));\n", member.Name)
-				fmt.Fprintf(implw, "              }\n")
-				fmt.Fprintf(implw, "            } else {\n")
-				fmt.Fprintf(implw, "              isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s array entry is invalid\" )));\n", member.Name)
-				fmt.Fprintf(implw, "            }\n")
-				fmt.Fprintf(implw, "          }\n")
-
+				fmt.Fprintf(implw, "  for (int rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
+				fmt.Fprintf(implw, "    %s[rowIndex]%s = %s (env, C%sBaseClass::getElement (env, val%s, rowIndex), \"%s array entry is not a number\");\n", target, postfix, readFunction, NameSpace, member.Name, member.Name)
 			}
-
-			fmt.Fprintf(implw, "        } else {\n")
-			fmt.Fprintf(implw, "          isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s member is not an array\" )));\n", member.Name)
-			fmt.Fprintf(implw, "        }\n")
-
 		} else {
-			fmt.Fprintf(implw, "        if (val%s->IsNumber ()) {\n", member.Name)
-			fmt.Fprintf(implw, "          MaybeLocal<Number> localVal%s = val%s->ToNumber(context);\n", member.Name, member.Name)
-			fmt.Fprintf(implw, "          s%s.m_%s%slocalVal%s.ToLocalChecked()->%s ();\n", structdefinition.Name, member.Name, assignmentOperator, member.Name, valueTypeCall)
-			fmt.Fprintf(implw, "        } else {\n")
-			fmt.Fprintf(implw, "          isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s member is not a number\" )));\n", member.Name)
-			fmt.Fprintf(implw, "        }\n")
+			fmt.Fprintf(implw, "  %s%s = %s (env, val%s, \"%s member is not a number\");\n", target, postfix, readFunction, member.Name, member.Name)
 		}
-
-		fmt.Fprintf(implw, "      } else {\n")
-		fmt.Fprintf(implw, "        isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"%s member not found in object\" )));\n", member.Name)
-		fmt.Fprintf(implw, "      }\n")
 		fmt.Fprintf(implw, "\n")
-
 	}
 
-	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "    } else {\n")
-	fmt.Fprintf(implw, "      isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"invalid object passed.\" )));\n")
-	fmt.Fprintf(implw, "    }\n")
-	fmt.Fprintf(implw, "  } else {\n")
-	fmt.Fprintf(implw, "    isolate->ThrowException(Exception::TypeError (String::NewFromUtf8(isolate, \"expected object parameter.\" )));\n")
-	fmt.Fprintf(implw, "  }\n")
-	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "  return s%s;\n", structdefinition.Name)
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "\n")
 
-	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "Local<Object> convert%s%sToObject (Isolate* isolate, s%s%s s%s)\n", NameSpace, structdefinition.Name, NameSpace, structdefinition.Name, structdefinition.Name)
+	fmt.Fprintf(implw, "napi_value convert%s%sToObject (napi_env env, s%s%s s%s)\n", NameSpace, structdefinition.Name, NameSpace, structdefinition.Name, structdefinition.Name)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "  Local<Object> returnInstance = Object::New(isolate);\n")
+	fmt.Fprintf(implw, "  napi_value returnInstance = nullptr;\n")
+	fmt.Fprintf(implw, "  C%sBaseClass::CheckStatus (env, napi_create_object (env, &returnInstance));\n", NameSpace)
+	fmt.Fprintf(implw, "\n")
 
 	for i := 0; i < len(structdefinition.Members); i++ {
-
 		member := structdefinition.Members[i]
 
-		conversionCall := ""
-		conversionValue := fmt.Sprintf("s%s.m_%s", structdefinition.Name, member.Name)
-		conversionPostfix := ""
-		switch member.Type {
-		case "uint8", "uint16", "uint32":
-			conversionCall = "Integer::NewFromUnsigned"
-		case "int8", "int16", "int32":
-			conversionCall = "Integer::New"
-		case "enum":
-			conversionCall = "Integer::New"
-			conversionPostfix = ".m_code"
-		case "uint64", "int64":
-			conversionCall = "String::NewFromUtf8"
-			conversionValue = "std::to_string (" + conversionValue
-			conversionPostfix = ").c_str()"
-		case "pointer":
-			conversionCall = "String::NewFromUtf8"
-			conversionValue = "std::to_string ((uint_ptr) " + conversionValue
-			conversionPostfix = ").c_str()"
-		case "bool":
-			conversionCall = "Boolean::New"
-		case "single":
-			conversionCall = "Number::New"
-			conversionValue = "(double) " + conversionValue
-		case "double":
-			conversionCall = "Number::New"
-
+		_, createFunction, err := getNodeStructMemberConversion(member, NameSpace)
+		if err != nil {
+			return err
+		}
+		value := fmt.Sprintf("s%s.m_%s", structdefinition.Name, member.Name)
+		postfix := ""
+		if member.Type == "enum" {
+			postfix = ".m_code"
 		}
 
 		if member.Rows > 0 {
+			fmt.Fprintf(implw, "  napi_value new%s = nullptr;\n", member.Name)
 
 			if member.Columns > 0 {
-
-				fmt.Fprintf(implw, "  Local<Array> new%s = Array::New (isolate, %d);\n", member.Name, member.Columns)
+				fmt.Fprintf(implw, "  C%sBaseClass::CheckStatus (env, napi_create_array_with_length (env, %d, &new%s));\n", NameSpace, member.Columns, member.Name)
 				fmt.Fprintf(implw, "  for (int colIndex = 0; colIndex < %d; colIndex++) {\n", member.Columns)
-				fmt.Fprintf(implw, "    Local<Array> colArray = Array::New (isolate, %d);\n", member.Rows)
-				fmt.Fprintf(implw, "    for (int rowIndex = 0; rowIndex < %d; rowIndex++) {\n", member.Rows)
-				fmt.Fprintf(implw, "      colArray->Set (rowIndex, %s (isolate, %s[colIndex][rowIndex]%s));\n", conversionCall, conversionValue, conversionPostfix)
-				fmt.Fprintf(implw, "    }\n")
-				fmt.Fprintf(implw, "    new%s->Set (colIndex, colArray);\n", member.Name)
+				fmt.Fprintf(implw, "    napi_value colArray = nullptr;\n")
+				fmt.Fprintf(implw, "    C%sBaseClass::CheckStatus (env, napi_create_array_with_length (env, %d, &colArray));\n", NameSpace, member.Rows)
+				fmt.Fprintf(implw, "    for (int rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
+				fmt.Fprintf(implw, "      C%sBaseClass::CheckStatus (env, napi_set_element (env, colArray, rowIndex, C%sBaseClass::%s));\n", NameSpace, NameSpace, fmt.Sprintf(createFunction, value+"[colIndex][rowIndex]"+postfix))
+				fmt.Fprintf(implw, "    C%sBaseClass::CheckStatus (env, napi_set_element (env, new%s, colIndex, colArray));\n", NameSpace, member.Name)
 				fmt.Fprintf(implw, "  }\n")
-				fmt.Fprintf(implw, "  returnInstance->Set (String::NewFromUtf8 (isolate, \"%s\"), new%s);\n", member.Name, member.Name)
-				fmt.Fprintf(implw, "\n")
-
 			} else {
-
-				fmt.Fprintf(implw, "  Local<Array> new%s = Array::New (isolate, %d);\n", member.Name, member.Rows)
-				fmt.Fprintf(implw, "  for (int rowIndex = 0; rowIndex < %d; rowIndex++) {\n", member.Rows)
-				fmt.Fprintf(implw, "    new%s->Set (rowIndex, %s (isolate, %s[rowIndex]%s));\n", member.Name, conversionCall, conversionValue, conversionPostfix)
-				fmt.Fprintf(implw, "  }\n")
-				fmt.Fprintf(implw, "  returnInstance->Set (String::NewFromUtf8 (isolate, \"%s\"), new%s);\n", member.Name, member.Name)
-				fmt.Fprintf(implw, "\n")
-
+				fmt.Fprintf(implw, "  C%sBaseClass::CheckStatus (env, napi_create_array_with_length (env, %d, &new%s));\n", NameSpace, member.Rows, member.Name)
+				fmt.Fprintf(implw, "  for (int rowIndex = 0; rowIndex < %d; rowIndex++)\n", member.Rows)
+				fmt.Fprintf(implw, "    C%sBaseClass::CheckStatus (env, napi_set_element (env, new%s, rowIndex, C%sBaseClass::%s));\n", NameSpace, member.Name, NameSpace, fmt.Sprintf(createFunction, value+"[rowIndex]"+postfix))
 			}
+			fmt.Fprintf(implw, "  C%sBaseClass::CheckStatus (env, napi_set_named_property (env, returnInstance, \"%s\", new%s));\n", NameSpace, member.Name, member.Name)
+			fmt.Fprintf(implw, "\n")
 
 		} else {
-			fmt.Fprintf(implw, "  returnInstance->Set (String::NewFromUtf8 (isolate, \"%s\"), %s (isolate, %s%s));\n", member.Name, conversionCall, conversionValue, conversionPostfix)
+			fmt.Fprintf(implw, "  C%sBaseClass::CheckStatus (env, napi_set_named_property (env, returnInstance, \"%s\", C%sBaseClass::%s));\n", NameSpace, member.Name, NameSpace, fmt.Sprintf(createFunction, value+postfix))
 		}
 
 	}
@@ -828,16 +690,11 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(w, "#ifndef %s_NODEWRAPPER_H\n", strings.ToUpper(NameSpace))
 	fmt.Fprintf(w, "#define %s_NODEWRAPPER_H\n", strings.ToUpper(NameSpace))
 	fmt.Fprintf(w, "\n")
-	fmt.Fprintf(w, "#include \"%s_dynamic.h\"\n", strings.ToLower(NameSpace))
-	fmt.Fprintf(w, "#include <node.h>\n")
-	fmt.Fprintf(w, "#include <node_object_wrap.h>\n")
+	fmt.Fprintf(w, "#include \"%s_dynamic.h\"\n", BaseName)
+	fmt.Fprintf(w, "#include <node_api.h>\n")
 	fmt.Fprintf(w, "#include <string>\n")
 	fmt.Fprintf(w, "\n")
 
-	fmt.Fprintf(w, "#define NODEWRAPPER_FIELDCOUNT 4\n")
-	fmt.Fprintf(w, "#define NODEWRAPPER_TABLEINDEX 2\n")
-	fmt.Fprintf(w, "#define NODEWRAPPER_HANDLEINDEX 3\n")
-
 	fmt.Fprintf(w, "/*************************************************************************************************************************\n")
 	fmt.Fprintf(w, " Forward declarations \n")
 	fmt.Fprintf(w, "**************************************************************************************************************************/\n")
@@ -851,16 +708,47 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(w, "**************************************************************************************************************************/\n")
 	fmt.Fprintf(w, "\n")
 
-	fmt.Fprintf(w, "class C%sBaseClass : public node::ObjectWrap {\n", NameSpace)
+	fmt.Fprintf(w, "class C%sBaseClass {\n", NameSpace)
 	fmt.Fprintf(w, "private:\n")
+	fmt.Fprintf(w, "    s%sDynamicWrapperTable * m_pWrapperTable;\n", NameSpace)
+	fmt.Fprintf(w, "    bool m_bOwnsWrapperTable;\n")
+	fmt.Fprintf(w, "    %sHandle m_pHandle;\n", NameSpace)
+	fmt.Fprintf(w, "    // Keeps the object alive that owns the wrapper table\n")
+	fmt.Fprintf(w, "    napi_ref m_pParentReference;\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "    static void Finalize (napi_env env, void * pNativeObject, void * pHint);\n")
 	fmt.Fprintf(w, "protected:\n")
+	fmt.Fprintf(w, "    static void Wrap (napi_env env, napi_value object, C%sBaseClass * pNativeObject);\n", NameSpace)
+	fmt.Fprintf(w, "    static napi_value NewInstance (napi_env env, napi_ref constructor, napi_value pParent, %sHandle pHandle);\n", NameSpace)
+	fmt.Fprintf(w, "    void setWrapperTable (s%sDynamicWrapperTable * pWrapperTable, bool bOwnsWrapperTable);\n", NameSpace)
 	fmt.Fprintf(w, "public:\n")
 	fmt.Fprintf(w, "    C%sBaseClass ();\n", NameSpace)
-	fmt.Fprintf(w, "    static void RaiseError (v8::Isolate * isolate, std::string Message);\n")
-	fmt.Fprintf(w, "    static void CheckError (v8::Isolate * isolate, s%sDynamicWrapperTable * sWrapperTable, %sHandle pInstance, %sResult errorCode);\n", NameSpace, NameSpace, NameSpace)
-	fmt.Fprintf(w, "    static void setHandle (%sHandle pHandle);\n", NameSpace)
-	fmt.Fprintf(w, "    static %sHandle getHandle (v8::Handle<v8::Object> objecthandle);\n", NameSpace)
-	fmt.Fprintf(w, "    static s%sDynamicWrapperTable * getDynamicWrapperTable (v8::Handle<v8::Object> objecthandle);\n", NameSpace)
+	fmt.Fprintf(w, "    virtual ~C%sBaseClass ();\n", NameSpace)
+	fmt.Fprintf(w, "    static void RaiseError (napi_env env, std::string Message);\n")
+	fmt.Fprintf(w, "    static void CheckStatus (napi_env env, napi_status status);\n")
+	fmt.Fprintf(w, "    static void CheckError (s%sDynamicWrapperTable * sWrapperTable, %sHandle pInstance, %sResult errorCode);\n", NameSpace, NameSpace, NameSpace)
+	fmt.Fprintf(w, "    static void CheckArgument (napi_env env, napi_value value, napi_valuetype expectedType, const std::string & sMessage);\n")
+	fmt.Fprintf(w, "    static void CheckArray (napi_env env, napi_value value, const std::string & sMessage);\n")
+	fmt.Fprintf(w, "    static %sHandle getHandle (napi_env env, napi_value object);\n", NameSpace)
+	fmt.Fprintf(w, "    static s%sDynamicWrapperTable * getDynamicWrapperTable (napi_env env, napi_value object);\n", NameSpace)
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "    static uint32_t getUInt32Value (napi_env env, napi_value value);\n")
+	fmt.Fprintf(w, "    static int32_t getInt32Value (napi_env env, napi_value value);\n")
+	fmt.Fprintf(w, "    static double getDoubleValue (napi_env env, napi_value value);\n")
+	fmt.Fprintf(w, "    static bool getBoolValue (napi_env env, napi_value value);\n")
+	fmt.Fprintf(w, "    static std::string getStringValue (napi_env env, napi_value value);\n")
+	fmt.Fprintf(w, "    static napi_value getMember (napi_env env, napi_value object, const char * pName);\n")
+	fmt.Fprintf(w, "    static napi_value getElement (napi_env env, napi_value array, uint32_t nIndex);\n")
+	fmt.Fprintf(w, "    static double getNumberMember (napi_env env, napi_value value, const std::string & sMessage);\n")
+	fmt.Fprintf(w, "    static uint64_t getUInt64Member (napi_env env, napi_value value, const std::string & sMessage);\n")
+	fmt.Fprintf(w, "    static int64_t getInt64Member (napi_env env, napi_value value, const std::string & sMessage);\n")
+	fmt.Fprintf(w, "    static bool getBoolMember (napi_env env, napi_value value, const std::string & sMessage);\n")
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "    static napi_value createUInt32Value (napi_env env, uint32_t nValue);\n")
+	fmt.Fprintf(w, "    static napi_value createInt32Value (napi_env env, int32_t nValue);\n")
+	fmt.Fprintf(w, "    static napi_value createDoubleValue (napi_env env, double dValue);\n")
+	fmt.Fprintf(w, "    static napi_value createBoolValue (napi_env env, bool bValue);\n")
+	fmt.Fprintf(w, "    static napi_value createStringValue (napi_env env, const std::string & sValue);\n")
 	fmt.Fprintf(w, "};\n")
 	fmt.Fprintf(w, "\n")
 	fmt.Fprintf(w, "\n")
@@ -873,12 +761,11 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 		fmt.Fprintf(w, "**************************************************************************************************************************/\n")
 		fmt.Fprintf(w, "class C%s%s : public C%sBaseClass {\n", NameSpace, class.ClassName, NameSpace)
 		fmt.Fprintf(w, "private:\n")
-		fmt.Fprintf(w, "    static void New(const v8::FunctionCallbackInfo<v8::Value>& args);\n")
-		fmt.Fprintf(w, "    static v8::Persistent<v8::Function> constructor;\n")
+		fmt.Fprintf(w, "    static napi_value New (napi_env env, napi_callback_info info);\n")
+		fmt.Fprintf(w, "    static napi_ref constructor;\n")
 
-		for j := 0; j < len(class.Methods); j++ {
-			method := class.Methods[j]
-			fmt.Fprintf(w, "    static void %s (const v8::FunctionCallbackInfo<v8::Value>& args);\n", method.MethodName)
+		for _, method := range class.instanceMethods() {
+			fmt.Fprintf(w, "    static napi_value %s (napi_env env, napi_callback_info info);\n", method.MethodName)
 		}
 
 		fmt.Fprintf(w, "\n")
@@ -886,8 +773,8 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 		fmt.Fprintf(w, "    C%s%s ();\n", NameSpace, class.ClassName)
 		fmt.Fprintf(w, "    ~C%s%s ();\n", NameSpace, class.ClassName)
 		fmt.Fprintf(w, "    \n")
-		fmt.Fprintf(w, "    static void Init();\n")
-		fmt.Fprintf(w, "    static v8::Local<v8::Object> NewInstance(v8::Local<v8::Object>, %sHandle pHandle);\n", NameSpace)
+		fmt.Fprintf(w, "    static void Init (napi_env env);\n")
+		fmt.Fprintf(w, "    static napi_value NewInstance (napi_env env, napi_value pParent, %sHandle pHandle);\n", NameSpace)
 		fmt.Fprintf(w, "    \n")
 		fmt.Fprintf(w, "};\n")
 		fmt.Fprintf(w, "\n")
@@ -901,21 +788,19 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(w, "\n")
 	fmt.Fprintf(w, "class C%sWrapper : public C%sBaseClass {\n", NameSpace, NameSpace)
 	fmt.Fprintf(w, "private:\n")
-	fmt.Fprintf(w, "    static void New(const v8::FunctionCallbackInfo<v8::Value>& args);\n")
-	fmt.Fprintf(w, "    static v8::Persistent<v8::Function> constructor;\n")
+	fmt.Fprintf(w, "    static napi_value New (napi_env env, napi_callback_info info);\n")
+	fmt.Fprintf(w, "    static napi_ref constructor;\n")
 
-	global := component.Global
-	for j := 0; j < len(global.Methods); j++ {
-		method := global.Methods[j]
-		fmt.Fprintf(w, "    static void %s(const v8::FunctionCallbackInfo<v8::Value>& args);\n", method.MethodName)
+	for _, method := range getNodeWrapperMethods(component) {
+		fmt.Fprintf(w, "    static napi_value %s (napi_env env, napi_callback_info info);\n", method.MethodName)
 	}
 
 	fmt.Fprintf(w, "\n")
 	fmt.Fprintf(w, "public:\n")
 	fmt.Fprintf(w, "    C%sWrapper ();\n", NameSpace)
 	fmt.Fprintf(w, "    ~C%sWrapper();\n", NameSpace)
-	fmt.Fprintf(w, "    static void Init();\n")
-	fmt.Fprintf(w, "    static v8::Local<v8::Object> NewInstance();\n")
+	fmt.Fprintf(w, "    static void Init (napi_env env);\n")
+	fmt.Fprintf(w, "    static napi_value NewInstance (napi_env env, napi_value libraryName);\n")
 	fmt.Fprintf(w, "};\n")
 
 	fmt.Fprintf(w, "\n")
@@ -923,16 +808,16 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(w, "\n")
 
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "#include <node.h>\n")
+	fmt.Fprintf(implw, "#include <node_api.h>\n")
+	fmt.Fprintf(implw, "#include <stdexcept>\n")
+	fmt.Fprintf(implw, "#include <vector>\n")
 	fmt.Fprintf(implw, "#include \"%s_nodewrapper.h\"\n", BaseName)
 	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "using namespace v8;\n")
-	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "Persistent<Function> C%sWrapper::constructor;\n", NameSpace)
+	fmt.Fprintf(implw, "napi_ref C%sWrapper::constructor = nullptr;\n", NameSpace)
 	for i := 0; i < len(component.Classes); i++ {
 		class := component.Classes[i]
-		fmt.Fprintf(implw, "Persistent<Function> C%s%s::constructor;\n", NameSpace, class.ClassName)
+		fmt.Fprintf(implw, "napi_ref C%s%s::constructor = nullptr;\n", NameSpace, class.ClassName)
 	}
 	fmt.Fprintf(implw, "\n")
 
@@ -949,32 +834,88 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(implw, "**************************************************************************************************************************/\n")
 	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "C%sBaseClass::C%sBaseClass ()\n", NameSpace, NameSpace)
-	fmt.Fprintf(implw, "    : node::ObjectWrap ()\n")
+	fmt.Fprintf(implw, "    : m_pWrapperTable (nullptr), m_bOwnsWrapperTable (false), m_pHandle (nullptr), m_pParentReference (nullptr)\n")
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "C%sBaseClass::~C%sBaseClass ()\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    if (m_bOwnsWrapperTable && (m_pWrapperTable != nullptr)) {\n")
+	fmt.Fprintf(implw, "        Release%sWrapperTable (m_pWrapperTable);\n", NameSpace)
+	fmt.Fprintf(implw, "        delete m_pWrapperTable;\n")
+	fmt.Fprintf(implw, "    }\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "void C%sBaseClass::Finalize (napi_env env, void * pNativeObject, void * pHint)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    C%sBaseClass * pObject = (C%sBaseClass *) pNativeObject;\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "    if (pObject->m_pParentReference != nullptr)\n")
+	fmt.Fprintf(implw, "        napi_delete_reference (env, pObject->m_pParentReference);\n")
+	fmt.Fprintf(implw, "    delete pObject;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "void C%sBaseClass::Wrap (napi_env env, napi_value object, C%sBaseClass * pNativeObject)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_status status = napi_wrap (env, object, pNativeObject, Finalize, nullptr, nullptr);\n")
+	fmt.Fprintf(implw, "    if (status != napi_ok) {\n")
+	fmt.Fprintf(implw, "        delete pNativeObject;\n")
+	fmt.Fprintf(implw, "        CheckStatus (env, status);\n")
+	fmt.Fprintf(implw, "    }\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::NewInstance (napi_env env, napi_ref constructor, napi_value pParent, %sHandle pHandle)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_value cons = nullptr;\n")
+	fmt.Fprintf(implw, "    napi_value instance = nullptr;\n")
+	fmt.Fprintf(implw, "    C%sBaseClass * pObject = nullptr;\n", NameSpace)
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_reference_value (env, constructor, &cons));\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_new_instance (env, cons, 0, nullptr, &instance));\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_unwrap (env, instance, (void **) &pObject));\n")
+	fmt.Fprintf(implw, "    pObject->m_pWrapperTable = getDynamicWrapperTable (env, pParent);\n")
+	fmt.Fprintf(implw, "    pObject->m_pHandle = pHandle;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_create_reference (env, pParent, 1, &pObject->m_pParentReference));\n")
+	fmt.Fprintf(implw, "    return instance;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "void C%sBaseClass::setWrapperTable (s%sDynamicWrapperTable * pWrapperTable, bool bOwnsWrapperTable)\n", NameSpace, NameSpace)
 	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    m_pWrapperTable = pWrapperTable;\n")
+	fmt.Fprintf(implw, "    m_bOwnsWrapperTable = bOwnsWrapperTable;\n")
+	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "void C%sBaseClass::RaiseError (napi_env env, std::string Message)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    bool isExceptionPending = false;\n")
+	fmt.Fprintf(implw, "    if ((napi_is_exception_pending (env, &isExceptionPending) == napi_ok) && !isExceptionPending)\n")
+	fmt.Fprintf(implw, "        napi_throw_error (env, nullptr, Message.c_str());\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "void C%sBaseClass::RaiseError (v8::Isolate * isolate, std::string Message)\n", NameSpace)
+	fmt.Fprintf(implw, "void C%sBaseClass::CheckStatus (napi_env env, napi_status status)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    if (isolate != nullptr) {	\n")
-	fmt.Fprintf(implw, "        isolate->ThrowException(Exception::TypeError(\n")
-	fmt.Fprintf(implw, "            String::NewFromUtf8(isolate, Message.c_str() )));\n")
+	fmt.Fprintf(implw, "    if (status != napi_ok) {\n")
+	fmt.Fprintf(implw, "        const napi_extended_error_info * pErrorInfo = nullptr;\n")
+	fmt.Fprintf(implw, "        napi_get_last_error_info (env, &pErrorInfo);\n")
+	fmt.Fprintf(implw, "        if ((pErrorInfo != nullptr) && (pErrorInfo->error_message != nullptr))\n")
+	fmt.Fprintf(implw, "            throw std::runtime_error (pErrorInfo->error_message);\n")
+	fmt.Fprintf(implw, "        throw std::runtime_error (\"Node-API call failed (\" + std::to_string (status) + \")\");\n")
 	fmt.Fprintf(implw, "    }\n")
 	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
 
-	fmt.Fprintf(implw, "void C%sBaseClass::CheckError (v8::Isolate * isolate, s%sDynamicWrapperTable * sWrapperTable, %sHandle pInstance, %sResult errorCode)\n", NameSpace, NameSpace, NameSpace, NameSpace)
+	fmt.Fprintf(implw, "void C%sBaseClass::CheckError (s%sDynamicWrapperTable * sWrapperTable, %sHandle pInstance, %sResult errorCode)\n", NameSpace, NameSpace, NameSpace, NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    if (errorCode != 0) {	\n")
+	fmt.Fprintf(implw, "    if (errorCode != 0) {\n")
 	fmt.Fprintf(implw, "      std::string sMessage;\n")
 
 	if len(component.Global.ErrorMethod) > 0 {
 
 		fmt.Fprintf(implw, "      if ((sWrapperTable != nullptr) && (pInstance != nullptr)) {\n")
 		fmt.Fprintf(implw, "        if (sWrapperTable->m_%s != nullptr) {\n", component.Global.ErrorMethod)
-		fmt.Fprintf(implw, "          uint32_t neededChars = 0;\n")
+		fmt.Fprintf(implw, "          %s_uint32 neededChars = 0;\n", NameSpace)
 		fmt.Fprintf(implw, "          bool hasLastError = 0;\n")
 		fmt.Fprintf(implw, "          if (sWrapperTable->m_%s (pInstance, 0, &neededChars, nullptr, &hasLastError) == 0) {\n", component.Global.ErrorMethod)
-		fmt.Fprintf(implw, "            uint32_t dummyChars = 0;\n")
+		fmt.Fprintf(implw, "            %s_uint32 dummyChars = 0;\n", NameSpace)
 		fmt.Fprintf(implw, "            std::vector<char> Buffer;\n")
 		fmt.Fprintf(implw, "            Buffer.resize (neededChars + 2);\n")
 		fmt.Fprintf(implw, "            if (sWrapperTable->m_%s (pInstance, neededChars + 1, &dummyChars, Buffer.data(), &hasLastError) == 0) {\n", component.Global.ErrorMethod)
@@ -989,25 +930,162 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(implw, "      throw std::runtime_error (\"%s Error\" + sMessage + \" (\" + std::to_string (errorCode) + \")\");\n", NameSpace)
 	fmt.Fprintf(implw, "    }\n")
 	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
 
+	fmt.Fprintf(implw, "void C%sBaseClass::CheckArgument (napi_env env, napi_value value, napi_valuetype expectedType, const std::string & sMessage)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_valuetype valueType = napi_undefined;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_typeof (env, value, &valueType));\n")
+	fmt.Fprintf(implw, "    if (valueType != expectedType)\n")
+	fmt.Fprintf(implw, "        throw std::runtime_error (sMessage);\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "void C%sBaseClass::CheckArray (napi_env env, napi_value value, const std::string & sMessage)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    bool isArray = false;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_is_array (env, value, &isArray));\n")
+	fmt.Fprintf(implw, "    if (!isArray)\n")
+	fmt.Fprintf(implw, "        throw std::runtime_error (sMessage);\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "%sHandle C%sBaseClass::getHandle (napi_env env, napi_value object)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    C%sBaseClass * pObject = nullptr;\n", NameSpace)
+	fmt.Fprintf(implw, "    if (napi_unwrap (env, object, (void **) &pObject) != napi_ok)\n")
+	fmt.Fprintf(implw, "        throw std::runtime_error (\"Invalid %s object\");\n", NameSpace)
+	fmt.Fprintf(implw, "    return pObject->m_pHandle;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "s%sDynamicWrapperTable * C%sBaseClass::getDynamicWrapperTable (napi_env env, napi_value object)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    C%sBaseClass * pObject = nullptr;\n", NameSpace)
+	fmt.Fprintf(implw, "    if (napi_unwrap (env, object, (void **) &pObject) != napi_ok)\n")
+	fmt.Fprintf(implw, "        throw std::runtime_error (\"Invalid %s object\");\n", NameSpace)
+	fmt.Fprintf(implw, "    return pObject->m_pWrapperTable;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "uint32_t C%sBaseClass::getUInt32Value (napi_env env, napi_value value)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    uint32_t nValue = 0;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_value_uint32 (env, value, &nValue));\n")
+	fmt.Fprintf(implw, "    return nValue;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "int32_t C%sBaseClass::getInt32Value (napi_env env, napi_value value)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    int32_t nValue = 0;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_value_int32 (env, value, &nValue));\n")
+	fmt.Fprintf(implw, "    return nValue;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "double C%sBaseClass::getDoubleValue (napi_env env, napi_value value)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    double dValue = 0.0;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_value_double (env, value, &dValue));\n")
+	fmt.Fprintf(implw, "    return dValue;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "bool C%sBaseClass::getBoolValue (napi_env env, napi_value value)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    bool bValue = false;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_value_bool (env, value, &bValue));\n")
+	fmt.Fprintf(implw, "    return bValue;\n")
+	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "void C%sBaseClass::setHandle (%sHandle pHandle)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "std::string C%sBaseClass::getStringValue (napi_env env, napi_value value)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    \n")
+	fmt.Fprintf(implw, "    size_t nLength = 0;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_value_string_utf8 (env, value, nullptr, 0, &nLength));\n")
+	fmt.Fprintf(implw, "    std::vector<char> Buffer (nLength + 1);\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_value_string_utf8 (env, value, Buffer.data(), Buffer.size(), &nLength));\n")
+	fmt.Fprintf(implw, "    return std::string (Buffer.data(), nLength);\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "%sHandle C%sBaseClass::getHandle (v8::Handle<v8::Object> objecthandle)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::getMember (napi_env env, napi_value object, const char * pName)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    auto Field = objecthandle->GetInternalField (NODEWRAPPER_HANDLEINDEX);\n")
-	fmt.Fprintf(implw, "    v8::Local<v8::External> externalField = Field.As<v8::External> ();\n")
-	fmt.Fprintf(implw, "    return (%sHandle *) externalField->Value();\n", NameSpace)
+	fmt.Fprintf(implw, "    bool hasMember = false;\n")
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_has_named_property (env, object, pName, &hasMember));\n")
+	fmt.Fprintf(implw, "    if (!hasMember)\n")
+	fmt.Fprintf(implw, "        throw std::runtime_error (std::string (pName) + \" member not found in object\");\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_named_property (env, object, pName, &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "s%sDynamicWrapperTable * C%sBaseClass::getDynamicWrapperTable (v8::Handle<v8::Object> objecthandle)\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::getElement (napi_env env, napi_value array, uint32_t nIndex)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    auto Field = objecthandle->GetInternalField (NODEWRAPPER_TABLEINDEX);\n")
-	fmt.Fprintf(implw, "    v8::Local<v8::External> externalField = Field.As<v8::External> ();\n")
-	fmt.Fprintf(implw, "    return (s%sDynamicWrapperTable *) externalField->Value();\n", NameSpace)
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_element (env, array, nIndex, &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "double C%sBaseClass::getNumberMember (napi_env env, napi_value value, const std::string & sMessage)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    CheckArgument (env, value, napi_number, sMessage);\n")
+	fmt.Fprintf(implw, "    return getDoubleValue (env, value);\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "// 64 bit members are returned as strings, so they are accepted as strings or numbers\n")
+	fmt.Fprintf(implw, "uint64_t C%sBaseClass::getUInt64Member (napi_env env, napi_value value, const std::string & sMessage)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_valuetype valueType = napi_undefined;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_typeof (env, value, &valueType));\n")
+	fmt.Fprintf(implw, "    if (valueType == napi_string)\n")
+	fmt.Fprintf(implw, "        return std::stoull (getStringValue (env, value));\n")
+	fmt.Fprintf(implw, "    return (uint64_t) getNumberMember (env, value, sMessage);\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "int64_t C%sBaseClass::getInt64Member (napi_env env, napi_value value, const std::string & sMessage)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_valuetype valueType = napi_undefined;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_typeof (env, value, &valueType));\n")
+	fmt.Fprintf(implw, "    if (valueType == napi_string)\n")
+	fmt.Fprintf(implw, "        return std::stoll (getStringValue (env, value));\n")
+	fmt.Fprintf(implw, "    return (int64_t) getNumberMember (env, value, sMessage);\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "bool C%sBaseClass::getBoolMember (napi_env env, napi_value value, const std::string & sMessage)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_valuetype valueType = napi_undefined;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_typeof (env, value, &valueType));\n")
+	fmt.Fprintf(implw, "    if (valueType == napi_boolean)\n")
+	fmt.Fprintf(implw, "        return getBoolValue (env, value);\n")
+	fmt.Fprintf(implw, "    return getNumberMember (env, value, sMessage) != 0.0;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::createUInt32Value (napi_env env, uint32_t nValue)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_create_uint32 (env, nValue, &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::createInt32Value (napi_env env, int32_t nValue)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_create_int32 (env, nValue, &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::createDoubleValue (napi_env env, double dValue)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_create_double (env, dValue, &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::createBoolValue (napi_env env, bool bValue)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_get_boolean (env, bValue, &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
+	fmt.Fprintf(implw, "}\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "napi_value C%sBaseClass::createStringValue (napi_env env, const std::string & sValue)\n", NameSpace)
+	fmt.Fprintf(implw, "{\n")
+	fmt.Fprintf(implw, "    napi_value value = nullptr;\n")
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_create_string_utf8 (env, sValue.c_str(), sValue.size(), &value));\n")
+	fmt.Fprintf(implw, "    return value;\n")
 	fmt.Fprintf(implw, "}\n")
 
 	fmt.Fprintf(implw, "\n")
@@ -1030,63 +1108,60 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 		fmt.Fprintf(implw, "{\n")
 		fmt.Fprintf(implw, "}\n")
 		fmt.Fprintf(implw, "\n")
-		fmt.Fprintf(implw, "void C%s%s::Init()\n", NameSpace, class.ClassName)
+		fmt.Fprintf(implw, "void C%s%s::Init (napi_env env)\n", NameSpace, class.ClassName)
 		fmt.Fprintf(implw, "{\n")
-		fmt.Fprintf(implw, "    Isolate* isolate = Isolate::GetCurrent();\n")
-		fmt.Fprintf(implw, "\n")
-		fmt.Fprintf(implw, "    // Prepare constructor template\n")
-		fmt.Fprintf(implw, "    Local<FunctionTemplate> tpl = FunctionTemplate::New(isolate, New);\n")
-		fmt.Fprintf(implw, "    tpl->SetClassName(String::NewFromUtf8(isolate, \"%s%s\"));\n", NameSpace, class.ClassName)
-		fmt.Fprintf(implw, "    tpl->InstanceTemplate()->SetInternalFieldCount(NODEWRAPPER_FIELDCOUNT);\n")
-		fmt.Fprintf(implw, "\n")
 		fmt.Fprintf(implw, "    // Prototype\n")
-
-		for j := 0; j < len(class.Methods); j++ {
-			method := class.Methods[j]
-			fmt.Fprintf(implw, "    NODE_SET_PROTOTYPE_METHOD(tpl, \"%s\", %s);\n", method.MethodName, method.MethodName)
+		instanceMethods := class.instanceMethods()
+		if len(instanceMethods) > 0 {
+			fmt.Fprintf(implw, "    napi_property_descriptor properties[] = {\n")
+			for _, method := range instanceMethods {
+				fmt.Fprintf(implw, "        { \"%s\", nullptr, %s, nullptr, nullptr, nullptr, napi_default, nullptr },\n", method.MethodName, method.MethodName)
+			}
+			for _, property := range class.Properties {
+				setter := "nullptr"
+				if !property.IsReadOnly() {
+					setter = property.SetterName()
+				}
+				fmt.Fprintf(implw, "        { \"%s\", nullptr, nullptr, %s, %s, nullptr, napi_default, nullptr },\n", property.Name, property.GetterName(), setter)
+			}
+			fmt.Fprintf(implw, "    };\n")
 		}
-
-		fmt.Fprintf(implw, "    constructor.Reset(isolate, tpl->GetFunction());\n")
 		fmt.Fprintf(implw, "\n")
+		fmt.Fprintf(implw, "    napi_value cons = nullptr;\n")
+		if len(instanceMethods) > 0 {
+			fmt.Fprintf(implw, "    CheckStatus (env, napi_define_class (env, \"%s%s\", NAPI_AUTO_LENGTH, New, nullptr, sizeof (properties) / sizeof (properties[0]), properties, &cons));\n", NameSpace, class.ClassName)
+		} else {
+			fmt.Fprintf(implw, "    CheckStatus (env, napi_define_class (env, \"%s%s\", NAPI_AUTO_LENGTH, New, nullptr, 0, nullptr, &cons));\n", NameSpace, class.ClassName)
+		}
+		fmt.Fprintf(implw, "    CheckStatus (env, napi_create_reference (env, cons, 1, &constructor));\n")
 		fmt.Fprintf(implw, "}\n")
 		fmt.Fprintf(implw, "\n")
 
-		fmt.Fprintf(implw, "void C%s%s::New(const FunctionCallbackInfo<Value>& args)\n", NameSpace, class.ClassName)
+		fmt.Fprintf(implw, "napi_value C%s%s::New (napi_env env, napi_callback_info info)\n", NameSpace, class.ClassName)
 		fmt.Fprintf(implw, "{\n")
-		fmt.Fprintf(implw, "    Isolate* isolate = args.GetIsolate();\n")
-		fmt.Fprintf(implw, "    HandleScope scope(isolate);\n")
-		fmt.Fprintf(implw, "\n")
-		fmt.Fprintf(implw, "    if (args.IsConstructCall()) {\n")
-		fmt.Fprintf(implw, "        C%sBaseClass * holderObj = ObjectWrap::Unwrap<C%sBaseClass>(args.Holder());\n", NameSpace, NameSpace)
-		fmt.Fprintf(implw, "        C%s%s * %sInstance = new C%s%s ();\n", NameSpace, class.ClassName, strings.ToLower(class.ClassName), NameSpace, class.ClassName)
-
-		//fmt.Fprintf (implw, "        Local<Object> localHolderObj = args.Holder();\n");
-		//fmt.Fprintf (implw, "        %sInstance->m_pWrapperObject.Reset (localHolderObj);\n", strings.ToLower (class.ClassName));
-
-		fmt.Fprintf(implw, "        %sInstance->Wrap(args.This());\n", strings.ToLower(class.ClassName))
-		fmt.Fprintf(implw, "        args.GetReturnValue().Set(args.This());\n")
-		fmt.Fprintf(implw, "    } else {\n")
-		fmt.Fprintf(implw, "        RaiseError (isolate, \"%s%s: Invalid call to Constructor\");\n", NameSpace, class.ClassName)
+		fmt.Fprintf(implw, "    try {\n")
+		fmt.Fprintf(implw, "        napi_value newTarget = nullptr;\n")
+		fmt.Fprintf(implw, "        napi_value thisObject = nullptr;\n")
+		fmt.Fprintf(implw, "        CheckStatus (env, napi_get_new_target (env, info, &newTarget));\n")
+		fmt.Fprintf(implw, "        if (newTarget == nullptr)\n")
+		fmt.Fprintf(implw, "            throw std::runtime_error (\"%s%s: Invalid call to Constructor\");\n", NameSpace, class.ClassName)
+		fmt.Fprintf(implw, "        CheckStatus (env, napi_get_cb_info (env, info, nullptr, nullptr, &thisObject, nullptr));\n")
+		fmt.Fprintf(implw, "        Wrap (env, thisObject, new C%s%s ());\n", NameSpace, class.ClassName)
+		fmt.Fprintf(implw, "        return thisObject;\n")
+		fmt.Fprintf(implw, "    } catch (std::exception & E) {\n")
+		fmt.Fprintf(implw, "        RaiseError (env, E.what());\n")
+		fmt.Fprintf(implw, "        return nullptr;\n")
 		fmt.Fprintf(implw, "    }\n")
 		fmt.Fprintf(implw, "}\n")
 		fmt.Fprintf(implw, "\n")
 
-		fmt.Fprintf(implw, "Local<Object> C%s%s::NewInstance(Local<Object> pParent, %sHandle pHandle)\n", NameSpace, class.ClassName, NameSpace)
+		fmt.Fprintf(implw, "napi_value C%s%s::NewInstance (napi_env env, napi_value pParent, %sHandle pHandle)\n", NameSpace, class.ClassName, NameSpace)
 		fmt.Fprintf(implw, "{\n")
-		fmt.Fprintf(implw, "    Isolate* isolate = Isolate::GetCurrent();\n")
-		fmt.Fprintf(implw, "    HandleScope scope(isolate);\n")
-		fmt.Fprintf(implw, "    Local<Function> cons = Local<Function>::New(isolate, constructor);\n")
-		fmt.Fprintf(implw, "    Local<Object> instance;\n")
-		fmt.Fprintf(implw, "    if (cons->NewInstance(isolate->GetCurrentContext()).ToLocal (&instance)) {\n")
-		fmt.Fprintf(implw, "      instance->SetInternalField (NODEWRAPPER_TABLEINDEX, External::New (isolate, C%sBaseClass::getDynamicWrapperTable (pParent)));\n", NameSpace)
-		fmt.Fprintf(implw, "      instance->SetInternalField (NODEWRAPPER_HANDLEINDEX, External::New (isolate, pHandle));\n")
-		fmt.Fprintf(implw, "    }\n")
-		fmt.Fprintf(implw, "    return instance;\n")
+		fmt.Fprintf(implw, "    return C%sBaseClass::NewInstance (env, constructor, pParent, pHandle);\n", NameSpace)
 		fmt.Fprintf(implw, "}\n")
 		fmt.Fprintf(implw, "\n")
 
-		for j := 0; j < len(class.Methods); j++ {
-			method := class.Methods[j]
+		for _, method := range class.instanceMethods() {
 			err := writeNodeMethodImplementation(method, implw, NameSpace, class.ClassName, false)
 			if err != nil {
 				return err
@@ -1109,84 +1184,95 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "void C%sWrapper::Init()\n", NameSpace)
+	fmt.Fprintf(implw, "void C%sWrapper::Init (napi_env env)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    Isolate* isolate = Isolate::GetCurrent();\n")
-	fmt.Fprintf(implw, "    // Prepare constructor template\n")
-	fmt.Fprintf(implw, "    Local<FunctionTemplate> tpl = FunctionTemplate::New(isolate, New);\n")
-	fmt.Fprintf(implw, "    tpl->SetClassName(String::NewFromUtf8(isolate, \"%sWrapper\"));\n", NameSpace)
-	fmt.Fprintf(implw, "    tpl->InstanceTemplate()->SetInternalFieldCount(NODEWRAPPER_FIELDCOUNT);\n")
-	fmt.Fprintf(implw, "    \n")
 	fmt.Fprintf(implw, "    // Prototype\n")
-	fmt.Fprintf(implw, "    \n")
-	global = component.Global
-	for j := 0; j < len(global.Methods); j++ {
-		method := global.Methods[j]
-		fmt.Fprintf(implw, "    NODE_SET_PROTOTYPE_METHOD(tpl, \"%s\", %s);\n", method.MethodName, method.MethodName)
+	wrapperMethods := getNodeWrapperMethods(component)
+	if len(wrapperMethods) > 0 {
+		fmt.Fprintf(implw, "    napi_property_descriptor properties[] = {\n")
+		for _, method := range wrapperMethods {
+			fmt.Fprintf(implw, "        { \"%s\", nullptr, %s, nullptr, nullptr, nullptr, napi_default, nullptr },\n", method.MethodName, method.MethodName)
+		}
+		fmt.Fprintf(implw, "    };\n")
 	}
-
-	fmt.Fprintf(implw, "    constructor.Reset(isolate, tpl->GetFunction());\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "    napi_value cons = nullptr;\n")
+	if len(wrapperMethods) > 0 {
+		fmt.Fprintf(implw, "    CheckStatus (env, napi_define_class (env, \"%sWrapper\", NAPI_AUTO_LENGTH, New, nullptr, sizeof (properties) / sizeof (properties[0]), properties, &cons));\n", NameSpace)
+	} else {
+		fmt.Fprintf(implw, "    CheckStatus (env, napi_define_class (env, \"%sWrapper\", NAPI_AUTO_LENGTH, New, nullptr, 0, nullptr, &cons));\n", NameSpace)
+	}
+	fmt.Fprintf(implw, "    CheckStatus (env, napi_create_reference (env, cons, 1, &constructor));\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "void C%sWrapper::New(const FunctionCallbackInfo<Value>& args)\n", NameSpace)
+	fmt.Fprintf(implw, "napi_value C%sWrapper::New (napi_env env, napi_callback_info info)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    Isolate* isolate = args.GetIsolate();\n")
-	fmt.Fprintf(implw, "    HandleScope scope(isolate);\n")
-	fmt.Fprintf(implw, "\n")
 	fmt.Fprintf(implw, "    try {\n")
-	fmt.Fprintf(implw, "        if (args.IsConstructCall()) {\n")
-
-	fmt.Fprintf(implw, "            // Get Library Name as Argument\n")
-	fmt.Fprintf(implw, "            std::string sLibraryName = \"%s.dll\";\n", BaseName)
-	fmt.Fprintf(implw, "            if (args[0]->IsString()) {\n")
-	fmt.Fprintf(implw, "                v8::String::Utf8Value stringParam (args[0]->ToString());\n")
-	fmt.Fprintf(implw, "                sLibraryName = *stringParam;\n")
-	fmt.Fprintf(implw, "            }\n")
-
-	fmt.Fprintf(implw, "            // Invoked as constructor: `new %sWrapper(...)`\n", NameSpace)
-	fmt.Fprintf(implw, "            C%sWrapper* obj = new C%sWrapper ();\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "        size_t argc = 1;\n")
+	fmt.Fprintf(implw, "        napi_value args[1];\n")
+	fmt.Fprintf(implw, "        napi_value newTarget = nullptr;\n")
+	fmt.Fprintf(implw, "        napi_value thisObject = nullptr;\n")
+	fmt.Fprintf(implw, "        CheckStatus (env, napi_get_cb_info (env, info, &argc, args, &thisObject, nullptr));\n")
+	fmt.Fprintf(implw, "        CheckStatus (env, napi_get_new_target (env, info, &newTarget));\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "        if (newTarget == nullptr) {\n")
+	fmt.Fprintf(implw, "            // Invoked as plain function `%sWrapper(...)`, turn into construct call.\n", NameSpace)
+	fmt.Fprintf(implw, "            return NewInstance (env, args[0]);\n")
+	fmt.Fprintf(implw, "        }\n")
+	fmt.Fprintf(implw, "\n")
 
-	fmt.Fprintf(implw, "            Local<Object> newObject = args.This();\n")
-	fmt.Fprintf(implw, "            std::auto_ptr<s%sDynamicWrapperTable> wrapperTable ( new s%sDynamicWrapperTable );\n", NameSpace, NameSpace)
-	fmt.Fprintf(implw, "            CheckError (isolate, nullptr, nullptr, Load%sWrapperTable (wrapperTable.get(), sLibraryName.c_str()));\n", NameSpace)
-	fmt.Fprintf(implw, "            newObject->SetInternalField (NODEWRAPPER_TABLEINDEX, External::New (isolate, wrapperTable.release ()));\n")
+	fmt.Fprintf(implw, "        // Get Library Name as Argument\n")
+	fmt.Fprintf(implw, "#if defined(_WIN32)\n")
+	fmt.Fprintf(implw, "        std::string sLibraryName = \"%s.dll\";\n", BaseName)
+	fmt.Fprintf(implw, "#elif defined(__APPLE__)\n")
+	fmt.Fprintf(implw, "        std::string sLibraryName = \"%s.dylib\";\n", BaseName)
+	fmt.Fprintf(implw, "#else\n")
+	fmt.Fprintf(implw, "        std::string sLibraryName = \"%s.so\";\n", BaseName)
+	fmt.Fprintf(implw, "#endif\n")
+	fmt.Fprintf(implw, "        napi_valuetype argumentType = napi_undefined;\n")
+	fmt.Fprintf(implw, "        CheckStatus (env, napi_typeof (env, args[0], &argumentType));\n")
+	fmt.Fprintf(implw, "        if (argumentType == napi_string)\n")
+	fmt.Fprintf(implw, "            sLibraryName = getStringValue (env, args[0]);\n")
+	fmt.Fprintf(implw, "\n")
+	fmt.Fprintf(implw, "        // Invoked as constructor: `new %sWrapper(...)`\n", NameSpace)
+	fmt.Fprintf(implw, "        C%sWrapper * obj = new C%sWrapper ();\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "        Wrap (env, thisObject, obj);\n")
+	fmt.Fprintf(implw, "        s%sDynamicWrapperTable * wrapperTable = new s%sDynamicWrapperTable;\n", NameSpace, NameSpace)
+	fmt.Fprintf(implw, "        obj->setWrapperTable (wrapperTable, true);\n")
+	fmt.Fprintf(implw, "        CheckError (nullptr, nullptr, Init%sWrapperTable (wrapperTable));\n", NameSpace)
+	fmt.Fprintf(implw, "        CheckError (nullptr, nullptr, Load%sWrapperTable (wrapperTable, sLibraryName.c_str()));\n", NameSpace)
+	fmt.Fprintf(implw, "\n")
 
 	// write out enums
 	for i := 0; i < len(component.Enums); i++ {
 		enum := component.Enums[i]
 		for j := 0; j < len(enum.Options); j++ {
 			option := enum.Options[j]
-			fmt.Fprintf(implw, "            newObject->Set (String::NewFromUtf8(isolate, \"e%s_%s\"), Integer::New(isolate, %d));\n", enum.Name, option.Name, option.Value)
+			fmt.Fprintf(implw, "        CheckStatus (env, napi_set_named_property (env, thisObject, \"e%s_%s\", createInt32Value (env, %d)));\n", enum.Name, option.Name, option.Value)
 		}
 	}
 
-	fmt.Fprintf(implw, "            obj->Wrap(newObject);\n")
-	fmt.Fprintf(implw, "            args.GetReturnValue().Set(newObject);\n")
-	fmt.Fprintf(implw, "        } else {\n")
-	fmt.Fprintf(implw, "            // Invoked as plain function `%sWrapper(...)`, turn into construct call.\n", NameSpace)
-	fmt.Fprintf(implw, "            const int argc = 1;\n")
-	fmt.Fprintf(implw, "            Local<Value> argv[argc] = { args[0] };\n")
-	fmt.Fprintf(implw, "            Local<Function> cons = Local<Function>::New(isolate, constructor);\n")
-	fmt.Fprintf(implw, "            args.GetReturnValue().Set(cons->NewInstance(isolate->GetCurrentContext(), argc, argv).ToLocalChecked());\n")
-	fmt.Fprintf(implw, "        }\n")
+	fmt.Fprintf(implw, "        return thisObject;\n")
 	fmt.Fprintf(implw, "    } catch (std::exception & E) {\n")
-	fmt.Fprintf(implw, "        RaiseError (isolate, E.what());\n")
+	fmt.Fprintf(implw, "        RaiseError (env, E.what());\n")
+	fmt.Fprintf(implw, "        return nullptr;\n")
 	fmt.Fprintf(implw, "    }\n")
 	fmt.Fprintf(implw, "}\n")
 	fmt.Fprintf(implw, "\n")
-	fmt.Fprintf(implw, "Local<Object> C%sWrapper::NewInstance ()\n", NameSpace)
+	fmt.Fprintf(implw, "napi_value C%sWrapper::NewInstance (napi_env env, napi_value libraryName)\n", NameSpace)
 	fmt.Fprintf(implw, "{\n")
-	fmt.Fprintf(implw, "    Isolate* isolate = Isolate::GetCurrent();\n")
-	fmt.Fprintf(implw, "    HandleScope scope(isolate);\n")
-	fmt.Fprintf(implw, "    Local<Function> cons = Local<Function>::New(isolate, constructor);\n")
-	fmt.Fprintf(implw, "    Local<Object> instance;\n")
-	fmt.Fprintf(implw, "    cons->NewInstance(isolate->GetCurrentContext()).ToLocal(&instance);\n")
+	fmt.Fprintf(implw, "    napi_value cons = nullptr;\n")
+	fmt.Fprintf(implw, "    napi_value instance = nullptr;\n")
+	fmt.Fprintf(implw, "    if (napi_get_reference_value (env, constructor, &cons) != napi_ok)\n")
+	fmt.Fprintf(implw, "        return nullptr;\n")
+	fmt.Fprintf(implw, "    if (napi_new_instance (env, cons, 1, &libraryName, &instance) != napi_ok)\n")
+	fmt.Fprintf(implw, "        return nullptr;\n")
 	fmt.Fprintf(implw, "    return instance;\n")
 	fmt.Fprintf(implw, "}\n")
 
 	fmt.Fprintf(implw, "\n")
 
-	global = component.Global
+	global := component.Global
 	for j := 0; j < len(global.Methods); j++ {
 		method := global.Methods[j]
 
@@ -1195,6 +1281,17 @@ func buildNodeWrapperClass(component ComponentDefinition, w io.Writer, implw io.
 			return err
 		}
 	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			if !method.IsStatic {
+				continue
+			}
+			err := writeNodeMethodImplementation(method, implw, NameSpace, class.ClassName, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
 
 	fmt.Fprintf(implw, "\n")
 
@@ -1210,8 +1307,10 @@ func buildNodeBindingGyp(component ComponentDefinition, w io.Writer, indentStrin
 	fmt.Fprintf(w, "%s%s{\n", indentString, indentString)
 	fmt.Fprintf(w, "%s%s%s\"target_name\": \"%s_nodeaddon\",\n", indentString, indentString, indentString, BaseName)
 	fmt.Fprintf(w, "%s%s%s\"sources\": [ \"%s_nodeaddon.cc\", \"%s_nodewrapper.cc\", \"%s_dynamic.cc\" ],\n", indentString, indentString, indentString, BaseName, BaseName, BaseName)
-	fmt.Fprintf(w, "%s%s%s\"cflags\": [ \"-fexceptions \" ],\n", indentString, indentString, indentString)
-	fmt.Fprintf(w, "%s%s%s\"cflags_cc\": [ \"-fexceptions \" ],\n", indentString, indentString, indentString)
+	fmt.Fprintf(w, "%s%s%s\"defines\": [ \"NAPI_VERSION=%d\" ],\n", indentString, indentString, indentString, nodeAPIVersion)
+	fmt.Fprintf(w, "%s%s%s\"cflags!\": [ \"-fno-exceptions\" ],\n", indentString, indentString, indentString)
+	fmt.Fprintf(w, "%s%s%s\"cflags_cc!\": [ \"-fno-exceptions\" ],\n", indentString, indentString, indentString)
+	fmt.Fprintf(w, "%s%s%s\"xcode_settings\": { \"GCC_ENABLE_CPP_EXCEPTIONS\": \"YES\" },\n", indentString, indentString, indentString)
 	fmt.Fprintf(w, "%s%s%s\"msvs_settings\": {\n", indentString, indentString, indentString)
 	fmt.Fprintf(w, "%s%s%s%s\"VCCLCompilerTool\": { \"ExceptionHandling\": 1 }\n", indentString, indentString, indentString, indentString)
 	fmt.Fprintf(w, "%s%s%s},\n", indentString, indentString, indentString)
@@ -1227,3 +1326,318 @@ func buildNodeBindingGyp(component ComponentDefinition, w io.Writer, indentStrin
 	return nil
 
 }
+
+// getNodeTypeScriptClassType returns the TypeScript type of a class parameter. The objects of the
+// NodeJS binding only expose the methods of their own class, so a derived class does not extend its parent.
+func getNodeTypeScriptClassType(component ComponentDefinition, className string, acceptDerived bool) string {
+	if strings.Contains(className, ":") {
+		return "object"
+	}
+	if !acceptDerived {
+		return className
+	}
+
+	parentNames := make(map[string]string)
+	for _, class := range component.Classes {
+		parentNames[class.ClassName] = component.parentClassName(class)
+	}
+
+	types := []string{}
+	for _, class := range component.Classes {
+		ancestorName := class.ClassName
+		for ancestorName != "" {
+			if ancestorName == className {
+				types = append(types, class.ClassName)
+				break
+			}
+			ancestorName = parentNames[ancestorName]
+		}
+	}
+	if len(types) == 0 {
+		return className
+	}
+	return strings.Join(types, " | ")
+}
+
+// getNodeTypeScriptConstantType returns the TypeScript type of a constant as the NodeJS binding exports it
+func getNodeTypeScriptConstantType(constant ComponentDefinitionConstant) string {
+	switch constant.Type {
+	case "uint64", "int64", "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	}
+	return "number"
+}
+
+// getNodeTypeScriptType returns the TypeScript type of a parameter as the NodeJS binding converts it
+func getNodeTypeScriptType(component ComponentDefinition, param ComponentDefinitionParam) (string, error) {
+	isInput := param.ParamPass == "in"
+	switch param.ParamType {
+	case "uint8", "uint16", "uint32", "int8", "int16", "int32", "single", "double":
+		return "number", nil
+	case "uint64", "int64", "pointer":
+		return "string", nil
+	case "string":
+		return "string", nil
+	case "bool":
+		return "boolean", nil
+	case "enum":
+		if strings.Contains(param.ParamClass, ":") {
+			return "number", nil
+		}
+		return "e" + param.ParamClass, nil
+	case "struct":
+		if strings.Contains(param.ParamClass, ":") {
+			return "object", nil
+		}
+		return "s" + param.ParamClass, nil
+	case "basicarray":
+		elementType, err := getNodeTypeScriptType(component, ComponentDefinitionParam{ParamType: param.ParamClass, ParamPass: param.ParamPass})
+		if err != nil {
+			return "", err
+		}
+		return elementType + "[]", nil
+	case "structarray":
+		elementType, err := getNodeTypeScriptType(component, ComponentDefinitionParam{ParamType: "struct", ParamClass: param.ParamClass, ParamPass: param.ParamPass})
+		if err != nil {
+			return "", err
+		}
+		return elementType + "[]", nil
+	case "functiontype":
+		return "Function", nil
+	case "class", "optionalclass":
+		return getNodeTypeScriptClassType(component, param.ParamClass, isInput), nil
+	}
+	return "", fmt.Errorf("invalid parameter type \"%s\" for TypeScript (%s)", param.ParamType, param.ParamName)
+}
+
+// getNodeTypeScriptMemberType returns the TypeScript type of a struct member. The binding reads all members
+// of a struct as numbers, but returns 64 bit integers and pointers as strings and bools as booleans.
+func getNodeTypeScriptMemberType(member ComponentDefinitionMember) (string, error) {
+	memberType := ""
+	switch member.Type {
+	case "uint8", "uint16", "uint32", "int8", "int16", "int32", "single", "double":
+		memberType = "number"
+	case "uint64", "int64", "pointer":
+		memberType = "number | string"
+	case "bool":
+		memberType = "boolean | number"
+	case "enum":
+		memberType = "e" + member.Class
+	default:
+		return "", fmt.Errorf("invalid member type \"%s\" for TypeScript (%s)", member.Type, member.Name)
+	}
+
+	if member.Rows > 0 {
+		if strings.Contains(memberType, " | ") {
+			memberType = "(" + memberType + ")"
+		}
+		memberType = memberType + "[]"
+		if member.Columns > 0 {
+			memberType = memberType + "[]"
+		}
+	}
+	return memberType, nil
+}
+
+func writeNodeTypeScriptDocComment(w LanguageWriter, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	w.Writeln("    /**")
+	for _, line := range lines {
+		w.Writeln("     * %s", line)
+	}
+	w.Writeln("     */")
+}
+
+func writeNodeTypeScriptMethod(component ComponentDefinition, w LanguageWriter, method ComponentDefinitionMethod) error {
+	lastInputIndex := -1
+	for k, param := range method.Params {
+		if param.ParamPass == "in" {
+			lastInputIndex = k
+		}
+	}
+
+	docLines := []string{}
+	if method.MethodDescription != "" {
+		docLines = append(docLines, method.MethodDescription)
+	}
+
+	// The binding reads the k-th argument for the k-th parameter, so outputs in front of inputs keep their position
+	arguments := []string{}
+	outputs := []ComponentDefinitionParam{}
+	for k, param := range method.Params {
+		if param.ParamPass != "in" {
+			if k < lastInputIndex {
+				arguments = append(arguments, fmt.Sprintf("_%s: undefined", param.ParamName))
+			}
+			outputs = append(outputs, param)
+			continue
+		}
+
+		paramType, err := getNodeTypeScriptType(component, param)
+		if err != nil {
+			return err
+		}
+		arguments = append(arguments, fmt.Sprintf("%s: %s", param.ParamName, paramType))
+
+		paramDescription := param.ParamDescription
+		switch param.ParamType {
+		case "basicarray", "structarray", "functiontype":
+			paramDescription = strings.TrimSpace(paramDescription + " (not passed to the library by the NodeJS binding)")
+		}
+		if paramDescription != "" {
+			docLines = append(docLines, fmt.Sprintf("@param %s %s", param.ParamName, paramDescription))
+		}
+	}
+
+	returnType := "void"
+	if len(outputs) == 1 {
+		switch outputs[0].ParamType {
+		case "basicarray", "structarray", "functiontype":
+		default:
+			paramType, err := getNodeTypeScriptType(component, outputs[0])
+			if err != nil {
+				return err
+			}
+			returnType = paramType
+			if outputs[0].ParamDescription != "" {
+				docLines = append(docLines, fmt.Sprintf("@returns %s", outputs[0].ParamDescription))
+			}
+		}
+	} else if len(outputs) > 1 {
+		fields := []string{}
+		for _, param := range outputs {
+			switch param.ParamType {
+			case "basicarray", "structarray", "functiontype":
+				continue
+			}
+			paramType, err := getNodeTypeScriptType(component, param)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", param.ParamName, paramType))
+			if param.ParamDescription != "" {
+				docLines = append(docLines, fmt.Sprintf("@returns %s - %s", param.ParamName, param.ParamDescription))
+			}
+		}
+		returnType = "{ " + strings.Join(fields, "; ") + " }"
+	}
+
+	writeNodeTypeScriptDocComment(w, docLines)
+	w.Writeln("    %s(%s): %s;", method.MethodName, strings.Join(arguments, ", "), returnType)
+	return nil
+}
+
+// buildNodeTypeDeclarations writes the TypeScript declarations of the module exported by the Node addon
+func buildNodeTypeDeclarations(component ComponentDefinition, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+
+	w.Writeln("")
+	w.Writeln("/**")
+	w.Writeln(" * Loads the %s and returns its global methods.", component.LibraryName)
+	w.Writeln(" * @param libraryName - Path of the shared library. Defaults to %s with the platform's library extension.", component.BaseName)
+	w.Writeln(" */")
+	w.Writeln("declare function %s(libraryName?: string): %s.Wrapper;", NameSpace, NameSpace)
+	w.Writeln("")
+	w.Writeln("declare namespace %s {", NameSpace)
+
+	for _, constant := range component.Constants {
+		w.Writeln("")
+		if constant.Description != "" {
+			w.Writeln("  /**")
+			w.Writeln("   * %s", constant.Description)
+			w.Writeln("   */")
+		}
+		w.Writeln("  const %s: %s;", constant.Name, getNodeTypeScriptConstantType(constant))
+	}
+
+	for _, enum := range component.Enums {
+		w.Writeln("")
+		w.Writeln("  const enum e%s {", enum.Name)
+		for j, option := range enum.Options {
+			separator := ","
+			if j == len(enum.Options)-1 {
+				separator = ""
+			}
+			w.Writeln("    %s = %d%s", option.Name, option.Value, separator)
+		}
+		w.Writeln("  }")
+	}
+
+	for _, structinfo := range component.Structs {
+		w.Writeln("")
+		w.Writeln("  interface s%s {", structinfo.Name)
+		for _, member := range structinfo.Members {
+			memberType, err := getNodeTypeScriptMemberType(member)
+			if err != nil {
+				return err
+			}
+			w.Writeln("    %s: %s;", member.Name, memberType)
+		}
+		w.Writeln("  }")
+	}
+
+	for _, class := range component.Classes {
+		w.Writeln("")
+		if class.ClassDescription != "" {
+			w.Writeln("  /**")
+			w.Writeln("   * %s", class.ClassDescription)
+			w.Writeln("   */")
+		}
+		w.Writeln("  class %s {", class.ClassName)
+		w.Writeln("    private constructor();")
+		for _, method := range class.instanceMethods() {
+			w.Writeln("")
+			err := writeNodeTypeScriptMethod(component, w, method)
+			if err != nil {
+				return err
+			}
+		}
+		for _, property := range class.Properties {
+			w.Writeln("")
+			propertyType, err := getNodeTypeScriptType(component, property.Getter().Params[0])
+			if err != nil {
+				return err
+			}
+			if property.Description != "" {
+				writeNodeTypeScriptDocComment(w, []string{property.Description})
+			}
+			if property.IsReadOnly() {
+				w.Writeln("    readonly %s: %s;", property.Name, propertyType)
+			} else {
+				w.Writeln("    %s: %s;", property.Name, propertyType)
+			}
+		}
+		w.Writeln("  }")
+	}
+
+	w.Writeln("")
+	w.Writeln("  class Wrapper {")
+	w.Writeln("    private constructor();")
+	if len(component.Enums) > 0 {
+		w.Writeln("")
+	}
+	for _, enum := range component.Enums {
+		for _, option := range enum.Options {
+			w.Writeln("    readonly e%s_%s: e%s;", enum.Name, option.Name, enum.Name)
+		}
+	}
+	for _, method := range getNodeWrapperMethods(component) {
+		w.Writeln("")
+		err := writeNodeTypeScriptMethod(component, w, method)
+		if err != nil {
+			return err
+		}
+	}
+	w.Writeln("  }")
+
+	w.Writeln("")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("export = %s;", NameSpace)
+
+	return nil
+}
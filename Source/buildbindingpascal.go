@@ -287,6 +287,14 @@ func buildDynamicPascalImplementation(component ComponentDefinition, w LanguageW
 			parentClassName = "BaseClass"
 		}
 
+		classDocLines := []string{}
+		if class.ClassDescription != "" {
+			classDocLines = append(classDocLines, class.ClassDescription)
+		}
+		if class.IsDeprecated() {
+			classDocLines = append(classDocLines, fmt.Sprintf("@deprecated(%s)", class.DeprecationNotice()))
+		}
+		writePascalDocComment(w, "  ", classDocLines)
 		if component.isBaseClass(class) {
 			w.Writeln(" %s = class(TObject)", pascalBaseClassName)
 			w.Writeln("  private")
@@ -305,11 +313,24 @@ func buildDynamicPascalImplementation(component ComponentDefinition, w LanguageW
 		w.AddIndentationLevel(2)
 		for j := 0; j < len(class.Methods); j++ {
 			method := class.Methods[j]
+			writePascalMethodDocComment(method, w)
 			err := writePascalClassMethodDefinition(method, w, NameSpace, class.ClassName, false, false)
 			if err != nil {
 				return err
 			}
 		}
+		for _, property := range class.Properties {
+			_, PropertyTypeName, err := getPascalClassParameters(property.Getter(), NameSpace, class.ClassName, false, false, false)
+			if err != nil {
+				return err
+			}
+			writePascalDocComment(w, "", []string{property.Description})
+			if property.IsReadOnly() {
+				w.Writeln("property %s: %s read %s;", property.Name, PropertyTypeName, property.GetterName())
+			} else {
+				w.Writeln("property %s: %s read %s write %s;", property.Name, PropertyTypeName, property.GetterName(), property.SetterName())
+			}
+		}
 		w.AddIndentationLevel(-2)
 
 		w.Writeln("  end;")
@@ -401,6 +422,7 @@ func buildDynamicPascalImplementation(component ComponentDefinition, w LanguageW
 	for j := 0; j < len(global.Methods); j++ {
 		method := global.Methods[j]
 
+		writePascalMethodDocComment(method, w)
 		err = writePascalClassMethodDefinition(method, w, NameSpace, "Wrapper", true, false)
 		if err != nil {
 			return err
@@ -691,9 +713,45 @@ func buildDynamicPascalImplementation(component ComponentDefinition, w LanguageW
 	return nil
 }
 
-func getPascalClassParameters(method ComponentDefinitionMethod, NameSpace string, ClassName string, isGlobal bool, isImplementation bool) (string, string, error) {
+// getPascalDefaultValue returns the Pascal literal of the default of an input parameter
+func getPascalDefaultValue(param ComponentDefinitionParam) string {
+	return getPascalLiteral(param.DefaultValue(), param.ParamType, param.ParamClass)
+}
+
+// getPascalConstantValue returns the Pascal literal of the value of a constant.
+// Constants are untyped, so floating point values need a decimal point.
+func getPascalConstantValue(constant ComponentDefinitionConstant) string {
+	switch constant.Type {
+	case "single", "double":
+		return getFloatingPointLiteral(constant.Value, constant.Type)
+	}
+	return getPascalLiteral(constant.Value, constant.Type, "")
+}
+
+// getPascalLiteral returns the Pascal literal of a value of a scalar type, a string or an enum
+func getPascalLiteral(value string, valueType string, valueClass string) string {
+	switch valueType {
+	case "string":
+		return "'" + strings.Replace(value, "'", "''", -1) + "'"
+	case "enum":
+		_, enumName, _ := decomposeParamClassName(valueClass)
+		return "e" + enumName + value
+	case "bool":
+		if value == "true" {
+			return "True"
+		}
+		return "False"
+	}
+	return value
+}
+
+func getPascalClassParameters(method ComponentDefinitionMethod, NameSpace string, ClassName string, isGlobal bool, isImplementation bool, withDefaults bool) (string, string, error) {
 	parameters := ""
 	returnType := ""
+	if method.IsStatic && !isGlobal {
+		// There is no instance to reach the library with, so static methods take the wrapper
+		parameters = fmt.Sprintf("AWrapper: T%sWrapper", NameSpace)
+	}
 
 	for k := 0; k < len(method.Params); k++ {
 		param := method.Params[k]
@@ -708,6 +766,9 @@ func getPascalClassParameters(method ComponentDefinitionMethod, NameSpace string
 				parameters = parameters + "; "
 			}
 			parameters = parameters + "const A" + param.ParamName + ": " + ParamTypeName
+			if withDefaults && param.HasDefault() {
+				parameters = parameters + " = " + getPascalDefaultValue(param)
+			}
 
 		case "out":
 			if parameters != "" {
@@ -726,22 +787,56 @@ func getPascalClassParameters(method ComponentDefinitionMethod, NameSpace string
 	return parameters, returnType, nil
 }
 
+// writePascalDocComment writes lines of text as a PasDoc comment
+func writePascalDocComment(w LanguageWriter, indent string, lines []string) {
+	if (len(lines) == 0) || ((len(lines) == 1) && (lines[0] == "")) {
+		return
+	}
+	w.Writeln(indent + "(*")
+	for _, line := range lines {
+		w.Writeln(indent+" %s", strings.Replace(line, "*)", "* )", -1))
+	}
+	w.Writeln(indent + "*)")
+}
+
+// writePascalMethodDocComment writes the PasDoc comment of a method with its parameters and return value
+func writePascalMethodDocComment(method ComponentDefinitionMethod, w LanguageWriter) {
+	lines := []string{}
+	if method.MethodDescription != "" {
+		lines = append(lines, method.MethodDescription)
+	}
+	for _, param := range method.Params {
+		switch param.ParamPass {
+		case "in", "out":
+			lines = append(lines, fmt.Sprintf("@param(A%s %s)", param.ParamName, param.ParamDescription))
+		case "return":
+			lines = append(lines, fmt.Sprintf("@returns(%s)", param.ParamDescription))
+		}
+	}
+	writePascalDocComment(w, "", lines)
+}
+
 func writePascalClassMethodDefinition(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassName string, isGlobal bool, isImplementation bool) error {
 
-	parameters, returnType, err := getPascalClassParameters(method, NameSpace, ClassName, isGlobal, isImplementation)
+	parameters, returnType, err := getPascalClassParameters(method, NameSpace, ClassName, isGlobal, isImplementation, !isImplementation)
 	if err != nil {
 		return err
 	}
 
 	classPrefix := ""
-	if isImplementation && isGlobal {
+	if (isImplementation && isGlobal) || method.IsStatic {
 		classPrefix = "class "
 	}
 
+	hintDirective := ""
+	if method.IsDeprecated() && !isImplementation {
+		hintDirective = fmt.Sprintf(" deprecated '%s';", strings.Replace(method.DeprecationMessage(ClassName+"."+method.MethodName), "'", "''", -1))
+	}
+
 	if returnType == "" {
-		w.Writeln("%sprocedure %s(%s);", classPrefix, method.MethodName, parameters)
+		w.Writeln("%sprocedure %s(%s);%s", classPrefix, method.MethodName, parameters, hintDirective)
 	} else {
-		w.Writeln("%sfunction %s(%s): %s;", classPrefix, method.MethodName, parameters, returnType)
+		w.Writeln("%sfunction %s(%s): %s;%s", classPrefix, method.MethodName, parameters, returnType, hintDirective)
 	}
 
 	return nil
@@ -749,7 +844,7 @@ func writePascalClassMethodDefinition(method ComponentDefinitionMethod, w Langua
 
 func writePascalClassMethodImplementation(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassName string, definitionLines []string, implementationLines []string, isGlobal bool) error {
 
-	parameters, returnType, err := getPascalClassParameters(method, NameSpace, ClassName, isGlobal, false)
+	parameters, returnType, err := getPascalClassParameters(method, NameSpace, ClassName, isGlobal, false, false)
 	if err != nil {
 		return err
 	}
@@ -772,6 +867,11 @@ func writePascalClassMethodImplementation(method ComponentDefinitionMethod, w La
 		callFunctionName = fmt.Sprintf("%s%sFunc", NameSpace, method.MethodName)
 		errorInstanceHandle = "nil"
 		wrapperInstanceName = "Self"
+	} else if method.IsStatic {
+		callFunctionName = fmt.Sprintf("%s%s_%sFunc", NameSpace, ClassName, method.MethodName)
+		errorInstanceHandle = "nil"
+		wrapperCallPrefix = "AWrapper."
+		wrapperInstanceName = "AWrapper"
 	} else {
 		callFunctionName = fmt.Sprintf("%s%s_%sFunc", NameSpace, ClassName, method.MethodName)
 		callFunctionParameters = "FHandle"
@@ -1039,10 +1139,14 @@ func writePascalClassMethodImplementation(method ComponentDefinitionMethod, w La
 		}
 	}
 
+	classPrefix := ""
+	if method.IsStatic {
+		classPrefix = "class "
+	}
 	if returnType == "" {
-		w.Writeln("  procedure T%s%s.%s(%s);", NameSpace, ClassName, method.MethodName, parameters)
+		w.Writeln("  %sprocedure T%s%s.%s(%s);", classPrefix, NameSpace, ClassName, method.MethodName, parameters)
 	} else {
-		w.Writeln("  function T%s%s.%s(%s): %s;", NameSpace, ClassName, method.MethodName, parameters, returnType)
+		w.Writeln("  %sfunction T%s%s.%s(%s): %s;", classPrefix, NameSpace, ClassName, method.MethodName, parameters, returnType)
 	}
 
 	if len(defineCommands)+len(definitionLines) > 0 {
@@ -1078,17 +1182,20 @@ func writePascalFunctionType(method ComponentDefinitionMethod, w LanguageWriter,
 
 	PascalCallbackName := ""
 	parameters := ""
+	hasInstance := !isGlobal && !method.IsStatic
 	if isGlobal {
 		PascalCallbackName = fmt.Sprintf("T%s%sFunc", NameSpace, method.MethodName)
 	} else {
 		PascalCallbackName = fmt.Sprintf("T%s%s_%sFunc", NameSpace, ClassName, method.MethodName)
+	}
+	if hasInstance {
 		parameters = fmt.Sprintf("p%s: T%sHandle", ClassName, NameSpace)
 	}
 
 	w.Writeln(spacing + "(**")
 	w.Writeln(spacing+"* %s", method.MethodDescription)
 	w.Writeln(spacing + "*")
-	if !isGlobal {
+	if hasInstance {
 		w.Writeln(spacing+"* @param[in] p%s - %s instance.", ClassName, ClassName)
 	}
 
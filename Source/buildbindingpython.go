@@ -38,6 +38,7 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"strconv"
 	"strings"
 )
 
@@ -65,6 +66,52 @@ func BuildBindingPythonDynamic(componentdefinition ComponentDefinition, outputFo
 		return err
 	}
 
+	DynamicPythonStub := path.Join(outputFolder, namespace+".pyi")
+	log.Printf("Creating \"%s\"", DynamicPythonStub)
+	dynpythonstubfile, err := CreateLanguageFile(DynamicPythonStub, indentString)
+	if err != nil {
+		return err
+	}
+	dynpythonstubfile.WritePythonLicenseHeader(componentdefinition,
+		fmt.Sprintf("This is an autogenerated Python type stub for the Python bindings\n of %s", libraryname),
+		true)
+	err = buildDynamicPythonStub(componentdefinition, dynpythonstubfile)
+	if err != nil {
+		return err
+	}
+
+	PythonPackageInit := path.Join(outputFolder, "__init__.py")
+	log.Printf("Creating \"%s\"", PythonPackageInit)
+	packageinitfile, err := CreateLanguageFile(PythonPackageInit, indentString)
+	if err != nil {
+		return err
+	}
+	packageinitfile.WritePythonLicenseHeader(componentdefinition,
+		fmt.Sprintf("This is an autogenerated Python file that exports the Python bindings\n of %s as a package", libraryname),
+		true)
+	err = buildDynamicPythonPackageInit(componentdefinition, packageinitfile)
+	if err != nil {
+		return err
+	}
+
+	PythonTypedMarker := path.Join(outputFolder, "py.typed")
+	log.Printf("Creating \"%s\"", PythonTypedMarker)
+	_, err = CreateOutputFile(PythonTypedMarker)
+	if err != nil {
+		return err
+	}
+
+	PythonProject := path.Join(outputFolder, "pyproject.toml")
+	log.Printf("Creating \"%s\"", PythonProject)
+	pythonprojectfile, err := CreateLanguageFile(PythonProject, indentString)
+	if err != nil {
+		return err
+	}
+	err = buildDynamicPythonProject(componentdefinition, pythonprojectfile)
+	if err != nil {
+		return err
+	}
+
 	if len(outputFolderExample) > 0 {
 		DynamicPythonExample := path.Join(outputFolderExample, namespace+"_Example"+".py")
 		if forceRecreation || !FileExists(DynamicPythonExample) {
@@ -95,6 +142,9 @@ func buildDynamicPythonImplementation(componentdefinition ComponentDefinition, w
 	w.Writeln("import platform")
 	w.Writeln("import enum")
 	w.Writeln("import os")
+	if componentdefinition.hasDeprecations() {
+		w.Writeln("import warnings")
+	}
 	w.Writeln("")
 
 	if len(componentdefinition.ImportedComponentDefinitions) > 0 {
@@ -138,10 +188,23 @@ func buildDynamicPythonImplementation(componentdefinition ComponentDefinition, w
 	w.Writeln("  SUCCESS = 0")
 	for i := 0; i < len(componentdefinition.Errors.Errors); i++ {
 		merror := componentdefinition.Errors.Errors[i]
+		writePythonDeprecationComment(w, "  ", merror.ComponentDefinitionDeprecation, merror.Name)
 		w.Writeln("  %s = %d", merror.Name, merror.Code)
 	}
 	w.Writeln("")
 
+	if len(componentdefinition.Constants) > 0 {
+		w.Writeln("'''Definition of Constants")
+		w.Writeln("'''")
+		for _, constant := range componentdefinition.Constants {
+			if constant.Description != "" {
+				w.Writeln("#: %s", constant.Description)
+			}
+			w.Writeln("%s = %s", constant.Name, getPythonConstantValue(constant))
+		}
+		w.Writeln("")
+	}
+
 	w.Writeln("'''Definition of Function Table")
 	w.Writeln("'''")
 	w.Writeln("class FunctionTable:")
@@ -173,9 +236,11 @@ func buildDynamicPythonImplementation(componentdefinition ComponentDefinition, w
 			enum := componentdefinition.Enums[i]
 			w.Writeln("'''Definition of %s", enum.Name)
 			w.Writeln("'''")
+			writePythonDeprecationComment(w, "", enum.ComponentDefinitionDeprecation, enum.Name)
 			w.Writeln("class %s(CTypesEnum):", enum.Name)
 			for j := 0; j < len(enum.Options); j++ {
 				option := enum.Options[j]
+				writePythonDeprecationComment(w, "  ", option.ComponentDefinitionDeprecation, enum.Name+"."+option.Name)
 				w.Writeln("  %s = %d", option.Name, option.Value)
 			}
 		}
@@ -355,7 +420,7 @@ func buildDynamicPythonImplementation(componentdefinition ComponentDefinition, w
 			implementationLines = append(implementationLines, fmt.Sprintf("  raise E%sException(ErrorCodes.COULDNOTLOADLIBRARY, \"Unknown namespace \" + %s)", NameSpace, sParamName))
 		}
 
-		err = writeMethod(method, w, NameSpace, "Wrapper", implementationLines, true)
+		err = writeMethod(method, w, NameSpace, "Wrapper", ComponentDefinitionDeprecation{}, implementationLines, true)
 		if err != nil {
 			return err
 		}
@@ -377,7 +442,7 @@ func buildDynamicPythonImplementation(componentdefinition ComponentDefinition, w
 
 func generateCTypeParemeters(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassName string, isGlobal bool) ([]ctypesParameter, error) {
 	parameters := []ctypesParameter{}
-	if !isGlobal {
+	if !isGlobal && !method.IsStatic {
 		cParams := make([]ctypesParameter, 1)
 		cParams[0].ParamName = "Object"
 		cParams[0].ParamType = "ctypes.c_void_p"
@@ -430,7 +495,7 @@ func writeFunctionTableMethod(method ComponentDefinitionMethod, w LanguageWriter
 
 func getMethodCParams(method ComponentDefinitionMethod, NameSpace string, ClassName string, isGlobal bool) (string, error) {
 	parameters := ""
-	if !isGlobal {
+	if !isGlobal && !method.IsStatic {
 		parameters = "ctypes.c_void_p"
 	}
 	for k := 0; k < len(method.Params); k++ {
@@ -729,11 +794,17 @@ func writePythonClass(component ComponentDefinition, class ComponentDefinitionCl
 			parentClass = pythonBaseClassName
 		}
 		w.Writeln("class %s(%s):", class.ClassName, parentClass)
+		if class.ClassDescription != "" {
+			writePythonDocString(w, "  ", []string{class.ClassDescription})
+		}
 		w.Writeln("  def __init__(self, handle, wrapper):")
 		w.Writeln("    %s.__init__(self, handle, wrapper)", parentClass)
 
 	} else {
 		w.Writeln("class %s:", class.ClassName)
+		if class.ClassDescription != "" {
+			writePythonDocString(w, "  ", []string{class.ClassDescription})
+		}
 		w.Writeln("  def __init__(self, handle, wrapper):")
 		w.Writeln("    if not handle or not wrapper:")
 		w.Writeln("      raise E%sException(ErrorCodes.INVALIDPARAM)", NameSpace)
@@ -745,15 +816,31 @@ func writePythonClass(component ComponentDefinition, class ComponentDefinitionCl
 	}
 
 	for i := 0; i < len(class.Methods); i++ {
-		err := writeMethod(class.Methods[i], w, NameSpace, class.ClassName, []string{}, false)
+		err := writeMethod(class.Methods[i], w, NameSpace, class.ClassName, class.ComponentDefinitionDeprecation, []string{}, false)
 		if err != nil {
 			return err
 		}
 	}
+
+	for _, property := range class.Properties {
+		w.Writeln("  @property")
+		w.Writeln("  def %s(self):", property.Name)
+		if property.Description != "" {
+			writePythonDocString(w, "    ", []string{property.Description})
+		}
+		w.Writeln("    return self.%s()", property.GetterName())
+		w.Writeln("    ")
+		if !property.IsReadOnly() {
+			w.Writeln("  @%s.setter", property.Name)
+			w.Writeln("  def %s(self, %sValue):", property.Name, property.Name)
+			w.Writeln("    self.%s(%sValue)", property.SetterName(), property.Name)
+			w.Writeln("    ")
+		}
+	}
 	return nil
 }
 
-func writeMethod(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassName string, implementationLines []string, isGlobal bool) error {
+func writeMethod(method ComponentDefinitionMethod, w LanguageWriter, NameSpace string, ClassName string, classDeprecation ComponentDefinitionDeprecation, implementationLines []string, isGlobal bool) error {
 	preCallLines := []string{}
 	checkCallLines := []string{}
 	postCallLines := []string{}
@@ -763,16 +850,19 @@ func writeMethod(method ComponentDefinitionMethod, w LanguageWriter, NameSpace s
 
 	wrapperReference := "self"
 	selfReference := "None"
-	if !isGlobal {
+	hasInstance := !isGlobal && !method.IsStatic
+	if method.IsStatic {
+		wrapperReference = "wrapper"
+	} else if !isGlobal {
 		wrapperReference = "self._wrapper"
 		selfReference = "self"
 	}
 	cArguments := ""
-	if !isGlobal {
+	if hasInstance {
 		cArguments = "self._handle"
 	}
 	cCheckArguments := ""
-	if !isGlobal {
+	if hasInstance {
 		cCheckArguments = "self._handle"
 	}
 	doCheckCall := false
@@ -975,13 +1065,32 @@ func writeMethod(method ComponentDefinitionMethod, w LanguageWriter, NameSpace s
 			default:
 				return fmt.Errorf("Invalid parameter of type \"%s\" used as pass=\"%s\"", param.ParamType, param.ParamPass)
 			}
+			if param.HasDefault() {
+				pythonInParams = pythonInParams + "=" + getPythonDefaultValue(param)
+			}
 
 		}
 	}
 
 	exportName := GetCExportName(NameSpace, ClassName, method, isGlobal)
 
-	w.Writeln("  def %s(self%s):", method.MethodName, pythonInParams)
+	docStringLines, err := getPythonDocStringLines(method)
+	if err != nil {
+		return err
+	}
+	if method.IsStatic {
+		w.Writeln("  @staticmethod")
+		w.Writeln("  def %s(wrapper%s):", method.MethodName, pythonInParams)
+	} else {
+		w.Writeln("  def %s(self%s):", method.MethodName, pythonInParams)
+	}
+	writePythonDocString(w, "    ", docStringLines)
+	// A call warns once, about the method if it is deprecated itself, or else about its class
+	if method.IsDeprecated() {
+		writePythonDeprecationWarning(w, "    ", method.ComponentDefinitionDeprecation, ClassName+"."+method.MethodName)
+	} else {
+		writePythonDeprecationWarning(w, "    ", classDeprecation, ClassName)
+	}
 	w.Writelns("    ", preCallLines)
 	if doCheckCall {
 		w.Writeln("    %s.checkError(%s, %s.lib.%s(%s))", wrapperReference, selfReference, wrapperReference, exportName, cCheckArguments)
@@ -1044,3 +1153,462 @@ func buildDynamicPythonExample(componentdefinition ComponentDefinition, w Langua
 
 	return nil
 }
+
+// writePythonDeprecationWarning warns the caller of a method about the deprecated element with the given qualified name.
+// The warning is attributed to the frame of the caller.
+func writePythonDeprecationWarning(w LanguageWriter, indent string, deprecation ComponentDefinitionDeprecation, name string) {
+	if deprecation.IsDeprecated() {
+		w.Writeln(indent+"warnings.warn(%s, DeprecationWarning, stacklevel=2)", strconv.Quote(deprecation.DeprecationMessage(name)))
+	}
+}
+
+// writePythonDeprecationComment notes the deprecation of an enum, option or error code, which cannot warn at runtime
+func writePythonDeprecationComment(w LanguageWriter, indent string, deprecation ComponentDefinitionDeprecation, name string) {
+	if deprecation.IsDeprecated() {
+		w.Writeln(indent+"# %s", deprecation.DeprecationMessage(name))
+	}
+}
+
+// writePythonStubDeprecatedDecorator marks a deprecated class or method in the type stub
+func writePythonStubDeprecatedDecorator(w LanguageWriter, indent string, deprecation ComponentDefinitionDeprecation, name string) {
+	if deprecation.IsDeprecated() {
+		w.Writeln(indent+"@deprecated(%s)", strconv.Quote(deprecation.DeprecationMessage(name)))
+	}
+}
+
+// getPythonDefaultValue returns the Python literal of the default of an input parameter
+func getPythonDefaultValue(param ComponentDefinitionParam) string {
+	return getPythonLiteral(param.DefaultValue(), param.ParamType, param.ParamClass)
+}
+
+// getPythonConstantValue returns the Python literal of the value of a constant.
+// Floating point values need a decimal point, as Python would read them as int otherwise.
+func getPythonConstantValue(constant ComponentDefinitionConstant) string {
+	switch constant.Type {
+	case "single", "double":
+		return getFloatingPointLiteral(constant.Value, constant.Type)
+	}
+	return getPythonLiteral(constant.Value, constant.Type, "")
+}
+
+// getPythonConstantType returns the Python type of a constant
+func getPythonConstantType(constant ComponentDefinitionConstant) string {
+	switch constant.Type {
+	case "single", "double":
+		return "float"
+	case "bool":
+		return "bool"
+	case "string":
+		return "str"
+	}
+	return "int"
+}
+
+// getPythonLiteral returns the Python literal of a value of a scalar type, a string or an enum
+func getPythonLiteral(value string, valueType string, valueClass string) string {
+	switch valueType {
+	case "string":
+		return strconv.Quote(value)
+	case "enum":
+		return getPythonStubClassName(valueClass) + "." + value
+	case "bool":
+		if value == "true" {
+			return "True"
+		}
+		return "False"
+	}
+	return value
+}
+
+// getPythonStubClassName returns the name of an enum, struct, function type or class in a Python stub.
+// Entities of imported components are prefixed with the module of their component.
+func getPythonStubClassName(paramClass string) string {
+	subNameSpace, className, _ := decomposeParamClassName(paramClass)
+	if len(subNameSpace) > 0 {
+		return subNameSpace + "." + className
+	}
+	return className
+}
+
+// getPythonStubBasicType returns the Python type a ctypes value of a basic type is converted to
+func getPythonStubBasicType(paramType string) (string, error) {
+	switch paramType {
+	case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64":
+		return "int", nil
+	case "single", "double":
+		return "float", nil
+	case "bool":
+		return "bool", nil
+	case "pointer":
+		return "Optional[int]", nil
+	case "string":
+		return "str", nil
+	}
+	return "", fmt.Errorf("invalid basic type \"%s\" for Python stub", paramType)
+}
+
+// getPythonStubParamType returns the type of a parameter as it is passed to or returned by the Python binding
+func getPythonStubParamType(param ComponentDefinitionParam) (string, error) {
+	isInput := param.ParamPass == "in"
+	switch param.ParamType {
+	case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool", "pointer", "string":
+		return getPythonStubBasicType(param.ParamType)
+	case "enum", "struct", "functiontype", "class":
+		return getPythonStubClassName(param.ParamClass), nil
+	case "optionalclass":
+		return fmt.Sprintf("Optional[%s]", getPythonStubClassName(param.ParamClass)), nil
+	case "basicarray":
+		elementType, err := getPythonStubBasicType(param.ParamClass)
+		if err != nil {
+			return "", err
+		}
+		if isInput {
+			return fmt.Sprintf("Sequence[%s]", elementType), nil
+		}
+		return fmt.Sprintf("List[%s]", elementType), nil
+	case "structarray":
+		if isInput {
+			return fmt.Sprintf("Sequence[%s]", getPythonStubClassName(param.ParamClass)), nil
+		}
+		return fmt.Sprintf("List[%s]", getPythonStubClassName(param.ParamClass)), nil
+	}
+	return "", fmt.Errorf("invalid parameter type \"%s\" for Python stub (%s)", param.ParamType, param.ParamName)
+}
+
+// writePythonStubMethod writes the typed signature of a method of the Python binding
+func writePythonStubMethod(method ComponentDefinitionMethod, className string, w LanguageWriter) error {
+	arguments := "self"
+	if method.IsStatic {
+		arguments = "wrapper: Wrapper"
+	}
+	returnTypes := []string{}
+	for _, param := range method.Params {
+		paramType, err := getPythonStubParamType(param)
+		if err != nil {
+			return err
+		}
+		if param.ParamPass == "in" {
+			paramName := param.ParamName
+			switch param.ParamType {
+			case "class", "optionalclass":
+				paramName = paramName + "Object"
+			case "functiontype":
+				paramName = paramName + "Func"
+			}
+			arguments = arguments + fmt.Sprintf(", %s: %s", paramName, paramType)
+			if param.HasDefault() {
+				arguments = arguments + " = " + getPythonDefaultValue(param)
+			}
+		} else {
+			returnTypes = append(returnTypes, paramType)
+		}
+	}
+
+	returnType := "None"
+	if len(returnTypes) == 1 {
+		returnType = returnTypes[0]
+	} else if len(returnTypes) > 1 {
+		returnType = fmt.Sprintf("Tuple[%s]", strings.Join(returnTypes, ", "))
+	}
+
+	docStringLines, err := getPythonDocStringLines(method)
+	if err != nil {
+		return err
+	}
+	if method.IsStatic {
+		w.Writeln("  @staticmethod")
+	}
+	writePythonStubDeprecatedDecorator(w, "  ", method.ComponentDefinitionDeprecation, className+"."+method.MethodName)
+	if len(docStringLines) == 0 {
+		w.Writeln("  def %s(%s) -> %s: ...", method.MethodName, arguments, returnType)
+		return nil
+	}
+	w.Writeln("  def %s(%s) -> %s:", method.MethodName, arguments, returnType)
+	writePythonDocString(w, "    ", docStringLines)
+	return nil
+}
+
+// writePythonStubProperty writes the type hints of the getter and the setter of a property
+func writePythonStubProperty(property ComponentDefinitionProperty, w LanguageWriter) error {
+	getter := property.Getter()
+	propertyType, err := getPythonStubParamType(getter.Params[0])
+	if err != nil {
+		return err
+	}
+	w.Writeln("  @property")
+	if property.Description == "" {
+		w.Writeln("  def %s(self) -> %s: ...", property.Name, propertyType)
+	} else {
+		w.Writeln("  def %s(self) -> %s:", property.Name, propertyType)
+		writePythonDocString(w, "    ", []string{property.Description})
+	}
+	if !property.IsReadOnly() {
+		setter := property.Setter()
+		valueType, err := getPythonStubParamType(setter.Params[0])
+		if err != nil {
+			return err
+		}
+		w.Writeln("  @%s.setter", property.Name)
+		w.Writeln("  def %s(self, %sValue: %s) -> None: ...", property.Name, property.Name, valueType)
+	}
+	return nil
+}
+
+// getPythonDocStringLines returns the docstring of a method of the Python binding, with the descriptions
+// of its arguments and return values
+func getPythonDocStringLines(method ComponentDefinitionMethod) ([]string, error) {
+	argumentLines := []string{}
+	returnLines := []string{}
+	for _, param := range method.Params {
+		paramType, err := getPythonStubParamType(param)
+		if err != nil {
+			return nil, err
+		}
+		if param.ParamPass == "in" {
+			paramName := param.ParamName
+			switch param.ParamType {
+			case "class", "optionalclass":
+				paramName = paramName + "Object"
+			case "functiontype":
+				paramName = paramName + "Func"
+			}
+			argumentLines = append(argumentLines, fmt.Sprintf("  %s (%s): %s", paramName, paramType, param.ParamDescription))
+		} else {
+			returnLines = append(returnLines, fmt.Sprintf("  %s (%s): %s", param.ParamName, paramType, param.ParamDescription))
+		}
+	}
+
+	lines := []string{}
+	if method.MethodDescription != "" {
+		lines = append(lines, method.MethodDescription)
+	}
+	if len(argumentLines) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Args:")
+		lines = append(lines, argumentLines...)
+	}
+	if len(returnLines) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		if len(returnLines) == 1 {
+			lines = append(lines, "Returns:")
+		} else {
+			lines = append(lines, "Returns a tuple of:")
+		}
+		lines = append(lines, returnLines...)
+	}
+	return lines, nil
+}
+
+// writePythonDocString writes lines of text as a docstring
+func writePythonDocString(w LanguageWriter, indent string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	if len(lines) == 1 {
+		w.Writeln(indent+"'''%s'''", escaper.Replace(lines[0]))
+		return
+	}
+	w.Writeln(indent+"'''%s", escaper.Replace(lines[0]))
+	for _, line := range lines[1:] {
+		if line == "" {
+			w.Writeln("")
+		} else {
+			w.Writeln(indent+"%s", escaper.Replace(line))
+		}
+	}
+	w.Writeln(indent + "'''")
+}
+
+// buildDynamicPythonStub writes the type stub of the Python binding. It declares the same names
+// as the generated module, with the types of the values the binding accepts and returns.
+func buildDynamicPythonStub(componentdefinition ComponentDefinition, w LanguageWriter) error {
+	NameSpace := componentdefinition.NameSpace
+
+	w.Writeln("")
+	w.Writeln("import ctypes")
+	w.Writeln("import enum")
+	w.Writeln("from typing import Callable, List, Optional, Sequence, Tuple")
+	if componentdefinition.hasDeprecations() {
+		w.Writeln("from typing_extensions import deprecated")
+	}
+	for _, subNameSpace := range componentdefinition.importedNameSpaces() {
+		w.Writeln("import %s", subNameSpace)
+	}
+	w.Writeln("")
+	w.Writeln("name: str")
+	w.Writeln("")
+
+	w.Writeln("class E%sException(Exception):", NameSpace)
+	w.Writeln("  def __init__(self, code: int, message: str = ...) -> None: ...")
+	w.Writeln("")
+
+	w.Writeln("class BindingVersion(enum.IntEnum):")
+	w.Writeln("  MAJOR = %d", majorVersion(componentdefinition.Version))
+	w.Writeln("  MINOR = %d", minorVersion(componentdefinition.Version))
+	w.Writeln("  MICRO = %d", microVersion(componentdefinition.Version))
+	w.Writeln("")
+
+	w.Writeln("class ErrorCodes(enum.IntEnum):")
+	w.Writeln("  SUCCESS = 0")
+	for _, merror := range componentdefinition.Errors.Errors {
+		writePythonDeprecationComment(w, "  ", merror.ComponentDefinitionDeprecation, merror.Name)
+		w.Writeln("  %s = %d", merror.Name, merror.Code)
+	}
+	w.Writeln("")
+
+	for _, constant := range componentdefinition.Constants {
+		w.Writeln("%s: %s", constant.Name, getPythonConstantType(constant))
+	}
+	if len(componentdefinition.Constants) > 0 {
+		w.Writeln("")
+	}
+
+	if len(componentdefinition.Enums) > 0 {
+		w.Writeln("class CTypesEnum(enum.IntEnum):")
+		w.Writeln("  @staticmethod")
+		w.Writeln("  def from_param(obj: int) -> int: ...")
+		w.Writeln("")
+		for _, enum := range componentdefinition.Enums {
+			writePythonStubDeprecatedDecorator(w, "", enum.ComponentDefinitionDeprecation, enum.Name)
+			w.Writeln("class %s(CTypesEnum):", enum.Name)
+			for _, option := range enum.Options {
+				writePythonDeprecationComment(w, "  ", option.ComponentDefinitionDeprecation, enum.Name+"."+option.Name)
+				w.Writeln("  %s = %d", option.Name, option.Value)
+			}
+			w.Writeln("")
+		}
+	}
+
+	for _, _struct := range componentdefinition.Structs {
+		w.Writeln("class %s(ctypes.Structure):", _struct.Name)
+		if len(_struct.Members) == 0 {
+			w.Writeln("  ...")
+		}
+		for _, member := range _struct.Members {
+			memberType := ""
+			if member.Rows > 0 {
+				elementType, err := getCTypesParameterTypeName(member.Type, NameSpace, member.Class, true)
+				if err != nil {
+					return err
+				}
+				if member.Type == "enum" {
+					elementType = "ctypes.c_int32"
+				}
+				memberType = fmt.Sprintf("ctypes.Array[%s]", elementType)
+				if member.Columns > 0 {
+					memberType = fmt.Sprintf("ctypes.Array[%s]", memberType)
+				}
+			} else if member.Type == "enum" {
+				memberType = "int"
+			} else {
+				var err error
+				memberType, err = getPythonStubBasicType(member.Type)
+				if err != nil {
+					return err
+				}
+			}
+			w.Writeln("  %s: %s", member.Name, memberType)
+		}
+		w.Writeln("")
+	}
+
+	for _, _func := range componentdefinition.Functions {
+		w.Writeln("class %s:", _func.FunctionName)
+		w.Writeln("  def __init__(self, callback: Callable[..., None]) -> None: ...")
+		w.Writeln("")
+	}
+
+	w.Writeln("class Wrapper:")
+	w.Writeln("  def __init__(self, libraryName: Optional[str] = None, symbolLookupMethodAddress: Optional[int] = None) -> None: ...")
+	w.Writeln("  def checkError(self, instance: Optional[%s], errorCode: int) -> None: ...", componentdefinition.Global.BaseClassName)
+	for _, method := range componentdefinition.Global.Methods {
+		err := writePythonStubMethod(method, "Wrapper", w)
+		if err != nil {
+			return err
+		}
+	}
+	w.Writeln("")
+
+	for _, class := range componentdefinition.Classes {
+		writePythonStubDeprecatedDecorator(w, "", class.ComponentDefinitionDeprecation, class.ClassName)
+		if componentdefinition.isBaseClass(class) {
+			w.Writeln("class %s:", class.ClassName)
+			if class.ClassDescription != "" {
+				writePythonDocString(w, "  ", []string{class.ClassDescription})
+			}
+			w.Writeln("  def __init__(self, handle: ctypes.c_void_p, wrapper: Wrapper) -> None: ...")
+		} else {
+			parentClass := class.ParentClass
+			if parentClass == "" {
+				parentClass = componentdefinition.Global.BaseClassName
+			}
+			w.Writeln("class %s(%s):", class.ClassName, parentClass)
+			if class.ClassDescription != "" {
+				writePythonDocString(w, "  ", []string{class.ClassDescription})
+			} else if len(class.Methods) == 0 {
+				w.Writeln("  ...")
+			}
+		}
+		for _, method := range class.Methods {
+			err := writePythonStubMethod(method, class.ClassName, w)
+			if err != nil {
+				return err
+			}
+		}
+		for _, property := range class.Properties {
+			err := writePythonStubProperty(property, w)
+			if err != nil {
+				return err
+			}
+		}
+		w.Writeln("")
+	}
+
+	return nil
+}
+
+// buildDynamicPythonPackageInit writes the __init__.py that turns the folder of the binding into a package
+func buildDynamicPythonPackageInit(componentdefinition ComponentDefinition, w LanguageWriter) error {
+	w.Writeln("")
+	w.Writeln("from .%s import *", componentdefinition.NameSpace)
+	return nil
+}
+
+// buildDynamicPythonProject writes the pyproject.toml that packages the binding together with
+// the shared library of the component, if it has been copied next to the binding.
+func buildDynamicPythonProject(componentdefinition ComponentDefinition, w LanguageWriter) error {
+	NameSpace := componentdefinition.NameSpace
+	BaseName := componentdefinition.BaseName
+
+	w.Writeln("# This file has been generated by the Automatic Component Toolkit (ACT) version %s.", componentdefinition.ACTVersion)
+	w.Writeln("# Copy the shared library %s into this folder before building the package.", BaseName)
+	w.Writeln("")
+	w.Writeln("[build-system]")
+	w.Writeln("requires = [\"setuptools>=61.0\"]")
+	w.Writeln("build-backend = \"setuptools.build_meta\"")
+	w.Writeln("")
+	w.Writeln("[project]")
+	w.Writeln("name = \"%s\"", strings.ToLower(BaseName))
+	w.Writeln("version = \"%d.%d.%d\"", majorVersion(componentdefinition.Version), minorVersion(componentdefinition.Version), microVersion(componentdefinition.Version))
+	w.Writeln("description = %q", "Python bindings of "+componentdefinition.LibraryName)
+	w.Writeln("requires-python = \">=3.6\"")
+	dependencies := []string{}
+	for _, subNameSpace := range componentdefinition.importedNameSpaces() {
+		subComponent := componentdefinition.ImportedComponentDefinitions[subNameSpace]
+		dependencies = append(dependencies, fmt.Sprintf("\"%s\"", strings.ToLower(subComponent.BaseName)))
+	}
+	w.Writeln("dependencies = [%s]", strings.Join(dependencies, ", "))
+	w.Writeln("")
+	w.Writeln("[tool.setuptools]")
+	w.Writeln("packages = [\"%s\"]", NameSpace)
+	w.Writeln("package-dir = { %s = \".\" }", NameSpace)
+	w.Writeln("")
+	w.Writeln("[tool.setuptools.package-data]")
+	w.Writeln("%s = [\"*.pyi\", \"py.typed\", \"%s.dll\", \"%s.so\", \"%s.dylib\"]", NameSpace, BaseName, BaseName, BaseName)
+	return nil
+}
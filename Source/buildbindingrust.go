@@ -0,0 +1,1341 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// buildbindingrust.go
+// functions to generate a Rust crate of a library's API, consisting of raw declarations of the C ABI
+// and safe wrapper types on top of them.
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// rustKeywords contains all identifiers that are reserved in Rust
+var rustKeywords = map[string]bool{
+	"as": true, "break": true, "const": true, "continue": true, "crate": true, "else": true, "enum": true,
+	"extern": true, "false": true, "fn": true, "for": true, "if": true, "impl": true, "in": true, "let": true,
+	"loop": true, "match": true, "mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "static": true, "struct": true, "super": true, "trait": true, "true": true,
+	"type": true, "unsafe": true, "use": true, "where": true, "while": true, "async": true, "await": true,
+	"dyn": true, "abstract": true, "become": true, "box": true, "do": true, "final": true, "macro": true,
+	"override": true, "priv": true, "typeof": true, "unsized": true, "virtual": true, "yield": true, "try": true,
+}
+
+// rustParameter is a parameter of a raw function declaration
+type rustParameter struct {
+	ParamName string
+	ParamType string
+}
+
+// rustMethodCode collects the code fragments of a safe method of the Rust bindings
+type rustMethodCode struct {
+	Signature    []string
+	ReturnTypes  []string
+	ReturnValues []string
+	PreCall      []string
+	CheckArgs    []string
+	CallArgs     []string
+	CheckCall    []string
+	PostCall     []string
+	DoCheckCall  bool
+}
+
+// BuildBindingRust builds a Rust crate of a library's API. The crate loads the library dynamically
+// and wraps its classes in types that release their instances when they are dropped.
+func BuildBindingRust(component ComponentDefinition, outputFolder string, outputFolderExample string, indentString string) error {
+	forceRecreation := false
+
+	LibraryName := component.LibraryName
+
+	outputFolderSource := path.Join(outputFolder, "src")
+	err := CreateOutputFolder(outputFolderSource)
+	if err != nil {
+		return err
+	}
+
+	CargoManifest := path.Join(outputFolder, "Cargo.toml")
+	log.Printf("Creating \"%s\"", CargoManifest)
+	cargofile, err := CreateLanguageFile(CargoManifest, indentString)
+	if err != nil {
+		return err
+	}
+	err = buildRustCargoManifest(component, cargofile)
+	if err != nil {
+		return err
+	}
+
+	RustSysName := path.Join(outputFolderSource, "sys.rs")
+	log.Printf("Creating \"%s\"", RustSysName)
+	sysfile, err := CreateLanguageFile(RustSysName, indentString)
+	if err != nil {
+		return err
+	}
+	sysfile.WriteCLicenseHeader(component,
+		fmt.Sprintf("This is an autogenerated Rust file that declares the raw C interface of %s", LibraryName),
+		true)
+	err = buildRustSys(component, sysfile)
+	if err != nil {
+		return err
+	}
+
+	RustLibName := path.Join(outputFolderSource, "lib.rs")
+	log.Printf("Creating \"%s\"", RustLibName)
+	libfile, err := CreateLanguageFile(RustLibName, indentString)
+	if err != nil {
+		return err
+	}
+	libfile.WriteCLicenseHeader(component,
+		fmt.Sprintf("This is an autogenerated Rust file in order to allow an easy\n and safe use of %s", LibraryName),
+		true)
+	err = buildRustLib(component, libfile)
+	if err != nil {
+		return err
+	}
+
+	if len(outputFolderExample) > 0 {
+		outputFolderExampleSource := path.Join(outputFolderExample, "src")
+		err = CreateOutputFolder(outputFolderExampleSource)
+		if err != nil {
+			return err
+		}
+
+		RustExampleManifest := path.Join(outputFolderExample, "Cargo.toml")
+		if forceRecreation || !FileExists(RustExampleManifest) {
+			log.Printf("Creating \"%s\"", RustExampleManifest)
+			examplecargofile, err := CreateLanguageFile(RustExampleManifest, indentString)
+			if err != nil {
+				return err
+			}
+			buildRustExampleManifest(component, examplecargofile)
+		} else {
+			log.Printf("Omitting recreation of Rust example manifest \"%s\"", RustExampleManifest)
+		}
+
+		RustExample := path.Join(outputFolderExampleSource, "main.rs")
+		if forceRecreation || !FileExists(RustExample) {
+			log.Printf("Creating \"%s\"", RustExample)
+			examplefile, err := CreateLanguageFile(RustExample, indentString)
+			if err != nil {
+				return err
+			}
+			examplefile.WriteCLicenseHeader(component,
+				fmt.Sprintf("This is an autogenerated Rust application that demonstrates the\n usage of the Rust bindings of %s", LibraryName),
+				true)
+			buildRustExample(component, examplefile)
+		} else {
+			log.Printf("Omitting recreation of Rust example \"%s\"", RustExample)
+		}
+	}
+
+	return nil
+}
+
+// rustCrateName returns the name of the crate that is generated for a component
+func rustCrateName(component ComponentDefinition) string {
+	return strings.ToLower(strings.NewReplacer("-", "_", ".", "_").Replace(component.BaseName))
+}
+
+// rustCrateVersion returns the version of a component in the semantic versioning format of Cargo
+func rustCrateVersion(component ComponentDefinition) string {
+	version := fmt.Sprintf("%d.%d.%d", majorVersion(component.Version), minorVersion(component.Version), microVersion(component.Version))
+	if len(preReleaseInfo(component.Version)) > 0 {
+		version = version + "-" + preReleaseInfo(component.Version)
+	}
+	if len(buildInfo(component.Version)) > 0 {
+		version = version + "+" + buildInfo(component.Version)
+	}
+	return version
+}
+
+// rustSnakeCase converts an identifier of the component definition into snake case
+func rustSnakeCase(name string) string {
+	runes := []rune(name)
+	var result strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' {
+				previousIsLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+				nextIsLower := (i+1 < len(runes)) && unicode.IsLower(runes[i+1])
+				if previousIsLower || (unicode.IsUpper(runes[i-1]) && nextIsLower) {
+					result.WriteRune('_')
+				}
+			}
+			result.WriteRune(unicode.ToLower(r))
+		} else {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
+
+// rustIdentifier converts the name of a method or parameter into a valid snake case identifier.
+// Names that clash with keywords, with the methods every class provides or with local variables of the
+// generated code get a trailing underscore.
+func rustIdentifier(name string) string {
+	identifier := rustSnakeCase(name)
+	if rustKeywords[identifier] || identifier == "handle" || identifier == "wrapper" || identifier == "result" {
+		identifier = identifier + "_"
+	}
+	return identifier
+}
+
+// rustResolveClass splits a param class into the path prefix of the crate that defines it and its name
+func rustResolveClass(component ComponentDefinition, paramClass string) (string, string, string, error) {
+	namespace, className, err := decomposeParamClassName(paramClass)
+	if err != nil {
+		return "", "", "", err
+	}
+	if len(namespace) == 0 || namespace == component.NameSpace {
+		return "", component.NameSpace, className, nil
+	}
+	subComponent, ok := component.ImportedComponentDefinitions[namespace]
+	if !ok {
+		return "", "", "", fmt.Errorf("unknown namespace \"%s\" of class \"%s\"", namespace, paramClass)
+	}
+	return "::" + rustCrateName(subComponent) + "::", namespace, className, nil
+}
+
+// getRustScalarType returns the Rust type of a scalar type of the component definition
+func getRustScalarType(paramType string) (string, error) {
+	switch paramType {
+	case "uint8":
+		return "u8", nil
+	case "uint16":
+		return "u16", nil
+	case "uint32":
+		return "u32", nil
+	case "uint64":
+		return "u64", nil
+	case "int8":
+		return "i8", nil
+	case "int16":
+		return "i16", nil
+	case "int32":
+		return "i32", nil
+	case "int64":
+		return "i64", nil
+	case "bool":
+		return "bool", nil
+	case "single":
+		return "f32", nil
+	case "double":
+		return "f64", nil
+	case "pointer":
+		return "*mut c_void", nil
+	}
+	return "", fmt.Errorf("invalid scalar type \"%s\" for Rust", paramType)
+}
+
+// getRustScalarDefault returns the value an output variable of a scalar type is initialized with
+func getRustScalarDefault(paramType string) string {
+	switch paramType {
+	case "bool":
+		return "false"
+	case "single", "double":
+		return "0.0"
+	case "pointer":
+		return "std::ptr::null_mut()"
+	}
+	return "0"
+}
+
+// getRustSysTypeName returns the type of a parameter's class as it is declared in the sys module.
+// sysPrefix is the path under which the sys module of the component is visible.
+func getRustSysTypeName(component ComponentDefinition, paramType string, paramClass string, sysPrefix string) (string, error) {
+	switch paramType {
+	case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "bool", "single", "double":
+		return getRustScalarType(paramType)
+	case "pointer":
+		return "*mut c_void", nil
+	case "string":
+		return "c_char", nil
+	case "basicarray":
+		return getRustScalarType(paramClass)
+	case "class", "optionalclass":
+		return sysPrefix + component.NameSpace + "Handle", nil
+	case "enum", "struct", "structarray", "functiontype":
+		cratePrefix, namespace, className, err := rustResolveClass(component, paramClass)
+		if err != nil {
+			return "", err
+		}
+		if len(cratePrefix) > 0 {
+			sysPrefix = cratePrefix + "sys::"
+		}
+		switch paramType {
+		case "enum":
+			return sysPrefix + "e" + namespace + className, nil
+		case "functiontype":
+			return sysPrefix + namespace + className, nil
+		}
+		return sysPrefix + "s" + namespace + className, nil
+	}
+	return "", fmt.Errorf("invalid parameter type \"%s\" for Rust", paramType)
+}
+
+// generateRustSysParameters returns the raw parameters of the C ABI that represent a parameter
+func generateRustSysParameters(component ComponentDefinition, param ComponentDefinitionParam, className string, methodName string) ([]rustParameter, error) {
+	typeName, err := getRustSysTypeName(component, param.ParamType, param.ParamClass, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s for %s.%s (%s)", err.Error(), className, methodName, param.ParamName)
+	}
+
+	switch param.ParamPass {
+	case "in":
+		switch param.ParamType {
+		case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool", "pointer", "enum", "class", "optionalclass", "functiontype":
+			return []rustParameter{{"n" + param.ParamName, typeName}}, nil
+		case "string":
+			return []rustParameter{{"p" + param.ParamName, "*const c_char"}}, nil
+		case "struct":
+			return []rustParameter{{"p" + param.ParamName, "*const " + typeName}}, nil
+		case "basicarray", "structarray":
+			return []rustParameter{
+				{"n" + param.ParamName + "BufferSize", "u64"},
+				{"p" + param.ParamName + "Buffer", "*const " + typeName}}, nil
+		}
+
+	case "out", "return":
+		switch param.ParamType {
+		case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool", "pointer", "enum", "struct", "class", "optionalclass":
+			return []rustParameter{{"p" + param.ParamName, "*mut " + typeName}}, nil
+		case "string":
+			return []rustParameter{
+				{"n" + param.ParamName + "BufferSize", "u32"},
+				{"p" + param.ParamName + "NeededChars", "*mut u32"},
+				{"p" + param.ParamName + "Buffer", "*mut c_char"}}, nil
+		case "basicarray", "structarray":
+			return []rustParameter{
+				{"n" + param.ParamName + "BufferSize", "u64"},
+				{"p" + param.ParamName + "NeededCount", "*mut u64"},
+				{"p" + param.ParamName + "Buffer", "*mut " + typeName}}, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid method parameter passing \"%s\" for %s.%s (%s)", param.ParamPass, className, methodName, param.ParamName)
+	}
+
+	return nil, fmt.Errorf("invalid method parameter type \"%s\" for %s.%s (%s)", param.ParamType, className, methodName, param.ParamName)
+}
+
+// getRustSysParameterList returns the raw parameter list of a function or a method of the C ABI
+func getRustSysParameterList(component ComponentDefinition, params []ComponentDefinitionParam, className string, methodName string, isGlobal bool) (string, error) {
+	parameters := []string{}
+	if !isGlobal && len(className) > 0 {
+		parameters = append(parameters, fmt.Sprintf("p%s: %s_%s", className, component.NameSpace, className))
+	}
+	for _, param := range params {
+		rustParams, err := generateRustSysParameters(component, param, className, methodName)
+		if err != nil {
+			return "", err
+		}
+		for _, rustParam := range rustParams {
+			parameters = append(parameters, fmt.Sprintf("%s: %s", rustParam.ParamName, rustParam.ParamType))
+		}
+	}
+	return strings.Join(parameters, ", "), nil
+}
+
+// getRustMethodPointerName returns the name of the function pointer type of a method in the sys module
+func getRustMethodPointerName(NameSpace string, ClassName string, method ComponentDefinitionMethod, isGlobal bool) string {
+	if isGlobal {
+		return fmt.Sprintf("P%s%sPtr", NameSpace, method.MethodName)
+	}
+	return fmt.Sprintf("P%s%s_%sPtr", NameSpace, ClassName, method.MethodName)
+}
+
+// writeRustDocComment writes a description as a Rust doc comment
+func writeRustDocComment(w LanguageWriter, indent string, description string) {
+	if len(description) > 0 {
+		w.Writeln(indent+"/// %s", description)
+	}
+}
+
+func buildRustCargoManifest(component ComponentDefinition, w LanguageWriter) error {
+	w.Writeln("# This file has been generated by the Automatic Component Toolkit (ACT) version %s.", component.ACTVersion)
+	w.Writeln("")
+	w.Writeln("[package]")
+	w.Writeln("name = \"%s\"", rustCrateName(component))
+	w.Writeln("version = \"%s\"", rustCrateVersion(component))
+	w.Writeln("edition = \"2018\"")
+	w.Writeln("description = %q", "Rust bindings of "+component.LibraryName)
+	w.Writeln("")
+	w.Writeln("[lib]")
+	w.Writeln("path = \"src/lib.rs\"")
+	w.Writeln("")
+	w.Writeln("[dependencies]")
+	for _, namespace := range component.importedNameSpaces() {
+		subComponent := component.ImportedComponentDefinitions[namespace]
+		w.Writeln("%s = { path = \"../../../%s_component/Bindings/Rust\" }", rustCrateName(subComponent), subComponent.NameSpace)
+	}
+	return nil
+}
+
+func buildRustSys(component ComponentDefinition, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	NAMESPACE := strings.ToUpper(NameSpace)
+	global := component.Global
+
+	w.Writeln("//! Raw declarations of the C interface of %s", component.LibraryName)
+	w.Writeln("")
+	w.Writeln("#![allow(non_camel_case_types, non_snake_case, dead_code)]")
+	w.Writeln("")
+	w.Writeln("use std::ffi::CStr;")
+	w.Writeln("use std::os::raw::{c_char, c_void};")
+	w.Writeln("")
+
+	w.Writeln("pub const %s_VERSION_MAJOR: u32 = %d;", NAMESPACE, majorVersion(component.Version))
+	w.Writeln("pub const %s_VERSION_MINOR: u32 = %d;", NAMESPACE, minorVersion(component.Version))
+	w.Writeln("pub const %s_VERSION_MICRO: u32 = %d;", NAMESPACE, microVersion(component.Version))
+	w.Writeln("")
+	w.Writeln("pub type %sResult = i32;", NameSpace)
+	w.Writeln("pub type %sHandle = *mut c_void;", NameSpace)
+	w.Writeln("")
+
+	w.Writeln("pub const %s_SUCCESS: %sResult = 0;", NAMESPACE, NameSpace)
+	for _, merror := range component.Errors.Errors {
+		writeRustDocComment(w, "", merror.Description)
+		w.Writeln("pub const %s_ERROR_%s: %sResult = %d;", NAMESPACE, merror.Name, NameSpace, merror.Code)
+	}
+	w.Writeln("")
+
+	for _, class := range component.Classes {
+		w.Writeln("pub type %s_%s = %sHandle;", NameSpace, class.ClassName, NameSpace)
+	}
+	w.Writeln("")
+
+	for _, enum := range component.Enums {
+		w.Writeln("pub type e%s%s = i32;", NameSpace, enum.Name)
+	}
+	if len(component.Enums) > 0 {
+		w.Writeln("")
+	}
+
+	for _, structinfo := range component.Structs {
+		w.Writeln("#[repr(C, packed)]")
+		w.Writeln("#[derive(Debug, Clone, Copy)]")
+		w.Writeln("pub struct s%s%s {", NameSpace, structinfo.Name)
+		for _, member := range structinfo.Members {
+			memberType, err := getRustSysTypeName(component, member.Type, member.Class, "")
+			if err != nil {
+				return err
+			}
+			if member.Type == "enum" {
+				memberType = "i32"
+			}
+			if member.Rows > 0 {
+				memberType = fmt.Sprintf("[%s; %d]", memberType, member.Rows)
+				if member.Columns > 0 {
+					memberType = fmt.Sprintf("[%s; %d]", memberType, member.Columns)
+				}
+			}
+			w.Writeln("  pub m_%s: %s,", member.Name, memberType)
+		}
+		w.Writeln("}")
+		w.Writeln("")
+	}
+
+	for _, functiontype := range component.Functions {
+		parameters, err := getRustSysParameterList(component, functiontype.Params, "", functiontype.FunctionName, true)
+		if err != nil {
+			return err
+		}
+		writeRustDocComment(w, "", functiontype.FunctionDescription)
+		w.Writeln("pub type %s%s = Option<unsafe extern \"C\" fn(%s)>;", NameSpace, functiontype.FunctionName, parameters)
+		w.Writeln("")
+	}
+
+	w.Writeln("/// Looks up the address of an exported function of the library by its name")
+	w.Writeln("pub type %sSymbolLookupMethod = unsafe extern \"C\" fn(pSymbolName: *const c_char, pSymbolAddress: *mut *mut c_void) -> %sResult;", NameSpace, NameSpace)
+	w.Writeln("")
+
+	exports := []string{}
+	pointerTypes := []string{}
+	writeMethodPointer := func(method ComponentDefinitionMethod, className string, isGlobal bool) error {
+		parameters, err := getRustSysParameterList(component, method.Params, className, method.MethodName, isGlobal || method.IsStatic)
+		if err != nil {
+			return err
+		}
+		pointerName := getRustMethodPointerName(NameSpace, className, method, isGlobal)
+		writeRustDocComment(w, "", method.MethodDescription)
+		w.Writeln("pub type %s = unsafe extern \"C\" fn(%s) -> %sResult;", pointerName, parameters, NameSpace)
+		w.Writeln("")
+		exports = append(exports, GetCExportName(NameSpace, className, method, isGlobal))
+		pointerTypes = append(pointerTypes, pointerName)
+		return nil
+	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			err := writeMethodPointer(method, class.ClassName, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, method := range global.Methods {
+		err := writeMethodPointer(method, "", true)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Writeln("/// All exported functions of the library")
+	w.Writeln("pub struct %sFunctionTable {", NameSpace)
+	for i, export := range exports {
+		w.Writeln("  pub %s: %s,", export, pointerTypes[i])
+	}
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl %sFunctionTable {", NameSpace)
+	w.Writeln("  /// Resolves all exported functions through a lookup function that returns null for unknown symbols.")
+	w.Writeln("  /// Fails with the name of the first symbol that could not be found.")
+	w.Writeln("  ///")
+	w.Writeln("  /// # Safety")
+	w.Writeln("  /// The lookup function must return addresses of functions with the declared signatures.")
+	w.Writeln("  pub unsafe fn load<F>(mut lookup: F) -> Result<%sFunctionTable, String>", NameSpace)
+	w.Writeln("  where")
+	w.Writeln("    F: FnMut(&CStr) -> *mut c_void,")
+	w.Writeln("  {")
+	w.Writeln("    Ok(%sFunctionTable {", NameSpace)
+	for _, export := range exports {
+		w.Writeln("      %s: std::mem::transmute(load_symbol(&mut lookup, b\"%s\\0\")?),", export, export)
+	}
+	w.Writeln("    })")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("unsafe fn load_symbol<F>(lookup: &mut F, name: &'static [u8]) -> Result<*mut c_void, String>")
+	w.Writeln("where")
+	w.Writeln("  F: FnMut(&CStr) -> *mut c_void,")
+	w.Writeln("{")
+	w.Writeln("  let name = CStr::from_bytes_with_nul_unchecked(name);")
+	w.Writeln("  let address = lookup(name);")
+	w.Writeln("  if address.is_null() {")
+	w.Writeln("    Err(name.to_string_lossy().into_owned())")
+	w.Writeln("  } else {")
+	w.Writeln("    Ok(address)")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("/// A shared library that has been loaded at runtime")
+	w.Writeln("pub struct Library {")
+	w.Writeln("  handle: *mut c_void,")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl Library {")
+	w.Writeln("  /// Loads a shared library from a file")
+	w.Writeln("  pub fn open(file_name: &str) -> Result<Library, String> {")
+	w.Writeln("    let name = std::ffi::CString::new(file_name).map_err(|_| format!(\"invalid library name \\\"{}\\\"\", file_name))?;")
+	w.Writeln("    let handle = unsafe { platform::open(name.as_ptr()) };")
+	w.Writeln("    if handle.is_null() {")
+	w.Writeln("      return Err(format!(\"could not load \\\"{}\\\": {}\", file_name, unsafe { platform::last_error() }));")
+	w.Writeln("    }")
+	w.Writeln("    Ok(Library { handle })")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Returns the address of an exported symbol, or null if the library does not export it")
+	w.Writeln("  pub fn symbol(&self, name: &CStr) -> *mut c_void {")
+	w.Writeln("    unsafe { platform::symbol(self.handle, name.as_ptr()) }")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl Drop for Library {")
+	w.Writeln("  fn drop(&mut self) {")
+	w.Writeln("    unsafe { platform::close(self.handle) };")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("#[cfg(unix)]")
+	w.Writeln("mod platform {")
+	w.Writeln("  use std::ffi::CStr;")
+	w.Writeln("  use std::os::raw::{c_char, c_int, c_void};")
+	w.Writeln("")
+	w.Writeln("  const RTLD_NOW: c_int = 2;")
+	w.Writeln("")
+	w.Writeln("  #[cfg_attr(any(target_os = \"linux\", target_os = \"android\"), link(name = \"dl\"))]")
+	w.Writeln("  extern \"C\" {")
+	w.Writeln("    fn dlopen(filename: *const c_char, flags: c_int) -> *mut c_void;")
+	w.Writeln("    fn dlsym(handle: *mut c_void, symbol: *const c_char) -> *mut c_void;")
+	w.Writeln("    fn dlclose(handle: *mut c_void) -> c_int;")
+	w.Writeln("    fn dlerror() -> *mut c_char;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn open(file_name: *const c_char) -> *mut c_void {")
+	w.Writeln("    dlopen(file_name, RTLD_NOW)")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn symbol(handle: *mut c_void, name: *const c_char) -> *mut c_void {")
+	w.Writeln("    dlsym(handle, name)")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn close(handle: *mut c_void) {")
+	w.Writeln("    dlclose(handle);")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn last_error() -> String {")
+	w.Writeln("    let message = dlerror();")
+	w.Writeln("    if message.is_null() {")
+	w.Writeln("      String::new()")
+	w.Writeln("    } else {")
+	w.Writeln("      CStr::from_ptr(message).to_string_lossy().into_owned()")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("#[cfg(windows)]")
+	w.Writeln("mod platform {")
+	w.Writeln("  use std::os::raw::{c_char, c_int, c_void};")
+	w.Writeln("")
+	w.Writeln("  #[link(name = \"kernel32\")]")
+	w.Writeln("  extern \"system\" {")
+	w.Writeln("    fn LoadLibraryA(file_name: *const c_char) -> *mut c_void;")
+	w.Writeln("    fn GetProcAddress(module: *mut c_void, name: *const c_char) -> *mut c_void;")
+	w.Writeln("    fn FreeLibrary(module: *mut c_void) -> c_int;")
+	w.Writeln("    fn GetLastError() -> u32;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn open(file_name: *const c_char) -> *mut c_void {")
+	w.Writeln("    LoadLibraryA(file_name)")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn symbol(handle: *mut c_void, name: *const c_char) -> *mut c_void {")
+	w.Writeln("    GetProcAddress(handle, name)")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn close(handle: *mut c_void) {")
+	w.Writeln("    FreeLibrary(handle);")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  pub unsafe fn last_error() -> String {")
+	w.Writeln("    format!(\"error code {}\", GetLastError())")
+	w.Writeln("  }")
+	w.Writeln("}")
+
+	return nil
+}
+
+func buildRustLib(component ComponentDefinition, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	NAMESPACE := strings.ToUpper(NameSpace)
+	global := component.Global
+	namespaces := component.importedNameSpaces()
+
+	w.Writeln("//! Safe bindings of %s", component.LibraryName)
+	w.Writeln("")
+	w.Writeln("pub mod sys;")
+	w.Writeln("")
+	if len(namespaces) > 0 {
+		w.Writeln("use std::cell::RefCell;")
+	}
+	w.Writeln("use std::fmt;")
+	w.Writeln("use std::os::raw::{c_char, c_void};")
+	w.Writeln("use std::rc::Rc;")
+	w.Writeln("")
+
+	w.Writeln("/// Error codes of %s", NameSpace)
+	w.Writeln("#[allow(non_camel_case_types)]")
+	w.Writeln("#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]")
+	w.Writeln("pub enum ErrorCode {")
+	for _, merror := range component.Errors.Errors {
+		writeRustDocComment(w, "  ", merror.Description)
+		w.Writeln("  %s,", merror.Name)
+	}
+	w.Writeln("  /// an error code that is not part of the interface definition")
+	w.Writeln("  Unknown(i32),")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl ErrorCode {")
+	w.Writeln("  /// Maps a result code of the library to an error code")
+	w.Writeln("  pub fn from_code(code: i32) -> ErrorCode {")
+	w.Writeln("    match code {")
+	for _, merror := range component.Errors.Errors {
+		w.Writeln("      sys::%s_ERROR_%s => ErrorCode::%s,", NAMESPACE, merror.Name, merror.Name)
+	}
+	w.Writeln("      _ => ErrorCode::Unknown(code),")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Returns the result code of the library")
+	w.Writeln("  pub fn code(self) -> i32 {")
+	w.Writeln("    match self {")
+	for _, merror := range component.Errors.Errors {
+		w.Writeln("      ErrorCode::%s => sys::%s_ERROR_%s,", merror.Name, NAMESPACE, merror.Name)
+	}
+	w.Writeln("      ErrorCode::Unknown(code) => code,")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Returns the description of the error code")
+	w.Writeln("  pub fn description(self) -> &'static str {")
+	w.Writeln("    match self {")
+	for _, merror := range component.Errors.Errors {
+		w.Writeln("      ErrorCode::%s => %q,", merror.Name, merror.Description)
+	}
+	w.Writeln("      ErrorCode::Unknown(_) => \"unknown error\",")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("/// An error that occurred in %s or in its bindings", NameSpace)
+	w.Writeln("#[derive(Debug, Clone, PartialEq, Eq)]")
+	w.Writeln("pub struct Error {")
+	w.Writeln("  code: ErrorCode,")
+	w.Writeln("  message: String,")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl Error {")
+	w.Writeln("  /// Creates an error from an error code and a message")
+	w.Writeln("  pub fn new(code: ErrorCode, message: &str) -> Error {")
+	w.Writeln("    Error {")
+	w.Writeln("      code,")
+	w.Writeln("      message: message.to_string(),")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Returns the error code")
+	w.Writeln("  pub fn code(&self) -> ErrorCode {")
+	w.Writeln("    self.code")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Returns the error message the library recorded, which may be empty")
+	w.Writeln("  pub fn message(&self) -> &str {")
+	w.Writeln("    &self.message")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl fmt::Display for Error {")
+	w.Writeln("  fn fmt(&self, f: &mut fmt::Formatter) -> fmt::Result {")
+	w.Writeln("    write!(f, \"%sException {} ({})\", self.code.code(), self.code.description())?;", NameSpace)
+	w.Writeln("    if !self.message.is_empty() {")
+	w.Writeln("      write!(f, \": {}\", self.message)?;")
+	w.Writeln("    }")
+	w.Writeln("    Ok(())")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl std::error::Error for Error {}")
+	w.Writeln("")
+	w.Writeln("/// Result of all calls into %s", NameSpace)
+	w.Writeln("pub type Result<T> = std::result::Result<T, Error>;")
+	w.Writeln("")
+
+	for _, enum := range component.Enums {
+		w.Writeln("#[repr(i32)]")
+		w.Writeln("#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]")
+		w.Writeln("pub enum %s {", enum.Name)
+		for _, option := range enum.Options {
+			w.Writeln("  %s = %d,", option.Name, option.Value)
+		}
+		w.Writeln("}")
+		w.Writeln("")
+		w.Writeln("impl %s {", enum.Name)
+		w.Writeln("  /// Converts a raw value into an option of %s", enum.Name)
+		w.Writeln("  pub fn from_i32(value: i32) -> Option<%s> {", enum.Name)
+		w.Writeln("    match value {")
+		for _, option := range enum.Options {
+			w.Writeln("      %d => Some(%s::%s),", option.Value, enum.Name, option.Name)
+		}
+		w.Writeln("      _ => None,")
+		w.Writeln("    }")
+		w.Writeln("  }")
+		w.Writeln("}")
+		w.Writeln("")
+	}
+
+	for _, structinfo := range component.Structs {
+		w.Writeln("pub type %s = sys::s%s%s;", structinfo.Name, NameSpace, structinfo.Name)
+		w.Writeln("")
+	}
+
+	for _, functiontype := range component.Functions {
+		writeRustDocComment(w, "", functiontype.FunctionDescription)
+		w.Writeln("pub type %s = sys::%s%s;", functiontype.FunctionName, NameSpace, functiontype.FunctionName)
+		w.Writeln("")
+	}
+
+	w.Writeln("fn string_from_buffer(buffer: &[u8]) -> String {")
+	w.Writeln("  let length = buffer.iter().position(|&c| c == 0).unwrap_or(buffer.len());")
+	w.Writeln("  String::from_utf8_lossy(&buffer[..length]).into_owned()")
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("struct WrapperData {")
+	w.Writeln("  table: sys::%sFunctionTable,", NameSpace)
+	for _, namespace := range namespaces {
+		w.Writeln("  %s_wrapper: RefCell<Option<::%s::Wrapper>>,", rustSnakeCase(namespace), rustCrateName(component.ImportedComponentDefinitions[namespace]))
+	}
+	w.Writeln("  // keeps the library loaded, dropped last")
+	w.Writeln("  _library: Option<sys::Library>,")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("/// A loaded instance of %s. Clones share the library, which stays loaded", component.LibraryName)
+	w.Writeln("/// as long as a clone or an instance of any of its classes is alive.")
+	w.Writeln("#[derive(Clone)]")
+	w.Writeln("pub struct Wrapper {")
+	w.Writeln("  data: Rc<WrapperData>,")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl Wrapper {")
+	w.Writeln("  /// Loads the library from a shared library file")
+	w.Writeln("  pub fn load_library(file_name: &str) -> Result<Wrapper> {")
+	w.Writeln("    let library = sys::Library::open(file_name)")
+	w.Writeln("      .map_err(|message| Error::new(ErrorCode::COULDNOTLOADLIBRARY, &message))?;")
+	w.Writeln("    let table = unsafe { sys::%sFunctionTable::load(|symbol| library.symbol(symbol)) }", NameSpace)
+	w.Writeln("      .map_err(|symbol| Error::new(ErrorCode::COULDNOTFINDLIBRARYEXPORT, &symbol))?;")
+	w.Writeln("    Wrapper::create(table, Some(library))")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Loads the library through the address of its symbol lookup method, e.g. after it has been")
+	w.Writeln("  /// injected into another component.")
+	w.Writeln("  ///")
+	w.Writeln("  /// # Safety")
+	w.Writeln("  /// The address must point to the symbol lookup method of a library that stays loaded as long")
+	w.Writeln("  /// as the wrapper is in use.")
+	w.Writeln("  pub unsafe fn from_symbol_lookup_method(symbol_lookup_method: *mut c_void) -> Result<Wrapper> {")
+	w.Writeln("    if symbol_lookup_method.is_null() {")
+	w.Writeln("      return Err(Error::new(ErrorCode::INVALIDPARAM, \"invalid symbol lookup method\"));")
+	w.Writeln("    }")
+	w.Writeln("    let lookup: sys::%sSymbolLookupMethod = std::mem::transmute(symbol_lookup_method);", NameSpace)
+	w.Writeln("    let table = sys::%sFunctionTable::load(|symbol| {", NameSpace)
+	w.Writeln("      let mut address: *mut c_void = std::ptr::null_mut();")
+	w.Writeln("      if lookup(symbol.as_ptr(), &mut address) != sys::%s_SUCCESS {", NAMESPACE)
+	w.Writeln("        return std::ptr::null_mut();")
+	w.Writeln("      }")
+	w.Writeln("      address")
+	w.Writeln("    })")
+	w.Writeln("    .map_err(|symbol| Error::new(ErrorCode::COULDNOTFINDLIBRARYEXPORT, &symbol))?;")
+	w.Writeln("    Wrapper::create(table, None)")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  fn create(table: sys::%sFunctionTable, library: Option<sys::Library>) -> Result<Wrapper> {", NameSpace)
+	w.Writeln("    let wrapper = Wrapper {")
+	w.Writeln("      data: Rc::new(WrapperData {")
+	w.Writeln("        table,")
+	for _, namespace := range namespaces {
+		w.Writeln("        %s_wrapper: RefCell::new(None),", rustSnakeCase(namespace))
+	}
+	w.Writeln("        _library: library,")
+	w.Writeln("      }),")
+	w.Writeln("    };")
+	w.Writeln("    wrapper.check_binary_version()?;")
+	w.Writeln("    Ok(wrapper)")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  fn check_binary_version(&self) -> Result<()> {")
+	w.Writeln("    let (major, minor, _) = self.%s()?;", rustIdentifier(global.VersionMethod))
+	w.Writeln("    if major != sys::%s_VERSION_MAJOR || minor < sys::%s_VERSION_MINOR {", NAMESPACE, NAMESPACE)
+	w.Writeln("      return Err(Error::new(ErrorCode::INCOMPATIBLEBINARYVERSION, \"\"));")
+	w.Writeln("    }")
+	w.Writeln("    Ok(())")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Returns the raw functions of the library")
+	w.Writeln("  pub fn table(&self) -> &sys::%sFunctionTable {", NameSpace)
+	w.Writeln("    &self.data.table")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  /// Turns a result code of the library into an error that carries the last error message of an instance")
+	w.Writeln("  pub fn check_error(&self, instance: sys::%sHandle, result: sys::%sResult) -> Result<()> {", NameSpace, NameSpace)
+	w.Writeln("    if result == sys::%s_SUCCESS {", NAMESPACE)
+	w.Writeln("      return Ok(());")
+	w.Writeln("    }")
+	w.Writeln("    let mut message = String::new();")
+	w.Writeln("    if !instance.is_null() {")
+	errorExport := GetCExportName(NameSpace, "", ComponentDefinitionMethod{MethodName: global.ErrorMethod}, true)
+	w.Writeln("      let mut needed_chars: u32 = 0;")
+	w.Writeln("      let mut has_error = false;")
+	w.Writeln("      let error_result = unsafe { (self.data.table.%s)(instance, 0, &mut needed_chars, std::ptr::null_mut(), &mut has_error) };", errorExport)
+	w.Writeln("      if error_result == sys::%s_SUCCESS && has_error && needed_chars > 0 {", NAMESPACE)
+	w.Writeln("        let mut buffer: Vec<u8> = vec![0; needed_chars as usize];")
+	w.Writeln("        let error_result = unsafe { (self.data.table.%s)(instance, needed_chars, &mut needed_chars, buffer.as_mut_ptr() as *mut c_char, &mut has_error) };", errorExport)
+	w.Writeln("        if error_result == sys::%s_SUCCESS {", NAMESPACE)
+	w.Writeln("          message = string_from_buffer(&buffer);")
+	w.Writeln("        }")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("    Err(Error::new(ErrorCode::from_code(result), &message))")
+	w.Writeln("  }")
+
+	for _, namespace := range namespaces {
+		subCrate := rustCrateName(component.ImportedComponentDefinitions[namespace])
+		w.Writeln("")
+		w.Writeln("  fn %s_wrapper(&self) -> Result<::%s::Wrapper> {", rustSnakeCase(namespace), subCrate)
+		w.Writeln("    match *self.data.%s_wrapper.borrow() {", rustSnakeCase(namespace))
+		w.Writeln("      Some(ref wrapper) => Ok(wrapper.clone()),")
+		w.Writeln("      None => Err(Error::new(ErrorCode::COULDNOTLOADLIBRARY, \"%s has not been injected\")),", namespace)
+		w.Writeln("    }")
+		w.Writeln("  }")
+	}
+
+	for _, method := range global.Methods {
+		isSpecialFunction, err := CheckHeaderSpecialFunction(method, global)
+		if err != nil {
+			return err
+		}
+		if isSpecialFunction == eSpecialMethodRelease || isSpecialFunction == eSpecialMethodAcquire {
+			// Ownership of instances is handled by Drop and Clone
+			continue
+		}
+
+		postCallLines := []string{}
+		if isSpecialFunction == eSpecialMethodInjection {
+			nameSpaceParam := rustIdentifier(method.Params[0].ParamName)
+			addressParam := rustIdentifier(method.Params[1].ParamName)
+			postCallLines = append(postCallLines, fmt.Sprintf("match %s {", nameSpaceParam))
+			for _, namespace := range namespaces {
+				subCrate := rustCrateName(component.ImportedComponentDefinitions[namespace])
+				subWrapper := rustSnakeCase(namespace) + "_wrapper"
+				postCallLines = append(postCallLines, fmt.Sprintf("  \"%s\" => {", namespace))
+				postCallLines = append(postCallLines, fmt.Sprintf("    if wrapper.data.%s.borrow().is_some() {", subWrapper))
+				postCallLines = append(postCallLines, fmt.Sprintf("      return Err(Error::new(ErrorCode::COULDNOTLOADLIBRARY, \"%s has already been injected\"));", namespace))
+				postCallLines = append(postCallLines, "    }")
+				postCallLines = append(postCallLines, fmt.Sprintf("    let %s = unsafe { ::%s::Wrapper::from_symbol_lookup_method(%s) }", subWrapper, subCrate, addressParam))
+				postCallLines = append(postCallLines, "      .map_err(|error| Error::new(ErrorCode::from_code(error.code().code()), error.message()))?;")
+				postCallLines = append(postCallLines, fmt.Sprintf("    *wrapper.data.%s.borrow_mut() = Some(%s);", subWrapper, subWrapper))
+				postCallLines = append(postCallLines, "  }")
+			}
+			postCallLines = append(postCallLines, fmt.Sprintf("  _ => return Err(Error::new(ErrorCode::COULDNOTLOADLIBRARY, &format!(\"unknown namespace {}\", %s))),", nameSpaceParam))
+			postCallLines = append(postCallLines, "}")
+		}
+
+		w.Writeln("")
+		err = writeRustMethod(component, method, w, "", true, postCallLines)
+		if err != nil {
+			return err
+		}
+	}
+	w.Writeln("}")
+	w.Writeln("")
+
+	w.Writeln("/// Access to the raw handle of an instance of a class of %s", NameSpace)
+	w.Writeln("pub trait ClassHandle {")
+	w.Writeln("  /// Returns the raw handle of the instance")
+	w.Writeln("  fn handle(&self) -> sys::%sHandle;", NameSpace)
+	w.Writeln("")
+	w.Writeln("  /// Returns the wrapper of the library the instance belongs to")
+	w.Writeln("  fn wrapper(&self) -> &Wrapper;")
+	w.Writeln("}")
+
+	for _, class := range component.Classes {
+		w.Writeln("")
+		err := writeRustClass(component, class, w)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rustAncestorClassNames returns the names of all classes a class inherits from, starting with its parent
+func rustAncestorClassNames(component ComponentDefinition, class ComponentDefinitionClass) []string {
+	ancestors := []string{}
+	parentName := component.parentClassName(class)
+	for parentName != "" && len(ancestors) < len(component.Classes) {
+		ancestors = append(ancestors, parentName)
+		found := false
+		for _, parent := range component.Classes {
+			if parent.ClassName == parentName {
+				parentName = component.parentClassName(parent)
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return ancestors
+}
+
+func writeRustClass(component ComponentDefinition, class ComponentDefinitionClass, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	ClassName := class.ClassName
+	global := component.Global
+
+	supertrait := "ClassHandle"
+	parentName := component.parentClassName(class)
+	if parentName != "" {
+		supertrait = parentName + "Methods"
+	}
+
+	// Static methods are associated functions of the struct, all other methods are part of the trait
+	instanceMethods := class.instanceMethods()
+
+	w.Writeln("/// Methods of %s, implemented by %s and all classes that inherit from it", ClassName, ClassName)
+	if len(instanceMethods) == 0 {
+		w.Writeln("pub trait %sMethods: %s {}", ClassName, supertrait)
+	} else {
+		w.Writeln("pub trait %sMethods: %s {", ClassName, supertrait)
+	}
+	for i, method := range instanceMethods {
+		if i > 0 {
+			w.Writeln("")
+		}
+		err := writeRustMethod(component, method, w, ClassName, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if len(instanceMethods) > 0 {
+		w.Writeln("}")
+	}
+	w.Writeln("")
+
+	writeRustDocComment(w, "", class.ClassDescription)
+	w.Writeln("pub struct %s {", ClassName)
+	w.Writeln("  handle: sys::%sHandle,", NameSpace)
+	w.Writeln("  wrapper: Wrapper,")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl %s {", ClassName)
+	w.Writeln("  /// Takes ownership of a handle that has been returned by the library")
+	w.Writeln("  ///")
+	w.Writeln("  /// # Safety")
+	w.Writeln("  /// The handle must be an owned, valid handle of a %s created by the library of the wrapper.", ClassName)
+	w.Writeln("  pub unsafe fn from_handle(handle: sys::%sHandle, wrapper: Wrapper) -> %s {", NameSpace, ClassName)
+	w.Writeln("    %s { handle, wrapper }", ClassName)
+	w.Writeln("  }")
+	for _, method := range class.Methods {
+		if !method.IsStatic {
+			continue
+		}
+		w.Writeln("")
+		err := writeRustMethod(component, method, w, ClassName, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("impl ClassHandle for %s {", ClassName)
+	w.Writeln("  fn handle(&self) -> sys::%sHandle {", NameSpace)
+	w.Writeln("    self.handle")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  fn wrapper(&self) -> &Wrapper {")
+	w.Writeln("    &self.wrapper")
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	ancestors := rustAncestorClassNames(component, class)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		w.Writeln("impl %sMethods for %s {}", ancestors[i], ClassName)
+	}
+	w.Writeln("impl %sMethods for %s {}", ClassName, ClassName)
+	w.Writeln("")
+
+	releaseExport := GetCExportName(NameSpace, "", ComponentDefinitionMethod{MethodName: global.ReleaseMethod}, true)
+	w.Writeln("impl Drop for %s {", ClassName)
+	w.Writeln("  fn drop(&mut self) {")
+	w.Writeln("    unsafe { (self.wrapper.data.table.%s)(self.handle) };", releaseExport)
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+
+	acquireExport := GetCExportName(NameSpace, "", ComponentDefinitionMethod{MethodName: global.AcquireMethod}, true)
+	w.Writeln("impl Clone for %s {", ClassName)
+	w.Writeln("  /// Shares ownership of the instance")
+	w.Writeln("  fn clone(&self) -> %s {", ClassName)
+	w.Writeln("    unsafe { (self.wrapper.data.table.%s)(self.handle) };", acquireExport)
+	w.Writeln("    %s {", ClassName)
+	w.Writeln("      handle: self.handle,")
+	w.Writeln("      wrapper: self.wrapper.clone(),")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("}")
+
+	for _, ancestor := range ancestors {
+		w.Writeln("")
+		w.Writeln("impl From<%s> for %s {", ClassName, ancestor)
+		w.Writeln("  fn from(instance: %s) -> %s {", ClassName, ancestor)
+		w.Writeln("    let instance = std::mem::ManuallyDrop::new(instance);")
+		w.Writeln("    unsafe { %s::from_handle(instance.handle, std::ptr::read(&instance.wrapper)) }", ancestor)
+		w.Writeln("  }")
+		w.Writeln("}")
+	}
+
+	return nil
+}
+
+// writeRustMethod writes a safe method of the Rust bindings. Global methods become methods of the
+// wrapper, class methods become provided methods of the class' trait.
+func writeRustMethod(component ComponentDefinition, method ComponentDefinitionMethod, w LanguageWriter, ClassName string, isGlobal bool, postCallLines []string) error {
+	NameSpace := component.NameSpace
+	code := rustMethodCode{}
+
+	for _, param := range method.Params {
+		name := rustIdentifier(param.ParamName)
+
+		typeName := ""
+		cratePrefix := ""
+		if param.ParamType == "basicarray" {
+			scalarType, err := getRustScalarType(param.ParamClass)
+			if err != nil {
+				return err
+			}
+			typeName = scalarType
+		} else if isScalarType(param.ParamType) {
+			scalarType, err := getRustScalarType(param.ParamType)
+			if err != nil {
+				return err
+			}
+			typeName = scalarType
+		} else if param.ParamType != "string" {
+			prefix, _, className, err := rustResolveClass(component, param.ParamClass)
+			if err != nil {
+				return err
+			}
+			cratePrefix = prefix
+			typeName = prefix + className
+		}
+
+		switch param.ParamPass {
+		case "in":
+			switch param.ParamType {
+			case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool", "pointer", "functiontype":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: %s", name, typeName))
+				code.CallArgs = append(code.CallArgs, name)
+
+			case "enum":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: %s", name, typeName))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s as i32", name))
+
+			case "string":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: &str", name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let %s_cstring = std::ffi::CString::new(%s)", name, name))
+				code.PreCall = append(code.PreCall, fmt.Sprintf("  .map_err(|_| Error::new(ErrorCode::INVALIDPARAM, \"%s contains a null character\"))?;", name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s_cstring.as_ptr()", name))
+
+			case "struct":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: &%s", name, typeName))
+				code.CallArgs = append(code.CallArgs, name)
+
+			case "basicarray", "structarray":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: &[%s]", name, typeName))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s.len() as u64", name), fmt.Sprintf("%s.as_ptr()", name))
+
+			case "class":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: &dyn %sMethods", name, typeName))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%sClassHandle::handle(%s)", cratePrefix, name))
+
+			case "optionalclass":
+				code.Signature = append(code.Signature, fmt.Sprintf("%s: Option<&dyn %sMethods>", name, typeName))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s.map_or(std::ptr::null_mut(), |instance| %sClassHandle::handle(instance))", name, cratePrefix))
+
+			default:
+				return fmt.Errorf("invalid parameter of type \"%s\" used as pass=\"%s\"", param.ParamType, param.ParamPass)
+			}
+			code.CheckArgs = code.CallArgs
+
+		case "out", "return":
+			switch param.ParamType {
+			case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double", "bool", "pointer":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let mut %s: %s = %s;", name, typeName, getRustScalarDefault(param.ParamType)))
+				code.CheckArgs = append(code.CheckArgs, fmt.Sprintf("&mut %s", name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("&mut %s", name))
+				code.ReturnTypes = append(code.ReturnTypes, typeName)
+				code.ReturnValues = append(code.ReturnValues, name)
+
+			case "enum":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let mut %s_value: i32 = 0;", name))
+				code.CheckArgs = append(code.CheckArgs, fmt.Sprintf("&mut %s_value", name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("&mut %s_value", name))
+				code.PostCall = append(code.PostCall, fmt.Sprintf("let %s = %s::from_i32(%s_value)", name, typeName, name))
+				code.PostCall = append(code.PostCall, "  .ok_or_else(|| Error::new(ErrorCode::INVALIDCAST, \"invalid enum value\"))?;")
+				code.ReturnTypes = append(code.ReturnTypes, typeName)
+				code.ReturnValues = append(code.ReturnValues, name)
+
+			case "struct":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let mut %s: %s = unsafe { std::mem::zeroed() };", name, typeName))
+				code.CheckArgs = append(code.CheckArgs, fmt.Sprintf("&mut %s", name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("&mut %s", name))
+				code.ReturnTypes = append(code.ReturnTypes, typeName)
+				code.ReturnValues = append(code.ReturnValues, name)
+
+			case "string":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let mut %s_needed: u32 = 0;", name))
+				code.CheckArgs = append(code.CheckArgs, "0", fmt.Sprintf("&mut %s_needed", name), "std::ptr::null_mut()")
+				code.CheckCall = append(code.CheckCall, fmt.Sprintf("let mut %s_buffer: Vec<u8> = vec![0; %s_needed as usize];", name, name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s_needed", name), fmt.Sprintf("&mut %s_needed", name), fmt.Sprintf("%s_buffer.as_mut_ptr() as *mut c_char", name))
+				code.PostCall = append(code.PostCall, fmt.Sprintf("let %s = string_from_buffer(&%s_buffer);", name, name))
+				code.ReturnTypes = append(code.ReturnTypes, "String")
+				code.ReturnValues = append(code.ReturnValues, name)
+				code.DoCheckCall = true
+
+			case "basicarray", "structarray":
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let mut %s_needed: u64 = 0;", name))
+				code.CheckArgs = append(code.CheckArgs, "0", fmt.Sprintf("&mut %s_needed", name), "std::ptr::null_mut()")
+				code.CheckCall = append(code.CheckCall, fmt.Sprintf("let mut %s: Vec<%s> = Vec::with_capacity(%s_needed as usize);", name, typeName, name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("%s_needed", name), fmt.Sprintf("&mut %s_needed", name), fmt.Sprintf("%s.as_mut_ptr()", name))
+				code.PostCall = append(code.PostCall, fmt.Sprintf("unsafe { %s.set_len(std::cmp::min(%s_needed as usize, %s.capacity())) };", name, name, name))
+				code.ReturnTypes = append(code.ReturnTypes, fmt.Sprintf("Vec<%s>", typeName))
+				code.ReturnValues = append(code.ReturnValues, name)
+				code.DoCheckCall = true
+
+			case "class", "optionalclass":
+				wrapperReference := "wrapper.clone()"
+				if len(cratePrefix) > 0 {
+					subNameSpace, _, _ := decomposeParamClassName(param.ParamClass)
+					code.PreCall = append(code.PreCall, fmt.Sprintf("let %s_wrapper = wrapper.%s_wrapper()?;", name, rustSnakeCase(subNameSpace)))
+					wrapperReference = name + "_wrapper"
+				}
+				code.PreCall = append(code.PreCall, fmt.Sprintf("let mut %s_handle: sys::%sHandle = std::ptr::null_mut();", name, NameSpace))
+				code.CheckArgs = append(code.CheckArgs, fmt.Sprintf("&mut %s_handle", name))
+				code.CallArgs = append(code.CallArgs, fmt.Sprintf("&mut %s_handle", name))
+				if param.ParamType == "optionalclass" {
+					code.PostCall = append(code.PostCall, fmt.Sprintf("let %s = if %s_handle.is_null() {", name, name))
+					code.PostCall = append(code.PostCall, "  None")
+					code.PostCall = append(code.PostCall, "} else {")
+					code.PostCall = append(code.PostCall, fmt.Sprintf("  Some(unsafe { %s::from_handle(%s_handle, %s) })", typeName, name, wrapperReference))
+					code.PostCall = append(code.PostCall, "};")
+					code.ReturnTypes = append(code.ReturnTypes, fmt.Sprintf("Option<%s>", typeName))
+				} else {
+					code.PostCall = append(code.PostCall, fmt.Sprintf("if %s_handle.is_null() {", name))
+					code.PostCall = append(code.PostCall, "  return Err(Error::new(ErrorCode::INVALIDCAST, \"invalid return/output value\"));")
+					code.PostCall = append(code.PostCall, "}")
+					code.PostCall = append(code.PostCall, fmt.Sprintf("let %s = unsafe { %s::from_handle(%s_handle, %s) };", name, typeName, name, wrapperReference))
+					code.ReturnTypes = append(code.ReturnTypes, typeName)
+				}
+				code.ReturnValues = append(code.ReturnValues, name)
+
+			default:
+				return fmt.Errorf("invalid parameter of type \"%s\" used as pass=\"%s\"", param.ParamType, param.ParamPass)
+			}
+
+		default:
+			return fmt.Errorf("invalid method parameter passing \"%s\" for %s.%s (%s)", param.ParamPass, ClassName, method.MethodName, param.ParamName)
+		}
+	}
+
+	returnType := "()"
+	returnValue := "()"
+	if len(code.ReturnTypes) == 1 {
+		returnType = code.ReturnTypes[0]
+		returnValue = code.ReturnValues[0]
+	} else if len(code.ReturnTypes) > 1 {
+		returnType = "(" + strings.Join(code.ReturnTypes, ", ") + ")"
+		returnValue = "(" + strings.Join(code.ReturnValues, ", ") + ")"
+	}
+
+	signature := append([]string{"&self"}, code.Signature...)
+	visibility := ""
+	handleReference := "handle"
+	if isGlobal {
+		visibility = "pub "
+		handleReference = "std::ptr::null_mut()"
+	} else if method.IsStatic {
+		signature = append([]string{"wrapper: &Wrapper"}, code.Signature...)
+		visibility = "pub "
+		handleReference = "std::ptr::null_mut()"
+	} else {
+		code.CheckArgs = append([]string{"handle"}, code.CheckArgs...)
+		code.CallArgs = append([]string{"handle"}, code.CallArgs...)
+	}
+	exportName := GetCExportName(NameSpace, ClassName, method, isGlobal)
+
+	writeRustDocComment(w, "  ", method.MethodDescription)
+	w.Writeln("  %sfn %s(%s) -> Result<%s> {", visibility, rustIdentifier(method.MethodName), strings.Join(signature, ", "), returnType)
+	if isGlobal {
+		w.Writeln("    let wrapper = self;")
+	} else if !method.IsStatic {
+		w.Writeln("    let wrapper = self.wrapper();")
+		w.Writeln("    let handle = self.handle();")
+	}
+	w.Writelns("    ", code.PreCall)
+	if code.DoCheckCall {
+		w.Writeln("    let result = unsafe { (wrapper.table().%s)(%s) };", exportName, strings.Join(code.CheckArgs, ", "))
+		w.Writeln("    wrapper.check_error(%s, result)?;", handleReference)
+		w.Writelns("    ", code.CheckCall)
+	}
+	w.Writeln("    let result = unsafe { (wrapper.table().%s)(%s) };", exportName, strings.Join(code.CallArgs, ", "))
+	w.Writeln("    wrapper.check_error(%s, result)?;", handleReference)
+	w.Writelns("    ", code.PostCall)
+	w.Writelns("    ", postCallLines)
+	w.Writeln("    Ok(%s)", returnValue)
+	w.Writeln("  }")
+
+	return nil
+}
+
+func buildRustExampleManifest(component ComponentDefinition, w LanguageWriter) {
+	w.Writeln("[package]")
+	w.Writeln("name = \"%s_example\"", rustCrateName(component))
+	w.Writeln("version = \"%s\"", rustCrateVersion(component))
+	w.Writeln("edition = \"2018\"")
+	w.Writeln("")
+	w.Writeln("[dependencies]")
+	w.Writeln("%s = { path = \"../../Bindings/Rust\" }", rustCrateName(component))
+}
+
+func buildRustExample(component ComponentDefinition, w LanguageWriter) {
+	crateName := rustCrateName(component)
+	global := component.Global
+
+	w.Writeln("fn main() -> %s::Result<()> {", crateName)
+	w.Writeln("  // TODO: pass the location of the shared library binary as argument or add it here")
+	w.Writeln("  let library_name = std::env::args()")
+	w.Writeln("    .nth(1)")
+	w.Writeln("    .unwrap_or_else(|| format!(\"%s{}\", std::env::consts::DLL_SUFFIX));", component.BaseName)
+	w.Writeln("  let wrapper = %s::Wrapper::load_library(&library_name)?;", crateName)
+	w.Writeln("")
+	w.Writeln("  let (major, minor, micro) = wrapper.%s()?;", rustIdentifier(global.VersionMethod))
+	mutable := ""
+	if len(global.PrereleaseMethod) > 0 || len(global.BuildinfoMethod) > 0 {
+		mutable = "mut "
+	}
+	w.Writeln("  let %sversion = format!(\"{}.{}.{}\", major, minor, micro);", mutable)
+	if len(global.PrereleaseMethod) > 0 {
+		w.Writeln("  let (has_info, prerelease_info) = wrapper.%s()?;", rustIdentifier(global.PrereleaseMethod))
+		w.Writeln("  if has_info {")
+		w.Writeln("    version = version + \"-\" + &prerelease_info;")
+		w.Writeln("  }")
+	}
+	if len(global.BuildinfoMethod) > 0 {
+		w.Writeln("  let (has_info, build_info) = wrapper.%s()?;", rustIdentifier(global.BuildinfoMethod))
+		w.Writeln("  if has_info {")
+		w.Writeln("    version = version + \"+\" + &build_info;")
+		w.Writeln("  }")
+	}
+	w.Writeln("  println!(\"%s version: {}\", version);", component.NameSpace)
+	w.Writeln("")
+	w.Writeln("  Ok(())")
+	w.Writeln("}")
+}
@@ -0,0 +1,800 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// builddocumentation.go
+// functions to generate a browsable API reference of a component in Markdown and HTML
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// documentationSpan is a piece of text in the API reference. It is printed as code if Code is set
+// and links to the section with the anchor Link if Link is not empty.
+type documentationSpan struct {
+	Text string
+	Code bool
+	Link string
+}
+
+// documentationListItem is an entry of a nested list in the API reference
+type documentationListItem struct {
+	Depth int
+	Spans []documentationSpan
+}
+
+// documentationSignature is the declaration of a method in one binding language
+type documentationSignature struct {
+	Language   string
+	CodeFormat string
+	Lines      []string
+}
+
+// documentationWriter writes the elements of the API reference in one output format
+type documentationWriter interface {
+	Begin(title string)
+	End()
+	Heading(level int, anchor string, text string)
+	Paragraph(spans ...documentationSpan)
+	List(items []documentationListItem)
+	Table(header []string, rows [][]documentationSpan)
+	Code(language string, codeFormat string, lines []string)
+}
+
+func docText(text string) documentationSpan {
+	return documentationSpan{Text: text}
+}
+
+func docCode(text string) documentationSpan {
+	return documentationSpan{Text: text, Code: true}
+}
+
+func docLink(text string, link string) documentationSpan {
+	return documentationSpan{Text: text, Link: link}
+}
+
+func docCodeLink(text string, link string) documentationSpan {
+	return documentationSpan{Text: text, Code: true, Link: link}
+}
+
+// BuildDocumentation writes the API reference of a component as Markdown and as HTML file
+func BuildDocumentation(component ComponentDefinition, outputFolder string) error {
+	MarkdownName := path.Join(outputFolder, component.BaseName+".md")
+	log.Printf("Creating \"%s\"", MarkdownName)
+	markdownfile, err := CreateOutputFile(MarkdownName)
+	if err != nil {
+		return err
+	}
+	err = buildDocumentation(component, &markdownDocumentationWriter{w: markdownfile})
+	if err != nil {
+		return err
+	}
+
+	HTMLName := path.Join(outputFolder, component.BaseName+".html")
+	log.Printf("Creating \"%s\"", HTMLName)
+	htmlfile, err := CreateOutputFile(HTMLName)
+	if err != nil {
+		return err
+	}
+	return buildDocumentation(component, &htmlDocumentationWriter{w: htmlfile})
+}
+
+func getDocumentationAnchor(kind string, name string) string {
+	return strings.ToLower(kind + "-" + strings.Replace(name, ":", "-", -1))
+}
+
+func getDocumentationMethodAnchor(className string, method ComponentDefinitionMethod, isGlobal bool) string {
+	if isGlobal {
+		return getDocumentationAnchor("function", method.MethodName)
+	}
+	return getDocumentationAnchor("method", className+"-"+method.MethodName)
+}
+
+// getDocumentationTypeLink returns the anchor of the class, enum, struct or function type that a type refers to,
+// or "" if it is a basic type or defined in an imported component
+func getDocumentationTypeLink(paramType string, paramClass string) string {
+	if strings.Contains(paramClass, ":") {
+		return ""
+	}
+	switch paramType {
+	case "class", "optionalclass":
+		return getDocumentationAnchor("class", paramClass)
+	case "enum":
+		return getDocumentationAnchor("enum", paramClass)
+	case "struct", "structarray":
+		return getDocumentationAnchor("struct", paramClass)
+	case "functiontype":
+		return getDocumentationAnchor("functiontype", paramClass)
+	}
+	return ""
+}
+
+func getDocumentationParamType(param ComponentDefinitionParam) documentationSpan {
+	switch param.ParamType {
+	case "class", "optionalclass", "enum", "struct", "functiontype":
+		return docCodeLink(param.ParamType+" "+param.ParamClass, getDocumentationTypeLink(param.ParamType, param.ParamClass))
+	case "basicarray", "structarray":
+		return docCodeLink(param.ParamType+" of "+param.ParamClass, getDocumentationTypeLink(param.ParamType, param.ParamClass))
+	}
+	return docCode(param.ParamType)
+}
+
+func getDocumentationMemberType(member ComponentDefinitionMember) documentationSpan {
+	typeName := member.Type
+	if member.Class != "" {
+		typeName = typeName + " " + member.Class
+	}
+	if member.Rows > 0 {
+		if member.Columns > 0 {
+			typeName = typeName + fmt.Sprintf("[%d][%d]", member.Rows, member.Columns)
+		} else {
+			typeName = typeName + fmt.Sprintf("[%d]", member.Rows)
+		}
+	}
+	return docCodeLink(typeName, getDocumentationTypeLink(member.Type, member.Class))
+}
+
+// getDocumentationDeclaration returns the declaration that one of the binding writers writes for a method.
+// The writer is run on a buffer, and the declaration is the last line of its output that starts with prefix.
+func getDocumentationDeclaration(prefix string, write func(w LanguageWriter) error) (string, error) {
+	var buffer bytes.Buffer
+	w := LanguageWriter{Writer: &buffer}
+	err := write(w)
+	if err != nil {
+		return "", err
+	}
+	declaration := ""
+	for _, line := range strings.Split(buffer.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if (line != "") && strings.HasPrefix(line, prefix) && !strings.HasPrefix(line, "*") && !strings.HasPrefix(line, "/") {
+			declaration = line
+		}
+	}
+	return strings.TrimSuffix(declaration, " {"), nil
+}
+
+// getDocumentationSignatures returns the declarations of a method in all bindings of a component
+func getDocumentationSignatures(component ComponentDefinition, method ComponentDefinitionMethod, className string, isGlobal bool) ([]documentationSignature, error) {
+	NameSpace := component.NameSpace
+	bindingClassName := className
+	if isGlobal {
+		bindingClassName = "Wrapper"
+	}
+
+	signatures := []documentationSignature{}
+	languages := make(map[string]bool, 0)
+	for _, binding := range component.BindingList.Bindings {
+		signature := documentationSignature{}
+		declaration := ""
+		var err error
+
+		switch binding.Language {
+		case "C", "CDynamic":
+			signature = documentationSignature{Language: "C", CodeFormat: "c"}
+			declaration, err = getDocumentationDeclaration(strings.ToUpper(NameSpace)+"_DECLSPEC", func(w LanguageWriter) error {
+				return WriteCCPPAbiMethod(method, w, NameSpace, bindingClassName, isGlobal, false, false)
+			})
+
+		case "Cpp", "CppDynamic":
+			signature = documentationSignature{Language: "C++", CodeFormat: "cpp"}
+			// The declaration of a deprecated method starts with its attribute, which stays part of the signature
+			attribute := getCppDeprecatedAttribute(method.ComponentDefinitionDeprecation, bindingClassName+"."+method.MethodName)
+			prefix := "inline "
+			if method.IsStatic {
+				prefix = "static inline "
+			}
+			declaration, err = getDocumentationDeclaration(attribute+prefix, func(w LanguageWriter) error {
+				return writeDynamicCPPMethodDeclaration(method, w, NameSpace, binding.ClassIdentifier, bindingClassName)
+			})
+			if declaration != "" {
+				declaration = attribute + strings.Replace(strings.TrimPrefix(declaration, attribute), "inline ", "", 1)
+			}
+
+		case "Pascal":
+			signature = documentationSignature{Language: "Pascal", CodeFormat: "pascal"}
+			declaration, err = getDocumentationDeclaration("", func(w LanguageWriter) error {
+				return writePascalClassMethodDefinition(method, w, NameSpace, bindingClassName, isGlobal, false)
+			})
+
+		case "CSharp":
+			signature = documentationSignature{Language: "C#", CodeFormat: "csharp"}
+			parameters, returnType, csharpErr := getCSharpClassParameters(method, NameSpace, className, isGlobal)
+			err = csharpErr
+			if isGlobal || method.IsStatic {
+				declaration = fmt.Sprintf("public static %s %s (%s)", returnType, method.MethodName, parameters)
+			} else {
+				declaration = fmt.Sprintf("public %s %s (%s)", returnType, method.MethodName, parameters)
+			}
+
+		case "Python":
+			signature = documentationSignature{Language: "Python", CodeFormat: "python"}
+			declaration, err = getDocumentationDeclaration("def ", func(w LanguageWriter) error {
+				return writePythonStubMethod(method, bindingClassName, w)
+			})
+			declaration = strings.TrimSuffix(declaration, " ...")
+
+		case "Go":
+			signature = documentationSignature{Language: "Go", CodeFormat: "go"}
+			declaration, err = getDocumentationDeclaration("func ", func(w LanguageWriter) error {
+				classdefinitions := []string{}
+				var discard bytes.Buffer
+				err := writeGoMethod(method, LanguageWriter{Writer: &discard}, LanguageWriter{Writer: &discard}, NameSpace, bindingClassName, isGlobal, &classdefinitions)
+				if err != nil {
+					return err
+				}
+				for _, line := range classdefinitions {
+					if strings.HasPrefix(line, "func ") {
+						w.Writeln("%s", line)
+					}
+				}
+				return nil
+			})
+
+		case "Node":
+			signature = documentationSignature{Language: "TypeScript", CodeFormat: "typescript"}
+			nodeMethod := method
+			if method.IsStatic {
+				nodeMethod = getNodeWrapperMethod(className, method)
+			}
+			declaration, err = getDocumentationDeclaration(nodeMethod.MethodName+"(", func(w LanguageWriter) error {
+				return writeNodeTypeScriptMethod(component, w, nodeMethod)
+			})
+
+		case "Rust":
+			signature = documentationSignature{Language: "Rust", CodeFormat: "rust"}
+			rustClassName := className
+			if isGlobal {
+				rustClassName = ""
+			}
+			declaration, err = getDocumentationDeclaration("", func(w LanguageWriter) error {
+				var buffer bytes.Buffer
+				err := writeRustMethod(component, method, LanguageWriter{Writer: &buffer}, rustClassName, isGlobal, nil)
+				if err != nil {
+					return err
+				}
+				for _, line := range strings.Split(buffer.String(), "\n") {
+					line = strings.TrimSpace(line)
+					if strings.HasPrefix(line, "fn ") || strings.HasPrefix(line, "pub fn ") {
+						w.Writeln("%s", line)
+						break
+					}
+				}
+				return nil
+			})
+
+		case "Java":
+			signature = documentationSignature{Language: "Java", CodeFormat: "java"}
+			javaClassName := className
+			if isGlobal {
+				javaClassName = ""
+			}
+			code, javaErr := generateJavaMethodCode(component, method, javaClassName, isGlobal)
+			err = javaErr
+			declaration = fmt.Sprintf("public %s %s(%s)", getJavaReturnType(javaClassName, method, code), getJavaMethodName(javaClassName, method), strings.Join(code.Signature, ", "))
+
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		if languages[signature.Language] || (declaration == "") {
+			continue
+		}
+		languages[signature.Language] = true
+		signature.Lines = []string{declaration}
+		if (signature.Language == "Python") && method.IsStatic {
+			signature.Lines = []string{"@staticmethod", declaration}
+		}
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+// getDocumentationDescription appends the deprecation notice of an element to its description
+func getDocumentationDescription(description string, deprecation ComponentDefinitionDeprecation) string {
+	if !deprecation.IsDeprecated() {
+		return description
+	}
+	if description == "" {
+		return deprecation.DeprecationNotice() + "."
+	}
+	return description + " (" + deprecation.DeprecationNotice() + ")"
+}
+
+func writeDocumentationParams(w documentationWriter, params []ComponentDefinitionParam) {
+	if len(params) == 0 {
+		return
+	}
+	hasDefaults := false
+	for _, param := range params {
+		hasDefaults = hasDefaults || param.HasDefault()
+	}
+	rows := [][]documentationSpan{}
+	for _, param := range params {
+		row := []documentationSpan{docCode(param.ParamName), getDocumentationParamType(param), docText(param.ParamPass)}
+		if hasDefaults {
+			defaultSpan := docText("")
+			if param.ParamType == "string" && param.HasDefault() {
+				defaultSpan = docCode(strconv.Quote(param.DefaultValue()))
+			} else if param.HasDefault() {
+				defaultSpan = docCode(param.DefaultValue())
+			}
+			row = append(row, defaultSpan)
+		}
+		rows = append(rows, append(row, docText(getDocumentationDescription(param.ParamDescription, param.ComponentDefinitionDeprecation))))
+	}
+	if hasDefaults {
+		w.Table([]string{"Parameter", "Type", "Pass", "Default", "Description"}, rows)
+		return
+	}
+	w.Table([]string{"Parameter", "Type", "Pass", "Description"}, rows)
+}
+
+func writeDocumentationMethod(component ComponentDefinition, w documentationWriter, method ComponentDefinitionMethod, className string, isGlobal bool) error {
+	title := className + "::" + method.MethodName
+	if isGlobal {
+		title = method.MethodName
+	}
+	w.Heading(4, getDocumentationMethodAnchor(className, method, isGlobal), title)
+	if method.MethodDescription != "" {
+		w.Paragraph(docText(method.MethodDescription))
+	}
+	if method.IsDeprecated() {
+		w.Paragraph(docText(method.DeprecationNotice() + "."))
+	}
+
+	signatures, err := getDocumentationSignatures(component, method, className, isGlobal)
+	if err != nil {
+		return err
+	}
+	for _, signature := range signatures {
+		w.Code(signature.Language, signature.CodeFormat, signature.Lines)
+	}
+
+	writeDocumentationParams(w, method.Params)
+	return nil
+}
+
+// getDocumentationClassHierarchy returns the classes of a component as a nested list, with every class below its parent
+func getDocumentationClassHierarchy(component ComponentDefinition) []documentationListItem {
+	children := make(map[string][]ComponentDefinitionClass, 0)
+	classNames := make(map[string]bool, 0)
+	for _, class := range component.Classes {
+		classNames[class.ClassName] = true
+	}
+	roots := []ComponentDefinitionClass{}
+	for _, class := range component.Classes {
+		parentClassName := component.parentClassName(class)
+		if classNames[parentClassName] {
+			children[parentClassName] = append(children[parentClassName], class)
+		} else {
+			roots = append(roots, class)
+		}
+	}
+
+	items := []documentationListItem{}
+	var addClasses func(classes []ComponentDefinitionClass, depth int)
+	addClasses = func(classes []ComponentDefinitionClass, depth int) {
+		for _, class := range classes {
+			spans := []documentationSpan{docCodeLink(class.ClassName, getDocumentationAnchor("class", class.ClassName))}
+			parentClassName := component.parentClassName(class)
+			if (depth == 0) && (parentClassName != "") {
+				spans = append(spans, docText(" (inherits from "), docCode(parentClassName), docText(")"))
+			}
+			items = append(items, documentationListItem{Depth: depth, Spans: spans})
+			addClasses(children[class.ClassName], depth+1)
+		}
+	}
+	addClasses(roots, 0)
+	return items
+}
+
+func buildDocumentation(component ComponentDefinition, w documentationWriter) error {
+	NameSpace := component.NameSpace
+
+	w.Begin(fmt.Sprintf("%s API Reference", component.LibraryName))
+	w.Heading(1, "", fmt.Sprintf("%s API Reference", component.LibraryName))
+	w.Paragraph(docText("Version "), docCode(component.Version), docText(", namespace "), docCode(NameSpace), docText(", library "), docCode(component.BaseName), docText("."))
+	if component.Copyright != "" {
+		w.Paragraph(docText(fmt.Sprintf("Copyright (C) %d %s", component.Year, component.Copyright)))
+	}
+	if len(component.ImportedComponentDefinitions) > 0 {
+		spans := []documentationSpan{docText("Imported namespaces: ")}
+		for k, subNameSpace := range component.importedNameSpaces() {
+			if k > 0 {
+				spans = append(spans, docText(", "))
+			}
+			spans = append(spans, docCode(subNameSpace))
+		}
+		w.Paragraph(append(spans, docText("."))...)
+	}
+
+	contents := []documentationListItem{}
+	contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Class hierarchy", "class-hierarchy")}})
+	if len(component.Global.Methods) > 0 {
+		contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Global functions", "global-functions")}})
+	}
+	contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Classes", "classes")}})
+	if len(component.Constants) > 0 {
+		contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Constants", "constants")}})
+	}
+	if len(component.Enums) > 0 {
+		contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Enums", "enums")}})
+	}
+	if len(component.Structs) > 0 {
+		contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Structs", "structs")}})
+	}
+	if len(component.Functions) > 0 {
+		contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Function types", "function-types")}})
+	}
+	if len(component.Errors.Errors) > 0 {
+		contents = append(contents, documentationListItem{Spans: []documentationSpan{docLink("Error codes", "error-codes")}})
+	}
+	w.Heading(2, "contents", "Contents")
+	w.List(contents)
+
+	w.Heading(2, "class-hierarchy", "Class hierarchy")
+	w.List(getDocumentationClassHierarchy(component))
+
+	if len(component.Global.Methods) > 0 {
+		w.Heading(2, "global-functions", "Global functions")
+		for _, method := range component.Global.Methods {
+			err := writeDocumentationMethod(component, w, method, "Wrapper", true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	w.Heading(2, "classes", "Classes")
+	for _, class := range component.Classes {
+		w.Heading(3, getDocumentationAnchor("class", class.ClassName), class.ClassName)
+		if class.ClassDescription != "" {
+			w.Paragraph(docText(class.ClassDescription))
+		}
+		if class.IsDeprecated() {
+			w.Paragraph(docText(class.DeprecationNotice() + "."))
+		}
+		parentClassName := component.parentClassName(class)
+		if parentClassName != "" {
+			w.Paragraph(docText("Inherits from "), docCodeLink(parentClassName, getDocumentationTypeLink("class", parentClassName)), docText("."))
+		}
+		derivedClasses := []documentationSpan{}
+		for _, derivedClass := range component.Classes {
+			if component.parentClassName(derivedClass) == class.ClassName {
+				if len(derivedClasses) > 0 {
+					derivedClasses = append(derivedClasses, docText(", "))
+				}
+				derivedClasses = append(derivedClasses, docCodeLink(derivedClass.ClassName, getDocumentationAnchor("class", derivedClass.ClassName)))
+			}
+		}
+		if len(derivedClasses) > 0 {
+			w.Paragraph(append(append([]documentationSpan{docText("Derived classes: ")}, derivedClasses...), docText("."))...)
+		}
+		if len(class.Properties) > 0 {
+			rows := [][]documentationSpan{}
+			for _, property := range class.Properties {
+				getter := property.Getter()
+				rows = append(rows, []documentationSpan{docCode(property.Name), getDocumentationParamType(getter.Params[0]), docText(property.Access), docText(property.Description)})
+			}
+			w.Table([]string{"Property", "Type", "Access", "Description"}, rows)
+		}
+		if len(class.Methods) > 0 {
+			methods := []documentationListItem{}
+			for _, method := range class.Methods {
+				spans := []documentationSpan{docCodeLink(method.MethodName, getDocumentationMethodAnchor(class.ClassName, method, false))}
+				description := getDocumentationDescription(method.MethodDescription, method.ComponentDefinitionDeprecation)
+				if description != "" {
+					spans = append(spans, docText(": "+description))
+				}
+				methods = append(methods, documentationListItem{Spans: spans})
+			}
+			w.List(methods)
+		}
+		for _, method := range class.Methods {
+			err := writeDocumentationMethod(component, w, method, class.ClassName, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(component.Constants) > 0 {
+		w.Heading(2, "constants", "Constants")
+		rows := [][]documentationSpan{}
+		for _, constant := range component.Constants {
+			value := constant.Value
+			if constant.Type == "string" {
+				value = strconv.Quote(value)
+			}
+			rows = append(rows, []documentationSpan{docCode(constant.Name), docCode(constant.Type), docCode(value), docText(constant.Description)})
+		}
+		w.Table([]string{"Constant", "Type", "Value", "Description"}, rows)
+	}
+
+	if len(component.Enums) > 0 {
+		w.Heading(2, "enums", "Enums")
+		for _, enum := range component.Enums {
+			w.Heading(3, getDocumentationAnchor("enum", enum.Name), enum.Name)
+			if enum.Description != "" {
+				w.Paragraph(docText(enum.Description))
+			}
+			if enum.IsDeprecated() {
+				w.Paragraph(docText(enum.DeprecationNotice() + "."))
+			}
+			hasDeprecatedOptions := false
+			hasOptionDescriptions := false
+			for _, option := range enum.Options {
+				hasDeprecatedOptions = hasDeprecatedOptions || option.IsDeprecated()
+				hasOptionDescriptions = hasOptionDescriptions || (option.Description != "")
+			}
+			rows := [][]documentationSpan{}
+			for _, option := range enum.Options {
+				row := []documentationSpan{docCode(option.Name), docText(fmt.Sprintf("%d", option.Value))}
+				if hasDeprecatedOptions || hasOptionDescriptions {
+					row = append(row, docText(getDocumentationDescription(option.Description, option.ComponentDefinitionDeprecation)))
+				}
+				rows = append(rows, row)
+			}
+			if hasOptionDescriptions {
+				w.Table([]string{"Option", "Value", "Description"}, rows)
+			} else if hasDeprecatedOptions {
+				w.Table([]string{"Option", "Value", "Deprecation"}, rows)
+			} else {
+				w.Table([]string{"Option", "Value"}, rows)
+			}
+		}
+	}
+
+	if len(component.Structs) > 0 {
+		w.Heading(2, "structs", "Structs")
+		for _, structinfo := range component.Structs {
+			w.Heading(3, getDocumentationAnchor("struct", structinfo.Name), structinfo.Name)
+			if structinfo.Description != "" {
+				w.Paragraph(docText(structinfo.Description))
+			}
+			hasMemberDescriptions := false
+			for _, member := range structinfo.Members {
+				hasMemberDescriptions = hasMemberDescriptions || (member.Description != "")
+			}
+			rows := [][]documentationSpan{}
+			for _, member := range structinfo.Members {
+				row := []documentationSpan{docCode(member.Name), getDocumentationMemberType(member)}
+				if hasMemberDescriptions {
+					row = append(row, docText(member.Description))
+				}
+				rows = append(rows, row)
+			}
+			if hasMemberDescriptions {
+				w.Table([]string{"Member", "Type", "Description"}, rows)
+			} else {
+				w.Table([]string{"Member", "Type"}, rows)
+			}
+		}
+	}
+
+	if len(component.Functions) > 0 {
+		w.Heading(2, "function-types", "Function types")
+		for _, functiontype := range component.Functions {
+			w.Heading(3, getDocumentationAnchor("functiontype", functiontype.FunctionName), functiontype.FunctionName)
+			if functiontype.FunctionDescription != "" {
+				w.Paragraph(docText(functiontype.FunctionDescription))
+			}
+			writeDocumentationParams(w, functiontype.Params)
+		}
+	}
+
+	if len(component.Errors.Errors) > 0 {
+		w.Heading(2, "error-codes", "Error codes")
+		rows := [][]documentationSpan{}
+		for _, merror := range component.Errors.Errors {
+			rows = append(rows, []documentationSpan{docCode(strings.ToUpper(NameSpace) + "_ERROR_" + merror.Name), docText(fmt.Sprintf("%d", merror.Code)), docText(getDocumentationDescription(merror.Description, merror.ComponentDefinitionDeprecation))})
+		}
+		w.Table([]string{"Error", "Code", "Description"}, rows)
+	}
+
+	w.End()
+	return nil
+}
+
+// markdownDocumentationWriter writes the API reference as GitHub flavored Markdown
+type markdownDocumentationWriter struct {
+	w io.Writer
+}
+
+var markdownEscaper = strings.NewReplacer("\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "|", "\\|", "<", "&lt;", "[", "\\[", "]", "\\]")
+
+func (writer *markdownDocumentationWriter) spans(spans []documentationSpan) string {
+	result := ""
+	for _, span := range spans {
+		text := markdownEscaper.Replace(span.Text)
+		if span.Code {
+			text = "`" + strings.Replace(span.Text, "|", "\\|", -1) + "`"
+		}
+		if span.Link != "" {
+			text = "[" + text + "](#" + span.Link + ")"
+		}
+		result = result + text
+	}
+	return result
+}
+
+func (writer *markdownDocumentationWriter) Begin(title string) {
+	fmt.Fprintf(writer.w, "<!-- This file has been generated by the Automatic Component Toolkit (ACT) version %s. -->\n", ACTVersion)
+}
+
+func (writer *markdownDocumentationWriter) End() {
+}
+
+func (writer *markdownDocumentationWriter) Heading(level int, anchor string, text string) {
+	fmt.Fprintln(writer.w, "")
+	if anchor != "" {
+		fmt.Fprintf(writer.w, "<a id=\"%s\"></a>\n", anchor)
+	}
+	fmt.Fprintf(writer.w, "%s %s\n", strings.Repeat("#", level), markdownEscaper.Replace(text))
+}
+
+func (writer *markdownDocumentationWriter) Paragraph(spans ...documentationSpan) {
+	fmt.Fprintln(writer.w, "")
+	fmt.Fprintln(writer.w, writer.spans(spans))
+}
+
+func (writer *markdownDocumentationWriter) List(items []documentationListItem) {
+	fmt.Fprintln(writer.w, "")
+	for _, item := range items {
+		fmt.Fprintf(writer.w, "%s- %s\n", strings.Repeat("  ", item.Depth), writer.spans(item.Spans))
+	}
+}
+
+func (writer *markdownDocumentationWriter) Table(header []string, rows [][]documentationSpan) {
+	fmt.Fprintln(writer.w, "")
+	fmt.Fprintf(writer.w, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(writer.w, "|%s\n", strings.Repeat(" --- |", len(header)))
+	for _, row := range rows {
+		cells := []string{}
+		for _, cell := range row {
+			cells = append(cells, writer.spans([]documentationSpan{cell}))
+		}
+		fmt.Fprintf(writer.w, "| %s |\n", strings.Join(cells, " | "))
+	}
+}
+
+func (writer *markdownDocumentationWriter) Code(language string, codeFormat string, lines []string) {
+	fmt.Fprintln(writer.w, "")
+	fmt.Fprintf(writer.w, "%s:\n", language)
+	fmt.Fprintf(writer.w, "```%s\n", codeFormat)
+	for _, line := range lines {
+		fmt.Fprintln(writer.w, line)
+	}
+	fmt.Fprintln(writer.w, "```")
+}
+
+// htmlDocumentationWriter writes the API reference as a single HTML page
+type htmlDocumentationWriter struct {
+	w io.Writer
+}
+
+func (writer *htmlDocumentationWriter) spans(spans []documentationSpan) string {
+	result := ""
+	for _, span := range spans {
+		text := html.EscapeString(span.Text)
+		if span.Code {
+			text = "<code>" + text + "</code>"
+		}
+		if span.Link != "" {
+			text = "<a href=\"#" + span.Link + "\">" + text + "</a>"
+		}
+		result = result + text
+	}
+	return result
+}
+
+func (writer *htmlDocumentationWriter) Begin(title string) {
+	fmt.Fprintln(writer.w, "<!DOCTYPE html>")
+	fmt.Fprintf(writer.w, "<!-- This file has been generated by the Automatic Component Toolkit (ACT) version %s. -->\n", ACTVersion)
+	fmt.Fprintln(writer.w, "<html>")
+	fmt.Fprintln(writer.w, "<head>")
+	fmt.Fprintln(writer.w, "<meta charset=\"utf-8\">")
+	fmt.Fprintf(writer.w, "<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintln(writer.w, "<style>")
+	fmt.Fprintln(writer.w, "body { font-family: sans-serif; max-width: 60em; margin: auto; padding: 1em; }")
+	fmt.Fprintln(writer.w, "pre { background: #f4f4f4; padding: 0.5em; overflow-x: auto; }")
+	fmt.Fprintln(writer.w, "table { border-collapse: collapse; }")
+	fmt.Fprintln(writer.w, "th, td { border: 1px solid #ccc; padding: 0.2em 0.5em; text-align: left; }")
+	fmt.Fprintln(writer.w, ".language { font-size: small; color: #666; margin-bottom: 0; }")
+	fmt.Fprintln(writer.w, "</style>")
+	fmt.Fprintln(writer.w, "</head>")
+	fmt.Fprintln(writer.w, "<body>")
+}
+
+func (writer *htmlDocumentationWriter) End() {
+	fmt.Fprintln(writer.w, "</body>")
+	fmt.Fprintln(writer.w, "</html>")
+}
+
+func (writer *htmlDocumentationWriter) Heading(level int, anchor string, text string) {
+	if anchor != "" {
+		fmt.Fprintf(writer.w, "<h%d id=\"%s\">%s</h%d>\n", level, anchor, html.EscapeString(text), level)
+	} else {
+		fmt.Fprintf(writer.w, "<h%d>%s</h%d>\n", level, html.EscapeString(text), level)
+	}
+}
+
+func (writer *htmlDocumentationWriter) Paragraph(spans ...documentationSpan) {
+	fmt.Fprintf(writer.w, "<p>%s</p>\n", writer.spans(spans))
+}
+
+func (writer *htmlDocumentationWriter) List(items []documentationListItem) {
+	depth := -1
+	for _, item := range items {
+		for ; depth < item.Depth; depth++ {
+			fmt.Fprintln(writer.w, "<ul>")
+		}
+		for ; depth > item.Depth; depth-- {
+			fmt.Fprintln(writer.w, "</ul>")
+		}
+		fmt.Fprintf(writer.w, "<li>%s</li>\n", writer.spans(item.Spans))
+	}
+	for ; depth >= 0; depth-- {
+		fmt.Fprintln(writer.w, "</ul>")
+	}
+}
+
+func (writer *htmlDocumentationWriter) Table(header []string, rows [][]documentationSpan) {
+	fmt.Fprintln(writer.w, "<table>")
+	fmt.Fprint(writer.w, "<tr>")
+	for _, title := range header {
+		fmt.Fprintf(writer.w, "<th>%s</th>", html.EscapeString(title))
+	}
+	fmt.Fprintln(writer.w, "</tr>")
+	for _, row := range rows {
+		fmt.Fprint(writer.w, "<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(writer.w, "<td>%s</td>", writer.spans([]documentationSpan{cell}))
+		}
+		fmt.Fprintln(writer.w, "</tr>")
+	}
+	fmt.Fprintln(writer.w, "</table>")
+}
+
+func (writer *htmlDocumentationWriter) Code(language string, codeFormat string, lines []string) {
+	fmt.Fprintf(writer.w, "<p class=\"language\">%s</p>\n", html.EscapeString(language))
+	fmt.Fprintf(writer.w, "<pre><code class=\"language-%s\">%s</code></pre>\n", codeFormat, html.EscapeString(strings.Join(lines, "\n")))
+}
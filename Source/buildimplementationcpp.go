@@ -144,9 +144,8 @@ func BuildImplementationCPP(component ComponentDefinition, outputFolder string,
 	}
 
 	IntfWrapperStubName := path.Join(stubOutputFolder, BaseName+stubIdentifier+".cpp")
-	if forceRecreation || (!FileExists(IntfWrapperStubName)) {
-		log.Printf("Creating \"%s\"", IntfWrapperStubName)
-		stubfile, err := CreateLanguageFile(IntfWrapperStubName, indentString)
+	if forceRecreation || updateImplementationStubs || (!FileExists(IntfWrapperStubName)) {
+		stubfile, err := CreateStubFile(IntfWrapperStubName, indentString, "Cpp")
 		if err != nil {
 			return err
 		}
@@ -154,7 +153,11 @@ func BuildImplementationCPP(component ComponentDefinition, outputFolder string,
 			fmt.Sprintf("This is an autogenerated C++ implementation file in order to allow easy\ndevelopment of %s. It needs to be generated only once.", LibraryName),
 			true)
 
-		err = buildCPPGlobalStubFile(component, stubfile, NameSpace, ImplementationSubNameSpace, implementation.ClassIdentifier, BaseName)
+		err = buildCPPGlobalStubFile(component, stubfile.LanguageWriter, NameSpace, ImplementationSubNameSpace, implementation.ClassIdentifier, BaseName)
+		if err != nil {
+			return err
+		}
+		err = stubfile.Close()
 		if err != nil {
 			return err
 		}
@@ -501,6 +504,8 @@ func buildCPPGlobalStubFile(component ComponentDefinition, stubfile LanguageWrit
 	stubfile.Writeln("#include \"%s_interfaces.hpp\"", BaseName)
 	stubfile.Writeln("#include \"%s_interfaceexception.hpp\"", BaseName)
 	stubfile.Writeln("")
+	writeProtectedRegion(stubfile, "", "Includes", []string{"// Include custom headers here."})
+	stubfile.Writeln("")
 	stubfile.Writeln("using namespace %s;", NameSpace)
 	stubfile.Writeln("using namespace %s::%s;", NameSpace, NameSpaceImplementation)
 	stubfile.Writeln("")
@@ -556,7 +561,7 @@ func buildCPPGlobalStubFile(component ComponentDefinition, stubfile LanguageWrit
 
 		stubfile.Writeln("%s", implementationdeclaration)
 		stubfile.Writeln("{")
-		stubfile.Writelns("  ", thisMethodDefaultImpl)
+		writeProtectedRegion(stubfile, "  ", method.MethodName, thisMethodDefaultImpl)
 		stubfile.Writeln("}")
 		stubfile.Writeln("")
 	}
@@ -789,6 +794,9 @@ func writeCImplementationMethod(component ComponentDefinition, method ComponentD
 		CMethodName = fmt.Sprintf("%s_%s", strings.ToLower(NameSpace), strings.ToLower(method.MethodName))
 	} else {
 		CMethodName = fmt.Sprintf("%s_%s_%s", strings.ToLower(NameSpace), strings.ToLower(ClassName), strings.ToLower(method.MethodName))
+	}
+	hasInstance := !isGlobal && !method.IsStatic
+	if hasInstance {
 		if cparameters != "" {
 			cparameters = ", " + cparameters
 		}
@@ -845,7 +853,7 @@ func writeCImplementationMethod(component ComponentDefinition, method ComponentD
 		}
 	}
 
-	if !isGlobal {
+	if hasInstance {
 		preCallCPPFunctionCode = append(preCallCPPFunctionCode, fmt.Sprintf("I%s%s* pI%s = dynamic_cast<I%s%s*>(pIBaseClass);", ClassIdentifier, ClassName, ClassName, ClassIdentifier, ClassName))
 		preCallCPPFunctionCode = append(preCallCPPFunctionCode, fmt.Sprintf("if (!pI%s)", ClassName))
 		preCallCPPFunctionCode = append(preCallCPPFunctionCode, fmt.Sprintf("  throw E%sInterfaceException(%s_ERROR_INVALIDCAST);", NameSpace, strings.ToUpper(NameSpace)))
@@ -856,7 +864,7 @@ func writeCImplementationMethod(component ComponentDefinition, method ComponentD
 	w.Writeln("{")
 
 	IBaseClassName := fmt.Sprintf("I%s%s", ClassIdentifier, BaseClassName)
-	if !isGlobal {
+	if hasInstance {
 		w.Writeln("  %s* pIBaseClass = (%s *)p%s;\n", IBaseClassName, IBaseClassName, ClassName)
 	} else {
 		w.Writeln("  %s* pIBaseClass = nullptr;\n", IBaseClassName)
@@ -906,13 +914,14 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 
 	StubHeaderFileName := path.Join(outputFolder, BaseName+stubIdentifier+"_"+strings.ToLower(class.ClassName)+".hpp")
 	StubImplFileName := path.Join(outputFolder, BaseName+stubIdentifier+"_"+strings.ToLower(class.ClassName)+".cpp")
-	if !forceRecreation && (FileExists(StubHeaderFileName) || FileExists(StubImplFileName)) {
+	stubHeaderExists := FileExists(StubHeaderFileName)
+	stubImplExists := FileExists(StubImplFileName)
+	if !forceRecreation && !updateImplementationStubs && (stubHeaderExists || stubImplExists) {
 		log.Printf("Omitting recreation of Stub implementation for \"%s\"", outClassName)
 		return nil
 	}
 
-	log.Printf("Creating \"%s\"", StubHeaderFileName)
-	stubheaderw, err := CreateLanguageFile(StubHeaderFileName, indentString)
+	stubheaderw, err := CreateStubFile(StubHeaderFileName, indentString, "Cpp")
 	if err != nil {
 		return err
 	}
@@ -920,8 +929,7 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 		fmt.Sprintf("This is the class declaration of %s", outClassName),
 		false)
 
-	log.Printf("Creating \"%s\"", StubImplFileName)
-	stubimplw, err := CreateLanguageFile(StubImplFileName, indentString)
+	stubimplw, err := CreateStubFile(StubImplFileName, indentString, "Cpp")
 	if err != nil {
 		return err
 	}
@@ -958,7 +966,7 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 	}
 	stubheaderw.Writeln("")
 
-	stubheaderw.Writeln("// Include custom headers here.")
+	writeProtectedRegion(stubheaderw.LanguageWriter, "", "Includes", []string{"// Include custom headers here."})
 	stubheaderw.Writeln("")
 
 	stubheaderw.Writeln("")
@@ -984,28 +992,22 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 		stubheaderw.Writeln("  %s_uint32 m_nReferenceCount = 1;", NameSpace)
 		stubheaderw.Writeln("")
 	}
-	stubheaderw.Writeln("  /**")
-	stubheaderw.Writeln("  * Put private members here.")
-	stubheaderw.Writeln("  */")
+	writeProtectedRegion(stubheaderw.LanguageWriter, "  ", "PrivateMembers", []string{"/**", "* Put private members here.", "*/"})
 	stubheaderw.Writeln("")
 
 	stubheaderw.Writeln("protected:")
 	stubheaderw.Writeln("")
-	stubheaderw.Writeln("  /**")
-	stubheaderw.Writeln("  * Put protected members here.")
-	stubheaderw.Writeln("  */")
+	writeProtectedRegion(stubheaderw.LanguageWriter, "  ", "ProtectedMembers", []string{"/**", "* Put protected members here.", "*/"})
 	stubheaderw.Writeln("")
 	stubheaderw.Writeln("public:")
 	stubheaderw.Writeln("")
-	stubheaderw.Writeln("  /**")
-	stubheaderw.Writeln("  * Put additional public members here. They will not be visible in the external API.")
-	stubheaderw.Writeln("  */")
+	writeProtectedRegion(stubheaderw.LanguageWriter, "  ", "PublicMembers", []string{"/**", "* Put additional public members here. They will not be visible in the external API.", "*/"})
 	stubheaderw.Writeln("")
 
 	stubimplw.Writeln("#include \"%s%s_%s.hpp\"", BaseName, stubIdentifier, strings.ToLower(class.ClassName))
 	stubimplw.Writeln("#include \"%s_interfaceexception.hpp\"", BaseName)
 	stubimplw.Writeln("")
-	stubimplw.Writeln("// Include custom headers here.")
+	writeProtectedRegion(stubimplw.LanguageWriter, "", "Includes", []string{"// Include custom headers here."})
 	stubimplw.Writeln("")
 
 	stubimplw.Writeln("")
@@ -1061,7 +1063,7 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 
 			stubimplw.Writeln("%s", implementationdeclaration)
 			stubimplw.Writeln("{")
-			stubimplw.Writelns("  ", implementations[i])
+			writeProtectedRegion(stubimplw.LanguageWriter, "  ", methods[i].MethodName, implementations[i])
 			stubimplw.Writeln("}")
 			stubimplw.Writeln("")
 		}
@@ -1079,12 +1081,14 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 		if err != nil {
 			return err
 		}
-		stubheaderw.Writeln("%s;", methodstring)
-		stubheaderw.Writeln("")
+		if !method.IsStatic {
+			stubheaderw.Writeln("%s;", methodstring)
+			stubheaderw.Writeln("")
+		}
 
 		stubimplw.Writeln("%s", implementationdeclaration)
 		stubimplw.Writeln("{")
-		stubimplw.Writeln("  throw E%sInterfaceException(%s_ERROR_NOTIMPLEMENTED);", NameSpace, strings.ToUpper(NameSpace))
+		writeProtectedRegion(stubimplw.LanguageWriter, "  ", method.MethodName, []string{fmt.Sprintf("throw E%sInterfaceException(%s_ERROR_NOTIMPLEMENTED);", NameSpace, strings.ToUpper(NameSpace))})
 		stubimplw.Writeln("}")
 		stubimplw.Writeln("")
 	}
@@ -1102,7 +1106,12 @@ func buildCPPStubClass(component ComponentDefinition, class ComponentDefinitionC
 		stubheaderw.Writeln("#endif")
 	}
 	stubheaderw.Writeln("#endif // __%s_%s", strings.ToUpper(NameSpace), strings.ToUpper(class.ClassName))
-	return nil
+
+	err = stubheaderw.Close()
+	if err != nil {
+		return err
+	}
+	return stubimplw.Close()
 }
 
 func buildCPPStub(component ComponentDefinition, NameSpace string, NameSpaceImplementation string, ClassIdentifier string, BaseName string, outputFolder string, indentString string, stubIdentifier string, forceRecreation bool) error {
@@ -1333,6 +1342,8 @@ func buildCPPInterfaceMethodDeclaration(method ComponentDefinitionMethod, classN
 			return "", "", fmt.Errorf("Method \"%s\"can not be virtual static", method.MethodName)
 		}
 		outstring = outstring + fmt.Sprintf(indentString+"static %s %s(%s)", returntype, method.MethodName, parameters)
+	} else if method.IsStatic {
+		outstring = outstring + fmt.Sprintf(indentString+"static %s %s(%s)", returntype, method.MethodName, parameters)
 	} else {
 		if isVirtual {
 			outstring = outstring + fmt.Sprintf(indentString+"virtual %s %s(%s) = 0", returntype, method.MethodName, parameters)
@@ -1343,6 +1354,9 @@ func buildCPPInterfaceMethodDeclaration(method ComponentDefinitionMethod, classN
 
 	if isGlobal {
 		templateimplementation = fmt.Sprintf("%s C%s%s::%s(%s)", returntype, ClassIdentifier, className, method.MethodName, parameters)
+	} else if method.IsStatic {
+		// Static methods are declared by the interface of the class
+		templateimplementation = fmt.Sprintf("%s I%s%s::%s(%s)", returntype, ClassIdentifier, className, method.MethodName, parameters)
 	} else {
 		templateimplementation = fmt.Sprintf("%s C%s%s::%s(%s)", returntype, ClassIdentifier, className, method.MethodName, parameters)
 	}
@@ -1588,6 +1602,8 @@ func generateCallCPPFunctionCode(method ComponentDefinitionMethod, NameSpace str
 	callFunctionCode := ""
 	if isGlobal {
 		callFunctionCode = fmt.Sprintf("%sC%s%s::%s(%s);\n", returnValueCode, ClassIdentifier, ClassName, method.MethodName, callParameters)
+	} else if method.IsStatic {
+		callFunctionCode = fmt.Sprintf("%sI%s%s::%s(%s);\n", returnValueCode, ClassIdentifier, ClassName, method.MethodName, callParameters)
 	} else {
 		callFunctionCode = fmt.Sprintf("%spI%s->%s(%s);\n", returnValueCode, ClassName, method.MethodName, callParameters)
 	}
@@ -1599,9 +1615,15 @@ func generateJournalFunctionCode(method ComponentDefinitionMethod, NameSpace str
 	journalInitFunctionCode := make([]string, 0)
 	journalSuccessFunctionCode := make([]string, 0)
 
-	journalInitFunctionCode = append(journalInitFunctionCode, fmt.Sprintf("if (m_GlobalJournal.get() != nullptr)  {"))
+	journalClassName := ClassName
+	if isGlobal {
+		journalClassName = ""
+	}
+	journalInitFunctionCode = append(journalInitFunctionCode, fmt.Sprintf("if ((m_GlobalJournal.get() != nullptr) && m_GlobalJournal->recordsCall(\"%s\", \"%s\"))  {", journalClassName, method.MethodName))
 	if isGlobal {
 		journalInitFunctionCode = append(journalInitFunctionCode, fmt.Sprintf("  pJournalEntry = m_GlobalJournal->beginStaticFunction(\"%s\");", method.MethodName))
+	} else if method.IsStatic {
+		journalInitFunctionCode = append(journalInitFunctionCode, fmt.Sprintf("  pJournalEntry = m_GlobalJournal->beginClassMethod(nullptr, \"%s\", \"%s\");", ClassName, method.MethodName))
 	} else {
 		journalInitFunctionCode = append(journalInitFunctionCode, fmt.Sprintf("  pJournalEntry = m_GlobalJournal->beginClassMethod(p%s, \"%s\", \"%s\");", ClassName, ClassName, method.MethodName))
 	}
@@ -1799,6 +1821,115 @@ func buildCMakeForCPPImplementation(component ComponentDefinition, w LanguageWri
 	}
 }
 
+// buildJournalingCPPHelpers generates the functions that format and filter journal entries
+func buildJournalingCPPHelpers(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+	valueListType := "std::list<std::pair<std::pair<std::string, std::string>, std::string>>"
+
+	w.Writeln("static bool %sHasSuffix (const std::string & sValue, const std::string & sSuffix)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  return (sValue.size() >= sSuffix.size()) && (sValue.compare(sValue.size() - sSuffix.size(), sSuffix.size(), sSuffix) == 0);")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("static std::vector<std::string> %sSplitJournalOption (const std::string & sValue, char cSeparator)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  std::vector<std::string> Parts;")
+	w.Writeln("  std::stringstream sStream (sValue);")
+	w.Writeln("  std::string sPart;")
+	w.Writeln("  while (std::getline (sStream, sPart, cSeparator))")
+	w.Writeln("    Parts.push_back (sPart);")
+	w.Writeln("  if (Parts.empty())")
+	w.Writeln("    Parts.push_back (\"\");")
+	w.Writeln("  return Parts;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("// A filter is Class, Class.Method, global.Method or *.Method. Methods are recorded with the class that declares them.")
+	w.Writeln("static bool %sJournalFilterMatches (const std::string & sFilter, const std::string & sClassName, const std::string & sMethodName)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  size_t nDot = sFilter.find('.');")
+	w.Writeln("  std::string sFilterClass = sFilter.substr(0, nDot);")
+	w.Writeln("  std::string sClass = sClassName.empty() ? \"global\" : sClassName;")
+	w.Writeln("  if ((sFilterClass != \"*\") && (sFilterClass != sClass))")
+	w.Writeln("    return false;")
+	w.Writeln("  if (nDot == std::string::npos)")
+	w.Writeln("    return true;")
+	w.Writeln("  std::string sFilterMethod = sFilter.substr(nDot + 1);")
+	w.Writeln("  return (sFilterMethod == \"*\") || (sFilterMethod == sMethodName);")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("static std::string %sEscapeXML (const std::string & sValue)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  std::string sResult;")
+	w.Writeln("  for (char cChar : sValue) {")
+	w.Writeln("    switch (cChar) {")
+	w.Writeln("      case '&': sResult += \"&amp;\"; break;")
+	w.Writeln("      case '<': sResult += \"&lt;\"; break;")
+	w.Writeln("      case '>': sResult += \"&gt;\"; break;")
+	w.Writeln("      case '\"': sResult += \"&quot;\"; break;")
+	w.Writeln("      default: sResult += cChar;")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("  return sResult;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("static std::string %sEscapeJSON (const std::string & sValue)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  std::stringstream sStream;")
+	w.Writeln("  for (char cChar : sValue) {")
+	w.Writeln("    switch (cChar) {")
+	w.Writeln("      case '\"': sStream << \"\\\\\\\"\"; break;")
+	w.Writeln("      case '\\\\': sStream << \"\\\\\\\\\"; break;")
+	w.Writeln("      case '\\n': sStream << \"\\\\n\"; break;")
+	w.Writeln("      case '\\r': sStream << \"\\\\r\"; break;")
+	w.Writeln("      case '\\t': sStream << \"\\\\t\"; break;")
+	w.Writeln("      default:")
+	w.Writeln("        if ((unsigned char) cChar < 0x20)")
+	w.Writeln("          sStream << \"\\\\u\" << std::setfill('0') << std::setw(4) << std::hex << (int) cChar << std::dec;")
+	w.Writeln("        else")
+	w.Writeln("          sStream << cChar;")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("  return sStream.str();")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("static std::string %sJournalValuesToJSON (const %s & Values)", NameSpace, valueListType)
+	w.Writeln("{")
+	w.Writeln("  std::stringstream sStream;")
+	w.Writeln("  sStream << \"[\";")
+	w.Writeln("  for (auto iValue = Values.begin(); iValue != Values.end(); iValue++) {")
+	w.Writeln("    if (iValue != Values.begin())")
+	w.Writeln("      sStream << \",\";")
+	w.Writeln("    sStream << \"{\\\"name\\\":\\\"\" << iValue->first.first << \"\\\",\\\"type\\\":\\\"\" << iValue->first.second << \"\\\",\\\"value\\\":\\\"\" << %sEscapeJSON(iValue->second) << \"\\\"}\";", NameSpace)
+	w.Writeln("  }")
+	w.Writeln("  sStream << \"]\";")
+	w.Writeln("  return sStream.str();")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("// Integers are written in little endian byte order, strings are prefixed with their length")
+	w.Writeln("static void %sAppendBinaryUInt (std::string & sBuffer, %s_uint64 nValue, size_t nByteCount)", NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  for (size_t nIndex = 0; nIndex < nByteCount; nIndex++)")
+	w.Writeln("    sBuffer += (char) ((nValue >> (8 * nIndex)) & 0xff);")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("static void %sAppendBinaryString (std::string & sBuffer, const std::string & sValue)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  %sAppendBinaryUInt (sBuffer, sValue.size(), 4);", NameSpace)
+	w.Writeln("  sBuffer += sValue;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("static void %sAppendBinaryValues (std::string & sBuffer, const %s & Values)", NameSpace, valueListType)
+	w.Writeln("{")
+	w.Writeln("  %sAppendBinaryUInt (sBuffer, Values.size(), 4);", NameSpace)
+	w.Writeln("  for (auto & Value : Values) {")
+	w.Writeln("    %sAppendBinaryString (sBuffer, Value.first.first);", NameSpace)
+	w.Writeln("    %sAppendBinaryString (sBuffer, Value.first.second);", NameSpace)
+	w.Writeln("    %sAppendBinaryString (sBuffer, Value.second);", NameSpace)
+	w.Writeln("  }")
+	w.Writeln("}")
+	w.Writeln("")
+}
+
 // buildJournalingCPP generates Declaration and Implementation of the Journaling class
 func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, implw LanguageWriter) error {
 	NameSpace := component.NameSpace
@@ -1812,6 +1943,7 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	headerw.Writeln("#include <string>")
 	headerw.Writeln("#include <memory>")
 	headerw.Writeln("#include <list>")
+	headerw.Writeln("#include <vector>")
 	headerw.Writeln("#include <mutex>")
 	headerw.Writeln("#include <chrono>")
 	headerw.Writeln("#include \"%s_types.hpp\"", BaseName)
@@ -1831,12 +1963,16 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	headerw.Writeln("    std::string m_sClassName;")
 	headerw.Writeln("    std::string m_sMethodName;")
 	headerw.Writeln("    std::string m_sInstanceHandle;")
+	headerw.Writeln("    %s_uint64 m_nInstanceHandle;", NameSpace)
 	headerw.Writeln("    %s_uint64 m_nInitTimeStamp;", NameSpace)
 	headerw.Writeln("    %s_uint64 m_nFinishTimeStamp;", NameSpace)
 	headerw.Writeln("    std::list<std::pair<std::pair<std::string, std::string>, std::string>> m_sParameters;")
 	headerw.Writeln("    std::list<std::pair<std::pair<std::string, std::string>, std::string>> m_sResultValues;")
 	headerw.Writeln("")
+	headerw.Writeln("    %s_uint64 getDuration();", NameSpace)
 	headerw.Writeln("    std::string getXMLString();")
+	headerw.Writeln("    std::string getJSONString();")
+	headerw.Writeln("    std::string getBinaryString();")
 	headerw.Writeln("    void addParameter (const std::string & sName, const std::string & sParameterType, const std::string & sParameterValue);")
 	headerw.Writeln("    void addResult (const std::string & sName, const std::string & sResultType, const std::string & sResultValue);")
 
@@ -1891,19 +2027,40 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 
 	headerw.Writeln("class C%sInterfaceJournal {", NameSpace)
 	headerw.Writeln("")
+	headerw.Writeln("  public:")
+	headerw.Writeln("")
+	headerw.Writeln("    enum class eFormat {")
+	headerw.Writeln("      XML,")
+	headerw.Writeln("      JSONLines,")
+	headerw.Writeln("      Binary")
+	headerw.Writeln("    };")
+	headerw.Writeln("")
 	headerw.Writeln("  protected:")
 	headerw.Writeln("")
 	headerw.Writeln("    std::string m_sFileName;")
+	headerw.Writeln("    eFormat m_Format;")
+	headerw.Writeln("    std::vector<std::string> m_IncludeFilters;")
+	headerw.Writeln("    std::vector<std::string> m_ExcludeFilters;")
+	headerw.Writeln("    %s_uint64 m_nMaxFileSize;", NameSpace)
+	headerw.Writeln("    %s_uint32 m_nMaxFileCount;", NameSpace)
 	headerw.Writeln("    std::mutex m_Mutex;")
 	headerw.Writeln("    std::ofstream m_Stream;")
 	headerw.Writeln("    std::chrono::time_point<std::chrono::high_resolution_clock> m_StartTime;")
+	headerw.Writeln("    void parseOptions (const std::string & sJournalOptions);")
+	headerw.Writeln("    void openFile ();")
+	headerw.Writeln("    void closeFile ();")
+	headerw.Writeln("    void rotateFiles ();")
 	headerw.Writeln("    void writeEntry (C%sInterfaceJournalEntry * pEntry);", NameSpace)
 	headerw.Writeln("    %s_uint64 getTimeStamp ();", NameSpace)
 	headerw.Writeln("")
 	headerw.Writeln("  public:")
 	headerw.Writeln("")
+	headerw.Writeln("    // sFileName may be followed by options of the form \";name=value\": format (xml, jsonl or binary),")
+	headerw.Writeln("    // include and exclude (comma separated filters Class, Class.Method, global.Method or *.Method),")
+	headerw.Writeln("    // maxsize (in bytes, the journal is rotated when it is exceeded) and maxfiles (number of rotated files)")
 	headerw.Writeln("    C%sInterfaceJournal (const std::string & sFileName);", NameSpace)
 	headerw.Writeln("    ~C%sInterfaceJournal ();", NameSpace)
+	headerw.Writeln("    bool recordsCall (const std::string & sClassName, const std::string & sMethodName);")
 	headerw.Writeln("    P%sInterfaceJournalEntry beginClassMethod (const %sHandle pHandle, const std::string & sClassName, const std::string & sMethodName);", NameSpace, NameSpace)
 	headerw.Writeln("    P%sInterfaceJournalEntry beginStaticFunction (const std::string & sMethodName);", NameSpace)
 	headerw.Writeln("    friend class C%sInterfaceJournalEntry;", NameSpace)
@@ -1918,6 +2075,7 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("#include <string>")
 	implw.Writeln("#include <sstream>")
 	implw.Writeln("#include <iomanip>")
+	implw.Writeln("#include <cstdio>")
 	implw.Writeln("")
 	implw.Writeln("#include \"%s_interfacejournal.hpp\"", strings.ToLower(BaseName))
 	implw.Writeln("#include \"%s_interfaceexception.hpp\"", strings.ToLower(BaseName))
@@ -1932,6 +2090,7 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("  return stream.str();")
 	implw.Writeln("}")
 	implw.Writeln("")
+	buildJournalingCPPHelpers(component, implw)
 
 	implw.Writeln("C%sInterfaceJournalEntry::C%sInterfaceJournalEntry(C%sInterfaceJournal * pJournal, std::string sClassName, std::string sMethodName, %sHandle pInstanceHandle)", NameSpace, NameSpace, NameSpace, NameSpace)
 	implw.Writeln("  : m_pJournal(pJournal), m_ErrorCode(%s_SUCCESS), m_sClassName(sClassName), m_sMethodName(sMethodName), m_nInitTimeStamp(0), m_nFinishTimeStamp(0)", strings.ToUpper(NameSpace))
@@ -1940,6 +2099,7 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("    throw E%sInterfaceException(%s_ERROR_INVALIDPARAM);", NameSpace, strings.ToUpper(NameSpace))
 	implw.Writeln("  m_nInitTimeStamp = m_pJournal->getTimeStamp ();")
 	implw.Writeln("  m_sInstanceHandle = %sHandleToHex (pInstanceHandle);", NameSpace)
+	implw.Writeln("  m_nInstanceHandle = (%s_uint64) pInstanceHandle;", NameSpace)
 	implw.Writeln("}")
 	implw.Writeln("")
 	implw.Writeln("C%sInterfaceJournalEntry::~C%sInterfaceJournalEntry()", NameSpace, NameSpace)
@@ -1956,13 +2116,16 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("  m_sResultValues.push_back(std::make_pair(std::make_pair(sName, sResultType), sResultValue));")
 	implw.Writeln("}")
 	implw.Writeln("")
+	implw.Writeln("%s_uint64 C%sInterfaceJournalEntry::getDuration()", NameSpace, NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  if (m_nFinishTimeStamp > m_nInitTimeStamp)")
+	implw.Writeln("    return m_nFinishTimeStamp - m_nInitTimeStamp;")
+	implw.Writeln("  return 0;")
+	implw.Writeln("}")
+	implw.Writeln("")
 	implw.Writeln("std::string C%sInterfaceJournalEntry::getXMLString()", NameSpace)
 	implw.Writeln("{")
 	implw.Writeln("  std::stringstream sStream;")
-	implw.Writeln("  %s_uint64 nDuration = 0;", NameSpace)
-	implw.Writeln("")
-	implw.Writeln("  if (m_nFinishTimeStamp > m_nInitTimeStamp)")
-	implw.Writeln("    nDuration = m_nFinishTimeStamp - m_nInitTimeStamp;")
 	implw.Writeln("")
 	implw.Writeln("  sStream << \"    <entry\";")
 	implw.Writeln("  if (m_sClassName != \"\")")
@@ -1970,20 +2133,20 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("  sStream << \" method=\\\"\" << m_sMethodName << \"\\\"\";")
 	implw.Writeln("  if (m_ErrorCode != %s_SUCCESS)", strings.ToUpper(NameSpace))
 	implw.Writeln("    sStream << \" errorcode=\\\"\" << m_ErrorCode << \"\\\"\";")
-	implw.Writeln("  sStream << \" timestamp=\\\"\" << m_nInitTimeStamp << \"\\\" duration=\\\"\" << nDuration << \"\\\">\\n\";")
+	implw.Writeln("  sStream << \" timestamp=\\\"\" << m_nInitTimeStamp << \"\\\" duration=\\\"\" << getDuration() << \"\\\">\\n\";")
 	implw.Writeln("")
 	implw.Writeln("  if (m_sClassName != \"\")")
 	implw.Writeln("    sStream << \"        <instance handle=\\\"\" << m_sInstanceHandle << \"\\\" />\\n\";")
 	implw.Writeln("")
 	implw.Writeln("  auto iParamIter = m_sParameters.begin();")
 	implw.Writeln("  while (iParamIter != m_sParameters.end()) {")
-	implw.Writeln("    sStream << \"        <parameter name=\\\"\" << iParamIter->first.first << \"\\\" type=\\\"\" << iParamIter->first.second << \"\\\" value=\\\"\" << iParamIter->second <<\"\\\" />\\n\";")
+	implw.Writeln("    sStream << \"        <parameter name=\\\"\" << iParamIter->first.first << \"\\\" type=\\\"\" << iParamIter->first.second << \"\\\" value=\\\"\" << %sEscapeXML(iParamIter->second) << \"\\\" />\\n\";", NameSpace)
 	implw.Writeln("    iParamIter++;")
 	implw.Writeln("  }")
 	implw.Writeln("")
 	implw.Writeln("  auto iResultIter = m_sResultValues.begin();")
 	implw.Writeln("  while (iResultIter != m_sResultValues.end()) {")
-	implw.Writeln("    sStream << \"        <result name=\\\"\" << iResultIter->first.first << \"\\\" type=\\\"\" << iResultIter->first.second << \"\\\" value=\\\"\" << iResultIter->second << \"\\\" />\\n\";")
+	implw.Writeln("    sStream << \"        <result name=\\\"\" << iResultIter->first.first << \"\\\" type=\\\"\" << iResultIter->first.second << \"\\\" value=\\\"\" << %sEscapeXML(iResultIter->second) << \"\\\" />\\n\";", NameSpace)
 	implw.Writeln("    iResultIter++;")
 	implw.Writeln("  }")
 	implw.Writeln("")
@@ -1991,6 +2154,42 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("  return sStream.str ();")
 	implw.Writeln("}")
 	implw.Writeln("")
+	implw.Writeln("std::string C%sInterfaceJournalEntry::getJSONString()", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  std::stringstream sStream;")
+	implw.Writeln("")
+	implw.Writeln("  sStream << \"{\";")
+	implw.Writeln("  if (m_sClassName != \"\")")
+	implw.Writeln("    sStream << \"\\\"class\\\":\\\"\" << m_sClassName << \"\\\",\";")
+	implw.Writeln("  sStream << \"\\\"method\\\":\\\"\" << m_sMethodName << \"\\\"\";")
+	implw.Writeln("  sStream << \",\\\"errorcode\\\":\" << m_ErrorCode;")
+	implw.Writeln("  sStream << \",\\\"timestamp\\\":\" << m_nInitTimeStamp << \",\\\"duration\\\":\" << getDuration();")
+	implw.Writeln("  if (m_sClassName != \"\")")
+	implw.Writeln("    sStream << \",\\\"instance\\\":\\\"\" << m_sInstanceHandle << \"\\\"\";")
+	implw.Writeln("  sStream << \",\\\"parameters\\\":\" << %sJournalValuesToJSON(m_sParameters);", NameSpace)
+	implw.Writeln("  sStream << \",\\\"results\\\":\" << %sJournalValuesToJSON(m_sResultValues);", NameSpace)
+	implw.Writeln("  sStream << \"}\";")
+	implw.Writeln("  return sStream.str ();")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("std::string C%sInterfaceJournalEntry::getBinaryString()", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  std::string sRecord;")
+	implw.Writeln("  %sAppendBinaryString(sRecord, m_sClassName);", NameSpace)
+	implw.Writeln("  %sAppendBinaryString(sRecord, m_sMethodName);", NameSpace)
+	implw.Writeln("  %sAppendBinaryUInt(sRecord, (%s_uint32) m_ErrorCode, 4);", NameSpace, NameSpace)
+	implw.Writeln("  %sAppendBinaryUInt(sRecord, m_nInitTimeStamp, 8);", NameSpace)
+	implw.Writeln("  %sAppendBinaryUInt(sRecord, getDuration(), 8);", NameSpace)
+	implw.Writeln("  %sAppendBinaryUInt(sRecord, m_nInstanceHandle, 8);", NameSpace)
+	implw.Writeln("  %sAppendBinaryValues(sRecord, m_sParameters);", NameSpace)
+	implw.Writeln("  %sAppendBinaryValues(sRecord, m_sResultValues);", NameSpace)
+	implw.Writeln("")
+	implw.Writeln("  // Every record is prefixed with its size, so that readers can skip it")
+	implw.Writeln("  std::string sBuffer;")
+	implw.Writeln("  %sAppendBinaryUInt(sBuffer, sRecord.size(), 4);", NameSpace)
+	implw.Writeln("  return sBuffer + sRecord;")
+	implw.Writeln("}")
+	implw.Writeln("")
 	implw.Writeln("void C%sInterfaceJournalEntry::writeSuccess()", NameSpace)
 	implw.Writeln("{")
 	implw.Writeln("  writeError(%s_SUCCESS);", strings.ToUpper(NameSpace))
@@ -2164,21 +2363,127 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("")
 	implw.Writeln("")
 	implw.Writeln("C%sInterfaceJournal::C%sInterfaceJournal (const std::string & sFileName)", NameSpace, NameSpace)
-	implw.Writeln("  : m_sFileName (sFileName)")
+	implw.Writeln("  : m_Format (eFormat::XML), m_nMaxFileSize (0), m_nMaxFileCount (1)")
 	implw.Writeln("{")
 	implw.Writeln("  m_StartTime = std::chrono::high_resolution_clock::now();")
-	implw.Writeln("  m_Stream.open (sFileName, std::ios::out);")
-	implw.Writeln("  m_Stream << \"<?xml version=\\\"1.0\\\" encoding=\\\"UTF-8\\\" ?>\\n\";")
-	implw.Writeln("  m_Stream << \"<journal library=\\\"%s\\\" version=\\\"%s\\\" xmlns=\\\"http://schemas.autodesk.com/components/%s/%s\\\">\\n\";",
+	implw.Writeln("  parseOptions (sFileName);")
+	implw.Writeln("  openFile ();")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("C%sInterfaceJournal::~C%sInterfaceJournal ()", NameSpace, NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  closeFile ();")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("void C%sInterfaceJournal::parseOptions (const std::string & sJournalOptions)", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  std::vector<std::string> Options = %sSplitJournalOption (sJournalOptions, ';');", NameSpace)
+	implw.Writeln("  m_sFileName = Options[0];")
+	implw.Writeln("  if (%sHasSuffix (m_sFileName, \".jsonl\"))", NameSpace)
+	implw.Writeln("    m_Format = eFormat::JSONLines;")
+	implw.Writeln("  else if (%sHasSuffix (m_sFileName, \".bin\"))", NameSpace)
+	implw.Writeln("    m_Format = eFormat::Binary;")
+	implw.Writeln("")
+	implw.Writeln("  for (size_t nIndex = 1; nIndex < Options.size(); nIndex++) {")
+	implw.Writeln("    size_t nEquals = Options[nIndex].find('=');")
+	implw.Writeln("    if (nEquals == std::string::npos)")
+	implw.Writeln("      throw E%sInterfaceException (%s_ERROR_INVALIDPARAM);", NameSpace, strings.ToUpper(NameSpace))
+	implw.Writeln("    std::string sName = Options[nIndex].substr(0, nEquals);")
+	implw.Writeln("    std::string sValue = Options[nIndex].substr(nEquals + 1);")
+	implw.Writeln("")
+	implw.Writeln("    if (sName == \"format\") {")
+	implw.Writeln("      if (sValue == \"xml\")")
+	implw.Writeln("        m_Format = eFormat::XML;")
+	implw.Writeln("      else if (sValue == \"jsonl\")")
+	implw.Writeln("        m_Format = eFormat::JSONLines;")
+	implw.Writeln("      else if (sValue == \"binary\")")
+	implw.Writeln("        m_Format = eFormat::Binary;")
+	implw.Writeln("      else")
+	implw.Writeln("        throw E%sInterfaceException (%s_ERROR_INVALIDPARAM);", NameSpace, strings.ToUpper(NameSpace))
+	implw.Writeln("    }")
+	implw.Writeln("    else if (sName == \"include\") {")
+	implw.Writeln("      for (auto & sFilter : %sSplitJournalOption (sValue, ','))", NameSpace)
+	implw.Writeln("        m_IncludeFilters.push_back (sFilter);")
+	implw.Writeln("    }")
+	implw.Writeln("    else if (sName == \"exclude\") {")
+	implw.Writeln("      for (auto & sFilter : %sSplitJournalOption (sValue, ','))", NameSpace)
+	implw.Writeln("        m_ExcludeFilters.push_back (sFilter);")
+	implw.Writeln("    }")
+	implw.Writeln("    else if ((sName == \"maxsize\") || (sName == \"maxfiles\")) {")
+	implw.Writeln("      if (sValue.empty() || (sValue.find_first_not_of(\"0123456789\") != std::string::npos))")
+	implw.Writeln("        throw E%sInterfaceException (%s_ERROR_INVALIDPARAM);", NameSpace, strings.ToUpper(NameSpace))
+	implw.Writeln("      if (sName == \"maxsize\")")
+	implw.Writeln("        m_nMaxFileSize = std::stoull (sValue);")
+	implw.Writeln("      else")
+	implw.Writeln("        m_nMaxFileCount = (%s_uint32) std::stoul (sValue);", NameSpace)
+	implw.Writeln("    }")
+	implw.Writeln("    else {")
+	implw.Writeln("      throw E%sInterfaceException (%s_ERROR_INVALIDPARAM);", NameSpace, strings.ToUpper(NameSpace))
+	implw.Writeln("    }")
+	implw.Writeln("  }")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("void C%sInterfaceJournal::openFile ()", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  m_Stream.open (m_sFileName, std::ios::out | std::ios::binary | std::ios::trunc);")
+	implw.Writeln("  switch (m_Format) {")
+	implw.Writeln("    case eFormat::XML:")
+	implw.Writeln("      m_Stream << \"<?xml version=\\\"1.0\\\" encoding=\\\"UTF-8\\\" ?>\\n\";")
+	implw.Writeln("      m_Stream << \"<journal library=\\\"%s\\\" version=\\\"%s\\\" xmlns=\\\"http://schemas.autodesk.com/components/%s/%s\\\">\\n\";",
 		NameSpace, component.Version, NameSpace, component.Version)
-	implw.Writeln("  m_Stream << \"\\n\";")
+	implw.Writeln("      m_Stream << \"\\n\";")
+	implw.Writeln("      break;")
+	implw.Writeln("    case eFormat::JSONLines:")
+	implw.Writeln("      m_Stream << \"{\\\"library\\\":\\\"%s\\\",\\\"version\\\":\\\"%s\\\"}\\n\";", NameSpace, component.Version)
+	implw.Writeln("      break;")
+	implw.Writeln("    case eFormat::Binary: {")
+	implw.Writeln("      std::string sHeader (\"ACTJ\");")
+	implw.Writeln("      %sAppendBinaryUInt (sHeader, 1, 4);", NameSpace)
+	implw.Writeln("      %sAppendBinaryString (sHeader, \"%s\");", NameSpace, NameSpace)
+	implw.Writeln("      %sAppendBinaryString (sHeader, \"%s\");", NameSpace, component.Version)
+	implw.Writeln("      m_Stream.write (sHeader.c_str(), sHeader.size());")
+	implw.Writeln("      break;")
+	implw.Writeln("    }")
+	implw.Writeln("  }")
+	implw.Writeln("}")
 	implw.Writeln("")
-
+	implw.Writeln("void C%sInterfaceJournal::closeFile ()", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  if (m_Format == eFormat::XML)")
+	implw.Writeln("    m_Stream << \"</journal>\\n\";")
+	implw.Writeln("  m_Stream.close ();")
 	implw.Writeln("}")
 	implw.Writeln("")
-	implw.Writeln("C%sInterfaceJournal::~C%sInterfaceJournal ()", NameSpace, NameSpace)
+	implw.Writeln("void C%sInterfaceJournal::rotateFiles ()", NameSpace)
 	implw.Writeln("{")
-	implw.Writeln("  m_Stream << \"</journal>\\n\";")
+	implw.Writeln("  closeFile ();")
+	implw.Writeln("  for (%s_uint32 nIndex = m_nMaxFileCount; nIndex > 0; nIndex--) {", NameSpace)
+	implw.Writeln("    std::string sRotatedFileName = m_sFileName + \".\" + std::to_string (nIndex);")
+	implw.Writeln("    if (nIndex == m_nMaxFileCount)")
+	implw.Writeln("      std::remove (sRotatedFileName.c_str());")
+	implw.Writeln("    else")
+	implw.Writeln("      std::rename (sRotatedFileName.c_str(), (m_sFileName + \".\" + std::to_string (nIndex + 1)).c_str());")
+	implw.Writeln("  }")
+	implw.Writeln("  if (m_nMaxFileCount > 0)")
+	implw.Writeln("    std::rename (m_sFileName.c_str(), (m_sFileName + \".1\").c_str());")
+	implw.Writeln("  openFile ();")
+	implw.Writeln("}")
+	implw.Writeln("")
+	implw.Writeln("bool C%sInterfaceJournal::recordsCall (const std::string & sClassName, const std::string & sMethodName)", NameSpace)
+	implw.Writeln("{")
+	implw.Writeln("  bool bIncluded = m_IncludeFilters.empty();")
+	implw.Writeln("  for (auto & sFilter : m_IncludeFilters) {")
+	implw.Writeln("    if (%sJournalFilterMatches (sFilter, sClassName, sMethodName))", NameSpace)
+	implw.Writeln("      bIncluded = true;")
+	implw.Writeln("  }")
+	implw.Writeln("  if (!bIncluded)")
+	implw.Writeln("    return false;")
+	implw.Writeln("")
+	implw.Writeln("  for (auto & sFilter : m_ExcludeFilters) {")
+	implw.Writeln("    if (%sJournalFilterMatches (sFilter, sClassName, sMethodName))", NameSpace)
+	implw.Writeln("      return false;")
+	implw.Writeln("  }")
+	implw.Writeln("  return true;")
 	implw.Writeln("}")
 	implw.Writeln("")
 	implw.Writeln("P%sInterfaceJournalEntry C%sInterfaceJournal::beginClassMethod(const %sHandle pHandle, const std::string & sClassName, const std::string & sMethodName)", NameSpace, NameSpace, NameSpace)
@@ -2196,11 +2501,24 @@ func buildJournalingCPP(component ComponentDefinition, headerw LanguageWriter, i
 	implw.Writeln("  if (pEntry == nullptr)")
 	implw.Writeln("    throw E%sInterfaceException (%s_ERROR_INVALIDPARAM);", NameSpace, strings.ToUpper(NameSpace))
 	implw.Writeln("")
-	implw.Writeln("  std::string sXMLString = pEntry->getXMLString();")
+	implw.Writeln("  std::string sEntryString;")
+	implw.Writeln("  switch (m_Format) {")
+	implw.Writeln("    case eFormat::XML:")
+	implw.Writeln("      sEntryString = pEntry->getXMLString() + \"\\n\";")
+	implw.Writeln("      break;")
+	implw.Writeln("    case eFormat::JSONLines:")
+	implw.Writeln("      sEntryString = pEntry->getJSONString() + \"\\n\";")
+	implw.Writeln("      break;")
+	implw.Writeln("    case eFormat::Binary:")
+	implw.Writeln("      sEntryString = pEntry->getBinaryString();")
+	implw.Writeln("      break;")
+	implw.Writeln("  }")
+	implw.Writeln("")
 	implw.Writeln("  m_Mutex.lock();")
 	implw.Writeln("  try {")
-	implw.Writeln("    m_Stream << sXMLString;")
-	implw.Writeln("    m_Stream << \"\\n\";")
+	implw.Writeln("    m_Stream.write (sEntryString.c_str(), sEntryString.size());")
+	implw.Writeln("    if ((m_nMaxFileSize > 0) && ((%s_uint64) m_Stream.tellp() >= m_nMaxFileSize))", NameSpace)
+	implw.Writeln("      rotateFiles ();")
 	implw.Writeln("")
 	implw.Writeln("    m_Mutex.unlock();")
 	implw.Writeln("  }")
@@ -35,10 +35,10 @@ SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 package main
 
 import (
+	"crypto/sha1"
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
 	"path"
 	"strings"
 )
@@ -111,9 +111,8 @@ func BuildImplementationPascal(component ComponentDefinition, outputFolder strin
 	defaultImplementation = append(defaultImplementation, fmt.Sprintf("raise E%sException.Create(%s_ERROR_NOTIMPLEMENTED);", NameSpace, strings.ToUpper(NameSpace)))
 
 	IntfWrapperStubName := path.Join(stubOutputFolder, baseName+stubIdentifier+".pas")
-	if forceRecreation || (!FileExists(IntfWrapperStubName)) {
-		log.Printf("Creating \"%s\"", IntfWrapperStubName)
-		templatefile, err := CreateLanguageFile(IntfWrapperStubName, indentString)
+	if forceRecreation || updateImplementationStubs || (!FileExists(IntfWrapperStubName)) {
+		templatefile, err := CreateStubFile(IntfWrapperStubName, indentString, "Pascal")
 		if err != nil {
 			return err
 		}
@@ -121,7 +120,11 @@ func BuildImplementationPascal(component ComponentDefinition, outputFolder strin
 			fmt.Sprintf("This is an autogenerated Pascal implementation file in order to allow easy\ndevelopment of %s. It needs to be generated only once.", libraryname),
 			true)
 
-		err = buildStubImplementation(component, templatefile, NameSpace, baseName, stubIdentifier, defaultImplementation)
+		err = buildStubImplementation(component, templatefile.LanguageWriter, NameSpace, baseName, stubIdentifier, defaultImplementation)
+		if err != nil {
+			return err
+		}
+		err = templatefile.Close()
 		if err != nil {
 			return err
 		}
@@ -324,17 +327,14 @@ func buildPascalExceptionDefinition(component ComponentDefinition, w LanguageWri
 
 }
 
-func createRandomUUID() (string, error) {
+// createNameBasedUUID derives a UUID from a name, so that regenerating the same interface yields the same GUID
+func createNameBasedUUID(name string) (string, error) {
 
-	u := make([]byte, 16)
-
-	_, err := rand.Read(u)
-	if err != nil {
-		return "", err
-	}
+	hash := sha1.Sum([]byte(name))
+	u := hash[0:16]
 
-	u[8] = (u[8] | 0x80) & 0xBF // what does this do?
-	u[6] = (u[6] | 0x40) & 0x4F // what does this do?
+	u[8] = (u[8] | 0x80) & 0xBF // RFC 4122 variant
+	u[6] = (u[6] | 0x50) & 0x5F // version 5: name-based, SHA-1
 
 	uuid := fmt.Sprintf("%X-%X-%X-%X-%X", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
 
@@ -386,7 +386,7 @@ func buildPascalInterfaceDefinition(component ComponentDefinition, w LanguageWri
 			w.Writeln("I%s%s = interface(%s)", NameSpace, class.ClassName, parentClassName)
 		}
 
-		uuid, err := createRandomUUID()
+		uuid, err := createNameBasedUUID(NameSpace + "." + class.ClassName)
 		if err != nil {
 			return err
 		}
@@ -412,6 +412,10 @@ func buildPascalInterfaceDefinition(component ComponentDefinition, w LanguageWri
 
 		for j := 0; j < len(class.Methods); j++ {
 			method := class.Methods[j]
+			if method.IsStatic {
+				// Interfaces cannot declare class methods, the exports call the static methods on the stub class
+				continue
+			}
 			err := writePascalImplClassMethodDefinition(method, w, NameSpace, class.ClassName, false)
 			if err != nil {
 				return err
@@ -446,8 +450,9 @@ func writePascalExportDefinition(component ComponentDefinition, method Component
 
 	PascalExportName := GetCExportName(NameSpace, ClassName, method, isGlobal)
 
+	hasInstance := !isGlobal && !method.IsStatic
 	parameters := ""
-	if !isGlobal {
+	if hasInstance {
 		parameters = fmt.Sprintf("p%s: T%sHandle", ClassName, NameSpace)
 	}
 
@@ -455,7 +460,7 @@ func writePascalExportDefinition(component ComponentDefinition, method Component
 		w.Writeln("(**")
 		w.Writeln("* %s", method.MethodDescription)
 		w.Writeln("*")
-		if !isGlobal {
+		if hasInstance {
 			w.Writeln("* @param[in] p%s - %s instance.", ClassName, ClassName)
 		}
 	}
@@ -749,7 +754,8 @@ func writePascalClassExportImplementation(component ComponentDefinition, method
 		return err
 	}
 
-	if !isGlobal {
+	hasInstance := !isGlobal && !method.IsStatic
+	if hasInstance {
 		// Define variables
 		variableDefinitions = append(variableDefinitions, fmt.Sprintf("Object%s: TObject;", ClassName))
 		variableDefinitions = append(variableDefinitions, fmt.Sprintf("Intf%s: I%s%s;", ClassName, NameSpace, ClassName))
@@ -775,7 +781,7 @@ func writePascalClassExportImplementation(component ComponentDefinition, method
 	w.Writelns("    ", parameterChecks)
 
 	w.Writeln("")
-	if !isGlobal {
+	if hasInstance {
 		w.Writeln("    Object%s := TObject(p%s);", ClassName, ClassName)
 		w.Writeln("    if Supports(Object%s, I%s%s) then begin", ClassName, NameSpace, ClassName)
 		w.Writeln("      Intf%s := Object%s as I%s%s;", ClassName, ClassName, NameSpace, ClassName)
@@ -795,6 +801,10 @@ func writePascalClassExportImplementation(component ComponentDefinition, method
 		classInstanceToCall = "T" + NameSpace + "Wrapper"
 		classInstanceSpacing = "    "
 		postCallCodeSpacing = "    "
+	} else if method.IsStatic {
+		classInstanceToCall = "T" + ClassIdentifier + NameSpace + ClassName
+		classInstanceSpacing = "    "
+		postCallCodeSpacing = "    "
 	}
 
 	if len(defaultImplementationLines) > 0 {
@@ -805,7 +815,7 @@ func writePascalClassExportImplementation(component ComponentDefinition, method
 		w.Writelns(postCallCodeSpacing, postCallCode)
 	}
 
-	if !isGlobal {
+	if hasInstance {
 		w.Writeln("    end else")
 		w.Writeln("      raise E%sException.Create(%s_ERROR_INVALIDCAST);", NameSpace, strings.ToUpper(NameSpace))
 		w.Writeln("")
@@ -813,7 +823,7 @@ func writePascalClassExportImplementation(component ComponentDefinition, method
 
 	w.Writeln("    Result := %s_SUCCESS;", strings.ToUpper(NameSpace))
 	w.Writeln("  except")
-	if !isGlobal {
+	if hasInstance {
 		w.Writeln("    On E: E%sException do begin", NameSpace)
 		w.Writeln("      Result := Handle%sException(Object%s , E);", NameSpace, ClassName)
 		w.Writeln("    end;")
@@ -902,11 +912,18 @@ func buildPascalExportsDefinition(component ComponentDefinition, w LanguageWrite
 	w.Writeln("")
 	w.Writeln("uses")
 	w.Writeln("  %s%s,", BaseName, stubIdentifier)
+	for _, class := range component.Classes {
+		if class.hasStaticMethods() {
+			// Static methods are class methods of the stub classes
+			w.Writeln("  %s%s_%s,", BaseName, stubIdentifier, strings.ToLower(class.ClassName))
+		}
+	}
 	w.Writeln("  %s_types,", BaseName)
 	w.Writeln("  %s_interfaces,", BaseName)
 	w.Writeln("  %s_exception,", BaseName)
 	w.Writeln("  Classes,")
 	writeSubComponentUses(component, w, false)
+	writeProtectedRegion(w, "  ", "Uses", []string{})
 	w.Writeln("  sysutils;")
 	w.Writeln("")
 
@@ -1282,7 +1299,7 @@ func writePascalClassMethodDummyStub(method ComponentDefinitionMethod, w Languag
 	}
 
 	classPrefix := ""
-	if isGlobal {
+	if isGlobal || method.IsStatic {
 		classPrefix = "class "
 	}
 
@@ -1292,9 +1309,12 @@ func writePascalClassMethodDummyStub(method ComponentDefinitionMethod, w Languag
 		w.Writeln("%sfunction %s.%s(%s): %s;", classPrefix, outClassName, method.MethodName, parameters, returnType)
 	}
 
-	w.Writeln("begin")
-	w.Writelns("  ", customImplementation)
-	w.Writeln("end;")
+	body := []string{"begin"}
+	for _, line := range customImplementation {
+		body = append(body, "  "+line)
+	}
+	body = append(body, "end;")
+	writeProtectedRegion(w, "", method.MethodName, body)
 	w.Writeln("")
 
 	return nil
@@ -1341,16 +1361,16 @@ func buildPascalStub(component ComponentDefinition, NameSpace string, ClassIdent
 		}
 
 		StubFileName := path.Join(outputFolder, BaseName+stubIdentifier+"_"+strings.ToLower(class.ClassName)+".pas")
-		if !forceRecreation && (FileExists(StubFileName)) {
+		if !forceRecreation && !updateImplementationStubs && (FileExists(StubFileName)) {
 			log.Printf("Omitting recreation of Stub implementation for \"%s\"", outClassName)
 			continue
 		}
 
-		log.Printf("Creating \"%s\"", StubFileName)
-		w, err := CreateLanguageFile(StubFileName, indentString)
+		stub, err := CreateStubFile(StubFileName, indentString, "Pascal")
 		if err != nil {
 			return err
 		}
+		w := stub.LanguageWriter
 		w.WritePascalLicenseHeader(component,
 			fmt.Sprintf("This is the class declaration of %s", outClassName),
 			false)
@@ -1371,6 +1391,7 @@ func buildPascalStub(component ComponentDefinition, NameSpace string, ClassIdent
 
 		writeSubComponentUses(component, w, true)
 
+		writeProtectedRegion(w, "  ", "Uses", []string{})
 		w.Writeln("  Classes,")
 		w.Writeln("  sysutils;")
 		w.Writeln("")
@@ -1387,10 +1408,13 @@ func buildPascalStub(component ComponentDefinition, NameSpace string, ClassIdent
 			w.Writeln("      FMessages: TStringList;")
 			w.Writeln("      FReferenceCount: integer;")
 		}
+		writeProtectedRegion(w, "      ", "PrivateMembers", []string{})
 		w.Writeln("")
 		w.Writeln("    protected")
+		writeProtectedRegion(w, "      ", "ProtectedMembers", []string{})
 		w.Writeln("")
 		w.Writeln("    public")
+		writeProtectedRegion(w, "      ", "PublicMembers", []string{})
 
 		if component.isBaseClass(class) {
 			w.Writeln("      constructor Create();")
@@ -1421,20 +1445,15 @@ func buildPascalStub(component ComponentDefinition, NameSpace string, ClassIdent
 
 		w.Writeln("implementation")
 		w.Writeln("")
+		writeProtectedRegion(w, "", "Implementation", []string{})
+		w.Writeln("")
 
 		if component.isBaseClass(class) {
 			w.Writeln("constructor %s.Create();", pascalBaseClassName)
-			w.Writeln("begin")
-			w.Writeln("  inherited Create();")
-			w.Writeln("  FMessages := TStringList.Create();")
-			w.Writeln("  FReferenceCount := 1;")
-			w.Writeln("end;")
+			writeProtectedRegion(w, "", "Create", []string{"begin", "  inherited Create();", "  FMessages := TStringList.Create();", "  FReferenceCount := 1;", "end;"})
 			w.Writeln("")
 			w.Writeln("destructor %s.Destroy();", pascalBaseClassName)
-			w.Writeln("begin")
-			w.Writeln("  FreeAndNil(FMessages);")
-			w.Writeln("  inherited Destroy();")
-			w.Writeln("end;")
+			writeProtectedRegion(w, "", "Destroy", []string{"begin", "  FreeAndNil(FMessages);", "  inherited Destroy();", "end;"})
 			w.Writeln("")
 		}
 
@@ -1456,6 +1475,11 @@ func buildPascalStub(component ComponentDefinition, NameSpace string, ClassIdent
 		}
 
 		w.Writeln("end.")
+
+		err = stub.Close()
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -1469,7 +1493,7 @@ func writePascalImplClassMethodDefinition(method ComponentDefinitionMethod, w La
 	}
 
 	classPrefix := ""
-	if isGlobal {
+	if isGlobal || method.IsStatic {
 		classPrefix = "class "
 	}
 
@@ -1506,6 +1530,7 @@ func buildStubImplementation(component ComponentDefinition, w LanguageWriter, Na
 	w.Writeln("  %s_interfaces,", BaseName)
 	w.Writeln("  Classes,")
 	writeSubComponentUses(component, w, false)
+	writeProtectedRegion(w, "  ", "Uses", []string{})
 	w.Writeln("  sysutils;")
 	w.Writeln("")
 	w.Writeln("type")
@@ -1548,6 +1573,8 @@ func buildStubImplementation(component ComponentDefinition, w LanguageWriter, Na
 	w.Writeln("")
 	w.Writeln("implementation")
 	w.Writeln("")
+	writeProtectedRegion(w, "", "Implementation", []string{})
+	w.Writeln("")
 
 	for _, subComponent := range component.ImportedComponentDefinitions {
 		theNameSpace := subComponent.NameSpace
@@ -0,0 +1,1016 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// buildreplaycpp.go
+// generates a C++ program that replays the journal of a C++ implementation against the library
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"strings"
+)
+
+// BuildReplayCpp generates a C++ replay program for the journal of a component, together with the
+// dynamic C binding it uses to load the library.
+func BuildReplayCpp(component ComponentDefinition, outputFolder string, indentString string) error {
+	if len(component.Global.JournalMethod) == 0 {
+		return fmt.Errorf("component \"%s\" does not define a journal method", component.NameSpace)
+	}
+
+	CTypesHeaderName := path.Join(outputFolder, component.BaseName+"_types.h")
+	log.Printf("Creating \"%s\"", CTypesHeaderName)
+	err := CreateCTypesHeader(component, CTypesHeaderName)
+	if err != nil {
+		return err
+	}
+	for _, subComponent := range component.ImportedComponentDefinitions {
+		CPPTypesHeaderName := path.Join(outputFolder, subComponent.BaseName+"_types.hpp")
+		log.Printf("Creating \"%s\"", CPPTypesHeaderName)
+		err = CreateCPPTypesHeader(subComponent, CPPTypesHeaderName)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = BuildBindingCExplicit(component, outputFolder, "", indentString)
+	if err != nil {
+		return err
+	}
+
+	ReplayFileName := path.Join(outputFolder, component.NameSpace+"_Replay.cpp")
+	log.Printf("Creating \"%s\"", ReplayFileName)
+	replayfile, err := CreateLanguageFile(ReplayFileName, indentString)
+	if err != nil {
+		return err
+	}
+	replayfile.WriteCLicenseHeader(component,
+		fmt.Sprintf("This is an autogenerated C++ application that replays a journal\nof %s against the library and reports the first call that diverges.", component.LibraryName),
+		true)
+	err = buildReplayCppProgram(component, replayfile)
+	if err != nil {
+		return err
+	}
+
+	CMakeFileName := path.Join(outputFolder, "CMakeLists.txt")
+	log.Printf("Creating \"%s\"", CMakeFileName)
+	cmakefile, err := CreateLanguageFile(CMakeFileName, "  ")
+	if err != nil {
+		return err
+	}
+	cmakefile.WriteCMakeLicenseHeader(component,
+		fmt.Sprintf("This is an autogenerated CMake Project for the journal replay of %s", component.LibraryName),
+		true)
+	buildReplayCppCMake(component, cmakefile)
+	return nil
+}
+
+func buildReplayCppCMake(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+	projectName := NameSpace + "Replay"
+
+	w.Writeln("cmake_minimum_required(VERSION 3.5)")
+	w.Writeln("")
+	w.Writeln("project(%s C CXX)", projectName)
+	w.Writeln("set(CMAKE_CXX_STANDARD 11)")
+	w.Writeln("")
+	w.Writeln("# The dynamic binding is plain C")
+	bindingSource := "\"${CMAKE_CURRENT_SOURCE_DIR}/" + component.BaseName + "_dynamic.cc\""
+	w.Writeln("SET_SOURCE_FILES_PROPERTIES(%s PROPERTIES LANGUAGE C)", bindingSource)
+	w.Writeln("")
+	w.Writeln("add_executable(%s\n  \"${CMAKE_CURRENT_SOURCE_DIR}/%s_Replay.cpp\"\n  %s\n)", projectName, NameSpace, bindingSource)
+	w.Writeln("if (UNIX)")
+	w.Writeln("  target_link_libraries(%s ${CMAKE_DL_LIBS})", projectName)
+	w.Writeln("endif (UNIX)")
+	w.Writeln("target_include_directories(%s PRIVATE \"${CMAKE_CURRENT_SOURCE_DIR}\")", projectName)
+}
+
+func buildReplayCppProgram(component ComponentDefinition, w LanguageWriter) error {
+	NameSpace := component.NameSpace
+	upperNameSpace := strings.ToUpper(NameSpace)
+
+	w.Writeln("#include <cstdint>")
+	w.Writeln("#include <fstream>")
+	w.Writeln("#include <iomanip>")
+	w.Writeln("#include <iostream>")
+	w.Writeln("#include <map>")
+	w.Writeln("#include <sstream>")
+	w.Writeln("#include <stdexcept>")
+	w.Writeln("#include <string>")
+	w.Writeln("#include <vector>")
+	w.Writeln("")
+	w.Writeln("extern \"C\" {")
+	w.Writeln("#include \"%s_dynamic.h\"", component.BaseName)
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("")
+
+	buildReplayCppJournalReader(component, w)
+
+	w.Writeln("/*************************************************************************************************************************")
+	w.Writeln(" Class C%sReplay", NameSpace)
+	w.Writeln("**************************************************************************************************************************/")
+	w.Writeln("")
+	w.Writeln("class C%sReplay {", NameSpace)
+	w.Writeln("private:")
+	w.Writeln("  s%sDynamicWrapperTable m_WrapperTable;", NameSpace)
+	w.Writeln("")
+	w.Writeln("  // Maps the handles recorded in the journal to the handles of this process")
+	w.Writeln("  std::map<std::string, %sHandle> m_Handles;", NameSpace)
+	w.Writeln("")
+	w.Writeln("  std::vector<std::string> m_Divergences;")
+	w.Writeln("  size_t m_nSkippedEntries;")
+	w.Writeln("")
+	w.Writeln("  static bool isNullHandle(const std::string & sRecordedHandle);")
+	w.Writeln("  const s%sReplayValue & getParameter(const s%sReplayEntry & Entry, const std::string & sName);", NameSpace, NameSpace)
+	w.Writeln("  const s%sReplayValue * findResult(const s%sReplayEntry & Entry, const std::string & sName);", NameSpace, NameSpace)
+	w.Writeln("  %sHandle getHandle(const std::string & sRecordedHandle);", NameSpace)
+	w.Writeln("  bool checkErrorCode(const s%sReplayEntry & Entry, %sResult nErrorCode);", NameSpace, NameSpace)
+	w.Writeln("  void checkResult(const s%sReplayEntry & Entry, const std::string & sName, const std::string & sValue);", NameSpace)
+	w.Writeln("  void checkHandleResult(const s%sReplayEntry & Entry, const std::string & sName, %sHandle pHandle);", NameSpace, NameSpace)
+	w.Writeln("  void skipEntry(const s%sReplayEntry & Entry, const std::string & sReason);", NameSpace)
+	w.Writeln("")
+
+	global := component.Global
+	for _, method := range global.Methods {
+		w.Writeln("  void replayGlobal_%s(const s%sReplayEntry & Entry);", method.MethodName, NameSpace)
+	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			w.Writeln("  void replay%s_%s(const s%sReplayEntry & Entry);", class.ClassName, method.MethodName, NameSpace)
+		}
+	}
+	w.Writeln("")
+	w.Writeln("public:")
+	w.Writeln("  C%sReplay(const std::string & sLibraryFileName);", NameSpace)
+	w.Writeln("  ~C%sReplay();", NameSpace)
+	w.Writeln("")
+	w.Writeln("  // Replays a journal entry. Returns false if the call diverges from the recording.")
+	w.Writeln("  bool replayEntry(const s%sReplayEntry & Entry);", NameSpace)
+	w.Writeln("  const std::vector<std::string> & getDivergences();")
+	w.Writeln("  size_t getSkippedEntryCount();")
+	w.Writeln("};")
+	w.Writeln("")
+	w.Writeln("")
+
+	w.Writeln("C%sReplay::C%sReplay(const std::string & sLibraryFileName)", NameSpace, NameSpace)
+	w.Writeln("  : m_nSkippedEntries(0)")
+	w.Writeln("{")
+	w.Writeln("  Init%sWrapperTable(&m_WrapperTable);", NameSpace)
+	w.Writeln("  %sResult nErrorCode = Load%sWrapperTable(&m_WrapperTable, sLibraryFileName.c_str());", NameSpace, NameSpace)
+	w.Writeln("  if (nErrorCode != %s_SUCCESS)", upperNameSpace)
+	w.Writeln("    throw std::runtime_error(\"could not load library \\\"\" + sLibraryFileName + \"\\\" (error \" + std::to_string(nErrorCode) + \")\");")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("C%sReplay::~C%sReplay()", NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  Release%sWrapperTable(&m_WrapperTable);", NameSpace)
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("bool C%sReplay::isNullHandle(const std::string & sRecordedHandle)", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  return sRecordedHandle.find_first_not_of('0') == std::string::npos;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("const s%sReplayValue & C%sReplay::getParameter(const s%sReplayEntry & Entry, const std::string & sName)", NameSpace, NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  for (auto & Parameter : Entry.m_Parameters) {")
+	w.Writeln("    if (Parameter.m_sName == sName)")
+	w.Writeln("      return Parameter;")
+	w.Writeln("  }")
+	w.Writeln("  throw E%sReplayError(\"the journal does not contain the parameter \\\"\" + sName + \"\\\"\");", NameSpace)
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("const s%sReplayValue * C%sReplay::findResult(const s%sReplayEntry & Entry, const std::string & sName)", NameSpace, NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  for (auto & Result : Entry.m_Results) {")
+	w.Writeln("    if (Result.m_sName == sName)")
+	w.Writeln("      return &Result;")
+	w.Writeln("  }")
+	w.Writeln("  return nullptr;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("%sHandle C%sReplay::getHandle(const std::string & sRecordedHandle)", NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  if (isNullHandle(sRecordedHandle))")
+	w.Writeln("    return nullptr;")
+	w.Writeln("  auto iHandle = m_Handles.find(sRecordedHandle);")
+	w.Writeln("  if (iHandle == m_Handles.end())")
+	w.Writeln("    throw E%sReplayError(\"handle \" + sRecordedHandle + \" has not been returned by a replayed call\");", NameSpace)
+	w.Writeln("  return iHandle->second;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("bool C%sReplay::checkErrorCode(const s%sReplayEntry & Entry, %sResult nErrorCode)", NameSpace, NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  if (nErrorCode == Entry.m_nErrorCode)")
+	w.Writeln("    return true;")
+	w.Writeln("  m_Divergences.push_back(\"error code is \" + std::to_string(nErrorCode) + \", recorded \" + std::to_string(Entry.m_nErrorCode));")
+	w.Writeln("  return false;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("void C%sReplay::checkResult(const s%sReplayEntry & Entry, const std::string & sName, const std::string & sValue)", NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  const s%sReplayValue * pResult = findResult(Entry, sName);", NameSpace)
+	w.Writeln("  if ((pResult != nullptr) && (pResult->m_sValue != sValue))")
+	w.Writeln("    m_Divergences.push_back(\"result \" + sName + \" is \\\"\" + sValue + \"\\\", recorded \\\"\" + pResult->m_sValue + \"\\\"\");")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("void C%sReplay::checkHandleResult(const s%sReplayEntry & Entry, const std::string & sName, %sHandle pHandle)", NameSpace, NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  const s%sReplayValue * pResult = findResult(Entry, sName);", NameSpace)
+	w.Writeln("  if (pResult == nullptr)")
+	w.Writeln("    return;")
+	w.Writeln("  if (isNullHandle(pResult->m_sValue) != (pHandle == nullptr)) {")
+	w.Writeln("    m_Divergences.push_back(\"result \" + sName + \" is \" + (pHandle == nullptr ? \"a null handle\" : \"a handle\") + \", recorded \" + pResult->m_sValue);")
+	w.Writeln("    return;")
+	w.Writeln("  }")
+	w.Writeln("  // Handles are reused by the library once they are released, so a recorded handle is remapped")
+	w.Writeln("  // every time it is returned.")
+	w.Writeln("  if (pHandle != nullptr)")
+	w.Writeln("    m_Handles[pResult->m_sValue] = pHandle;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("void C%sReplay::skipEntry(const s%sReplayEntry & Entry, const std::string & sReason)", NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  std::cerr << \"Warning: skipping call \" << Entry.m_nIndex << \" (\" << Entry.getCallName() << \"): \" << sReason << std::endl;")
+	w.Writeln("  m_nSkippedEntries++;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("const std::vector<std::string> & C%sReplay::getDivergences()", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  return m_Divergences;")
+	w.Writeln("}")
+	w.Writeln("")
+	w.Writeln("size_t C%sReplay::getSkippedEntryCount()", NameSpace)
+	w.Writeln("{")
+	w.Writeln("  return m_nSkippedEntries;")
+	w.Writeln("}")
+	w.Writeln("")
+
+	buildReplayCppDispatch(component, w)
+
+	for _, method := range global.Methods {
+		isSpecialFunction, err := CheckHeaderSpecialFunction(method, global)
+		if err != nil {
+			return err
+		}
+		err = buildReplayCppMethod(component, w, "", method, isSpecialFunction)
+		if err != nil {
+			return err
+		}
+	}
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			err := buildReplayCppMethod(component, w, class.ClassName, method, eSpecialMethodNone)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	buildReplayCppMain(component, w)
+	return nil
+}
+
+func buildReplayCppJournalReader(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+
+	w.Writeln("/*************************************************************************************************************************")
+	w.Writeln(" Journal entries")
+	w.Writeln("**************************************************************************************************************************/")
+	w.Writeln("")
+	w.Writeln("class E%sReplayError : public std::runtime_error {", NameSpace)
+	w.Writeln("public:")
+	w.Writeln("  E%sReplayError(const std::string & sMessage)", NameSpace)
+	w.Writeln("    : std::runtime_error(sMessage)")
+	w.Writeln("  {")
+	w.Writeln("  }")
+	w.Writeln("};")
+	w.Writeln("")
+	w.Writeln("struct s%sReplayValue {", NameSpace)
+	w.Writeln("  std::string m_sName;")
+	w.Writeln("  std::string m_sType;")
+	w.Writeln("  std::string m_sValue;")
+	w.Writeln("};")
+	w.Writeln("")
+	w.Writeln("struct s%sReplayEntry {", NameSpace)
+	w.Writeln("  size_t m_nIndex;")
+	w.Writeln("  std::string m_sClassName;")
+	w.Writeln("  std::string m_sMethodName;")
+	w.Writeln("  %sResult m_nErrorCode;", NameSpace)
+	w.Writeln("  std::string m_sTimeStamp;")
+	w.Writeln("  std::string m_sInstanceHandle;")
+	w.Writeln("  std::vector<s%sReplayValue> m_Parameters;", NameSpace)
+	w.Writeln("  std::vector<s%sReplayValue> m_Results;", NameSpace)
+	w.Writeln("")
+	w.Writeln("  std::string getCallName() const")
+	w.Writeln("  {")
+	w.Writeln("    if (m_sClassName.empty())")
+	w.Writeln("      return m_sMethodName;")
+	w.Writeln("    return m_sClassName + \"::\" + m_sMethodName;")
+	w.Writeln("  }")
+	w.Writeln("};")
+	w.Writeln("")
+	w.Writeln("")
+	w.Writeln("/*************************************************************************************************************************")
+	w.Writeln(" Class C%sJournalReader", NameSpace)
+	w.Writeln("**************************************************************************************************************************/")
+	w.Writeln("")
+	w.Writeln("// Reads the XML, JSON Lines or binary journal written by the C++ implementation of %s", NameSpace)
+	w.Writeln("class C%sJournalReader {", NameSpace)
+	w.Writeln("private:")
+	w.Writeln("  std::string m_sContent;")
+	w.Writeln("  size_t m_nPosition;")
+	w.Writeln("  size_t m_nLine;")
+	w.Writeln("")
+	w.Writeln("  static std::string decodeXML(const std::string & sValue)")
+	w.Writeln("  {")
+	w.Writeln("    static const char * Entities[5][2] = { { \"&lt;\", \"<\" }, { \"&gt;\", \">\" }, { \"&quot;\", \"\\\"\" }, { \"&apos;\", \"'\" }, { \"&amp;\", \"&\" } };")
+	w.Writeln("    std::string sResult;")
+	w.Writeln("    size_t nPosition = 0;")
+	w.Writeln("    while (nPosition < sValue.size()) {")
+	w.Writeln("      bool bDecoded = false;")
+	w.Writeln("      if (sValue[nPosition] == '&') {")
+	w.Writeln("        for (auto & Entity : Entities) {")
+	w.Writeln("          std::string sEntity(Entity[0]);")
+	w.Writeln("          if (sValue.compare(nPosition, sEntity.size(), sEntity) == 0) {")
+	w.Writeln("            sResult += Entity[1];")
+	w.Writeln("            nPosition += sEntity.size();")
+	w.Writeln("            bDecoded = true;")
+	w.Writeln("            break;")
+	w.Writeln("          }")
+	w.Writeln("        }")
+	w.Writeln("      }")
+	w.Writeln("      if (!bDecoded) {")
+	w.Writeln("        sResult += sValue[nPosition];")
+	w.Writeln("        nPosition++;")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("    return sResult;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  // Reads the next element tag. End tags are returned with a leading \"/\".")
+	w.Writeln("  bool readTag(std::string & sTagName, std::map<std::string, std::string> & Attributes)")
+	w.Writeln("  {")
+	w.Writeln("    Attributes.clear();")
+	w.Writeln("    while (true) {")
+	w.Writeln("      size_t nStart = m_sContent.find('<', m_nPosition);")
+	w.Writeln("      if (nStart == std::string::npos)")
+	w.Writeln("        return false;")
+	w.Writeln("")
+	w.Writeln("      std::string sTerminator;")
+	w.Writeln("      if (m_sContent.compare(nStart, 4, \"<!--\") == 0)")
+	w.Writeln("        sTerminator = \"-->\";")
+	w.Writeln("      else if (m_sContent.compare(nStart, 2, \"<?\") == 0)")
+	w.Writeln("        sTerminator = \"?>\";")
+	w.Writeln("      if (!sTerminator.empty()) {")
+	w.Writeln("        size_t nEnd = m_sContent.find(sTerminator, nStart);")
+	w.Writeln("        if (nEnd == std::string::npos)")
+	w.Writeln("          throw E%sReplayError(\"unterminated journal markup\");", NameSpace)
+	w.Writeln("        m_nPosition = nEnd + sTerminator.size();")
+	w.Writeln("        continue;")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      size_t nPosition = m_sContent.find_first_of(\" \\t\\r\\n>\", nStart + 2) ;")
+	w.Writeln("      if (nPosition == std::string::npos)")
+	w.Writeln("        throw E%sReplayError(\"unterminated journal element\");", NameSpace)
+	w.Writeln("      sTagName = m_sContent.substr(nStart + 1, nPosition - nStart - 1);")
+	w.Writeln("      if ((sTagName.size() > 1) && (sTagName.back() == '/')) {")
+	w.Writeln("        sTagName.pop_back();")
+	w.Writeln("        nPosition--;")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      while (true) {")
+	w.Writeln("        nPosition = m_sContent.find_first_not_of(\" \\t\\r\\n\", nPosition);")
+	w.Writeln("        if (nPosition == std::string::npos)")
+	w.Writeln("          throw E%sReplayError(\"unterminated journal element <\" + sTagName + \">\");", NameSpace)
+	w.Writeln("        if (m_sContent[nPosition] == '/') {")
+	w.Writeln("          nPosition++;")
+	w.Writeln("          continue;")
+	w.Writeln("        }")
+	w.Writeln("        if (m_sContent[nPosition] == '>') {")
+	w.Writeln("          m_nPosition = nPosition + 1;")
+	w.Writeln("          return true;")
+	w.Writeln("        }")
+	w.Writeln("")
+	w.Writeln("        size_t nEquals = m_sContent.find('=', nPosition);")
+	w.Writeln("        if ((nEquals == std::string::npos) || (nEquals + 1 >= m_sContent.size()))")
+	w.Writeln("          throw E%sReplayError(\"invalid attribute in journal element <\" + sTagName + \">\");", NameSpace)
+	w.Writeln("        std::string sName = m_sContent.substr(nPosition, nEquals - nPosition);")
+	w.Writeln("        char cQuote = m_sContent[nEquals + 1];")
+	w.Writeln("        if ((cQuote != '\"') && (cQuote != '\\''))")
+	w.Writeln("          throw E%sReplayError(\"invalid attribute \" + sName + \" in journal element <\" + sTagName + \">\");", NameSpace)
+	w.Writeln("        size_t nValueEnd = m_sContent.find(cQuote, nEquals + 2);")
+	w.Writeln("        if (nValueEnd == std::string::npos)")
+	w.Writeln("          throw E%sReplayError(\"unterminated attribute \" + sName + \" in journal element <\" + sTagName + \">\");", NameSpace)
+	w.Writeln("        Attributes[sName] = decodeXML(m_sContent.substr(nEquals + 2, nValueEnd - nEquals - 2));")
+	w.Writeln("        nPosition = nValueEnd + 1;")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  // Reads the entries of an XML journal")
+	w.Writeln("  void readXMLEntries(std::vector<s%sReplayEntry> & Entries, std::string & sLibrary, std::string & sVersion)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    std::string sTagName;")
+	w.Writeln("    std::map<std::string, std::string> Attributes;")
+	w.Writeln("    bool bInEntry = false;")
+	w.Writeln("    bool bHasJournal = false;")
+	w.Writeln("")
+	w.Writeln("    while (readTag(sTagName, Attributes)) {")
+	w.Writeln("      if (sTagName == \"journal\") {")
+	w.Writeln("        bHasJournal = true;")
+	w.Writeln("        sLibrary = Attributes[\"library\"];")
+	w.Writeln("        sVersion = Attributes[\"version\"];")
+	w.Writeln("      }")
+	w.Writeln("      else if (sTagName == \"entry\") {")
+	w.Writeln("        if (!bHasJournal)")
+	w.Writeln("          throw E%sReplayError(\"journal element <entry> precedes the <journal> element\");", NameSpace)
+	w.Writeln("        s%sReplayEntry Entry;", NameSpace)
+	w.Writeln("        Entry.m_nIndex = Entries.size() + 1;")
+	w.Writeln("        Entry.m_sClassName = Attributes[\"class\"];")
+	w.Writeln("        Entry.m_sMethodName = Attributes[\"method\"];")
+	w.Writeln("        Entry.m_nErrorCode = %s_SUCCESS;", strings.ToUpper(NameSpace))
+	w.Writeln("        if (Attributes.count(\"errorcode\") > 0)")
+	w.Writeln("          Entry.m_nErrorCode = (%sResult) std::stol(Attributes[\"errorcode\"]);", NameSpace)
+	w.Writeln("        Entry.m_sTimeStamp = Attributes[\"timestamp\"];")
+	w.Writeln("        Entries.push_back(Entry);")
+	w.Writeln("        bInEntry = true;")
+	w.Writeln("      }")
+	w.Writeln("      else if (sTagName == \"/entry\") {")
+	w.Writeln("        bInEntry = false;")
+	w.Writeln("      }")
+	w.Writeln("      else if ((sTagName == \"instance\") || (sTagName == \"parameter\") || (sTagName == \"result\")) {")
+	w.Writeln("        if (!bInEntry)")
+	w.Writeln("          throw E%sReplayError(\"journal element <\" + sTagName + \"> is outside of an entry\");", NameSpace)
+	w.Writeln("        if (sTagName == \"instance\") {")
+	w.Writeln("          Entries.back().m_sInstanceHandle = Attributes[\"handle\"];")
+	w.Writeln("        }")
+	w.Writeln("        else {")
+	w.Writeln("          s%sReplayValue Value;", NameSpace)
+	w.Writeln("          Value.m_sName = Attributes[\"name\"];")
+	w.Writeln("          Value.m_sType = Attributes[\"type\"];")
+	w.Writeln("          Value.m_sValue = Attributes[\"value\"];")
+	w.Writeln("          if (sTagName == \"parameter\")")
+	w.Writeln("            Entries.back().m_Parameters.push_back(Value);")
+	w.Writeln("          else")
+	w.Writeln("            Entries.back().m_Results.push_back(Value);")
+	w.Writeln("        }")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("")
+	w.Writeln("    if (!bHasJournal)")
+	w.Writeln("      throw E%sReplayError(\"the file does not contain a <journal> element\");", NameSpace)
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  // Reads the entries of a JSON Lines journal. The first line names the library, every further line is one entry.")
+	w.Writeln("  void readJSONLinesEntries(std::vector<s%sReplayEntry> & Entries, std::string & sLibrary, std::string & sVersion)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    std::istringstream Stream(m_sContent);")
+	w.Writeln("    std::string sLine;")
+	w.Writeln("    bool bHasHeader = false;")
+	w.Writeln("    m_nLine = 0;")
+	w.Writeln("")
+	w.Writeln("    while (std::getline(Stream, sLine)) {")
+	w.Writeln("      m_nLine++;")
+	w.Writeln("      if (!sLine.empty() && (sLine.back() == '\\r'))")
+	w.Writeln("        sLine.pop_back();")
+	w.Writeln("      if (sLine.find_first_not_of(\" \\t\") == std::string::npos)")
+	w.Writeln("        continue;")
+	w.Writeln("")
+	w.Writeln("      std::map<std::string, std::string> Members;")
+	w.Writeln("      std::map<std::string, std::vector<s%sReplayValue>> Arrays;", NameSpace)
+	w.Writeln("      size_t nPosition = 0;")
+	w.Writeln("      readJSONObject(sLine, nPosition, Members, &Arrays);")
+	w.Writeln("      skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("      if (nPosition != sLine.size())")
+	w.Writeln("        throwJSONError(\"unexpected characters after the entry\");")
+	w.Writeln("")
+	w.Writeln("      if (!bHasHeader) {")
+	w.Writeln("        if (Members.count(\"library\") == 0)")
+	w.Writeln("          throw E%sReplayError(\"the first line of the journal does not name the library\");", NameSpace)
+	w.Writeln("        sLibrary = Members[\"library\"];")
+	w.Writeln("        sVersion = Members[\"version\"];")
+	w.Writeln("        bHasHeader = true;")
+	w.Writeln("        continue;")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      if (Members.count(\"method\") == 0)")
+	w.Writeln("        throwJSONError(\"the entry does not have a method\");")
+	w.Writeln("      s%sReplayEntry Entry;", NameSpace)
+	w.Writeln("      Entry.m_nIndex = Entries.size() + 1;")
+	w.Writeln("      Entry.m_sClassName = Members[\"class\"];")
+	w.Writeln("      Entry.m_sMethodName = Members[\"method\"];")
+	w.Writeln("      Entry.m_nErrorCode = %s_SUCCESS;", strings.ToUpper(NameSpace))
+	w.Writeln("      if (Members.count(\"errorcode\") > 0)")
+	w.Writeln("        Entry.m_nErrorCode = (%sResult) std::stol(Members[\"errorcode\"]);", NameSpace)
+	w.Writeln("      Entry.m_sTimeStamp = Members[\"timestamp\"];")
+	w.Writeln("      Entry.m_sInstanceHandle = Members[\"instance\"];")
+	w.Writeln("      Entry.m_Parameters = Arrays[\"parameters\"];")
+	w.Writeln("      Entry.m_Results = Arrays[\"results\"];")
+	w.Writeln("      Entries.push_back(Entry);")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  void throwJSONError(const std::string & sReason)")
+	w.Writeln("  {")
+	w.Writeln("    throw E%sReplayError(\"invalid journal line \" + std::to_string(m_nLine) + \": \" + sReason);", NameSpace)
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  static void skipJSONWhitespace(const std::string & sLine, size_t & nPosition)")
+	w.Writeln("  {")
+	w.Writeln("    while ((nPosition < sLine.size()) && ((sLine[nPosition] == ' ') || (sLine[nPosition] == '\\t')))")
+	w.Writeln("      nPosition++;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  void expectJSON(const std::string & sLine, size_t & nPosition, char cExpected)")
+	w.Writeln("  {")
+	w.Writeln("    skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("    if ((nPosition >= sLine.size()) || (sLine[nPosition] != cExpected))")
+	w.Writeln("      throwJSONError(std::string(\"expected '\") + cExpected + \"'\");")
+	w.Writeln("    nPosition++;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  std::string readJSONString(const std::string & sLine, size_t & nPosition)")
+	w.Writeln("  {")
+	w.Writeln("    expectJSON(sLine, nPosition, '\"');")
+	w.Writeln("    std::string sResult;")
+	w.Writeln("    while (nPosition < sLine.size()) {")
+	w.Writeln("      char cChar = sLine[nPosition++];")
+	w.Writeln("      if (cChar == '\"')")
+	w.Writeln("        return sResult;")
+	w.Writeln("      if (cChar != '\\\\') {")
+	w.Writeln("        sResult += cChar;")
+	w.Writeln("        continue;")
+	w.Writeln("      }")
+	w.Writeln("      if (nPosition >= sLine.size())")
+	w.Writeln("        break;")
+	w.Writeln("      cChar = sLine[nPosition++];")
+	w.Writeln("      switch (cChar) {")
+	w.Writeln("        case 'n': sResult += '\\n'; break;")
+	w.Writeln("        case 'r': sResult += '\\r'; break;")
+	w.Writeln("        case 't': sResult += '\\t'; break;")
+	w.Writeln("        case 'b': sResult += '\\b'; break;")
+	w.Writeln("        case 'f': sResult += '\\f'; break;")
+	w.Writeln("        case 'u': {")
+	w.Writeln("          if (nPosition + 4 > sLine.size())")
+	w.Writeln("            throwJSONError(\"invalid unicode escape\");")
+	w.Writeln("          unsigned long nCodePoint = std::stoul(sLine.substr(nPosition, 4), nullptr, 16);")
+	w.Writeln("          nPosition += 4;")
+	w.Writeln("          if (nCodePoint < 0x80) {")
+	w.Writeln("            sResult += (char) nCodePoint;")
+	w.Writeln("          }")
+	w.Writeln("          else if (nCodePoint < 0x800) {")
+	w.Writeln("            sResult += (char) (0xc0 | (nCodePoint >> 6));")
+	w.Writeln("            sResult += (char) (0x80 | (nCodePoint & 0x3f));")
+	w.Writeln("          }")
+	w.Writeln("          else {")
+	w.Writeln("            sResult += (char) (0xe0 | (nCodePoint >> 12));")
+	w.Writeln("            sResult += (char) (0x80 | ((nCodePoint >> 6) & 0x3f));")
+	w.Writeln("            sResult += (char) (0x80 | (nCodePoint & 0x3f));")
+	w.Writeln("          }")
+	w.Writeln("          break;")
+	w.Writeln("        }")
+	w.Writeln("        default:")
+	w.Writeln("          sResult += cChar;")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("    throwJSONError(\"unterminated string\");")
+	w.Writeln("    return sResult;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  // Reads a JSON object. Arrays of values are stored in pArrays, all other members are stored as string in Members.")
+	w.Writeln("  void readJSONObject(const std::string & sLine, size_t & nPosition, std::map<std::string, std::string> & Members, std::map<std::string, std::vector<s%sReplayValue>> * pArrays)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    expectJSON(sLine, nPosition, '{');")
+	w.Writeln("    skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("    if ((nPosition < sLine.size()) && (sLine[nPosition] == '}')) {")
+	w.Writeln("      nPosition++;")
+	w.Writeln("      return;")
+	w.Writeln("    }")
+	w.Writeln("")
+	w.Writeln("    while (true) {")
+	w.Writeln("      std::string sName = readJSONString(sLine, nPosition);")
+	w.Writeln("      expectJSON(sLine, nPosition, ':');")
+	w.Writeln("      skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("      if (nPosition >= sLine.size())")
+	w.Writeln("        throwJSONError(\"missing value of \" + sName);")
+	w.Writeln("")
+	w.Writeln("      if (sLine[nPosition] == '[') {")
+	w.Writeln("        if (pArrays == nullptr)")
+	w.Writeln("          throwJSONError(\"unexpected array \" + sName);")
+	w.Writeln("        nPosition++;")
+	w.Writeln("        std::vector<s%sReplayValue> & Values = (*pArrays)[sName];", NameSpace)
+	w.Writeln("        skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("        if ((nPosition < sLine.size()) && (sLine[nPosition] == ']')) {")
+	w.Writeln("          nPosition++;")
+	w.Writeln("        }")
+	w.Writeln("        else {")
+	w.Writeln("          while (true) {")
+	w.Writeln("            std::map<std::string, std::string> ValueMembers;")
+	w.Writeln("            readJSONObject(sLine, nPosition, ValueMembers, nullptr);")
+	w.Writeln("            s%sReplayValue Value;", NameSpace)
+	w.Writeln("            Value.m_sName = ValueMembers[\"name\"];")
+	w.Writeln("            Value.m_sType = ValueMembers[\"type\"];")
+	w.Writeln("            Value.m_sValue = ValueMembers[\"value\"];")
+	w.Writeln("            Values.push_back(Value);")
+	w.Writeln("            skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("            if ((nPosition < sLine.size()) && (sLine[nPosition] == ',')) {")
+	w.Writeln("              nPosition++;")
+	w.Writeln("              continue;")
+	w.Writeln("            }")
+	w.Writeln("            expectJSON(sLine, nPosition, ']');")
+	w.Writeln("            break;")
+	w.Writeln("          }")
+	w.Writeln("        }")
+	w.Writeln("      }")
+	w.Writeln("      else if (sLine[nPosition] == '\"') {")
+	w.Writeln("        Members[sName] = readJSONString(sLine, nPosition);")
+	w.Writeln("      }")
+	w.Writeln("      else {")
+	w.Writeln("        size_t nEnd = sLine.find_first_of(\",} \\t\", nPosition);")
+	w.Writeln("        if ((nEnd == std::string::npos) || (nEnd == nPosition))")
+	w.Writeln("          throwJSONError(\"invalid value of \" + sName);")
+	w.Writeln("        Members[sName] = sLine.substr(nPosition, nEnd - nPosition);")
+	w.Writeln("        nPosition = nEnd;")
+	w.Writeln("      }")
+	w.Writeln("")
+	w.Writeln("      skipJSONWhitespace(sLine, nPosition);")
+	w.Writeln("      if ((nPosition < sLine.size()) && (sLine[nPosition] == ',')) {")
+	w.Writeln("        nPosition++;")
+	w.Writeln("        continue;")
+	w.Writeln("      }")
+	w.Writeln("      expectJSON(sLine, nPosition, '}');")
+	w.Writeln("      return;")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  // Reads the entries of a binary journal. Integers are little endian, strings are prefixed with their length.")
+	w.Writeln("  void readBinaryEntries(std::vector<s%sReplayEntry> & Entries, std::string & sLibrary, std::string & sVersion)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    m_nPosition = 4;")
+	w.Writeln("    %s_uint64 nFormatVersion = readBinaryUInt(4);", NameSpace)
+	w.Writeln("    if (nFormatVersion != 1)")
+	w.Writeln("      throw E%sReplayError(\"unsupported binary journal format version \" + std::to_string(nFormatVersion));", NameSpace)
+	w.Writeln("    sLibrary = readBinaryString();")
+	w.Writeln("    sVersion = readBinaryString();")
+	w.Writeln("")
+	w.Writeln("    while (m_nPosition < m_sContent.size()) {")
+	w.Writeln("      size_t nRecordSize = (size_t) readBinaryUInt(4);")
+	w.Writeln("      size_t nRecordEnd = m_nPosition + nRecordSize;")
+	w.Writeln("      if (nRecordEnd > m_sContent.size())")
+	w.Writeln("        throw E%sReplayError(\"truncated binary journal entry \" + std::to_string(Entries.size() + 1));", NameSpace)
+	w.Writeln("")
+	w.Writeln("      s%sReplayEntry Entry;", NameSpace)
+	w.Writeln("      Entry.m_nIndex = Entries.size() + 1;")
+	w.Writeln("      Entry.m_sClassName = readBinaryString();")
+	w.Writeln("      Entry.m_sMethodName = readBinaryString();")
+	w.Writeln("      Entry.m_nErrorCode = (%sResult) (%s_int32) readBinaryUInt(4);", NameSpace, NameSpace)
+	w.Writeln("      Entry.m_sTimeStamp = std::to_string(readBinaryUInt(8));")
+	w.Writeln("      readBinaryUInt(8);")
+	w.Writeln("      %s_uint64 nInstanceHandle = readBinaryUInt(8);", NameSpace)
+	w.Writeln("      if (!Entry.m_sClassName.empty()) {")
+	w.Writeln("        std::stringstream Handle;")
+	w.Writeln("        Handle << std::setfill('0') << std::setw(sizeof(%s_uint64) * 2) << std::hex << nInstanceHandle;", NameSpace)
+	w.Writeln("        Entry.m_sInstanceHandle = Handle.str();")
+	w.Writeln("      }")
+	w.Writeln("      readBinaryValues(Entry.m_Parameters);")
+	w.Writeln("      readBinaryValues(Entry.m_Results);")
+	w.Writeln("      if (m_nPosition > nRecordEnd)")
+	w.Writeln("        throw E%sReplayError(\"invalid binary journal entry \" + std::to_string(Entry.m_nIndex));", NameSpace)
+	w.Writeln("      m_nPosition = nRecordEnd;")
+	w.Writeln("      Entries.push_back(Entry);")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  %s_uint64 readBinaryUInt(size_t nByteCount)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    if (m_nPosition + nByteCount > m_sContent.size())")
+	w.Writeln("      throw E%sReplayError(\"truncated binary journal\");", NameSpace)
+	w.Writeln("    %s_uint64 nValue = 0;", NameSpace)
+	w.Writeln("    for (size_t nIndex = 0; nIndex < nByteCount; nIndex++)")
+	w.Writeln("      nValue |= ((%s_uint64) (unsigned char) m_sContent[m_nPosition + nIndex]) << (8 * nIndex);", NameSpace)
+	w.Writeln("    m_nPosition += nByteCount;")
+	w.Writeln("    return nValue;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  std::string readBinaryString()")
+	w.Writeln("  {")
+	w.Writeln("    size_t nLength = (size_t) readBinaryUInt(4);")
+	w.Writeln("    if (m_nPosition + nLength > m_sContent.size())")
+	w.Writeln("      throw E%sReplayError(\"truncated binary journal\");", NameSpace)
+	w.Writeln("    std::string sValue = m_sContent.substr(m_nPosition, nLength);")
+	w.Writeln("    m_nPosition += nLength;")
+	w.Writeln("    return sValue;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  void readBinaryValues(std::vector<s%sReplayValue> & Values)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    size_t nCount = (size_t) readBinaryUInt(4);")
+	w.Writeln("    for (size_t nIndex = 0; nIndex < nCount; nIndex++) {")
+	w.Writeln("      s%sReplayValue Value;", NameSpace)
+	w.Writeln("      Value.m_sName = readBinaryString();")
+	w.Writeln("      Value.m_sType = readBinaryString();")
+	w.Writeln("      Value.m_sValue = readBinaryString();")
+	w.Writeln("      Values.push_back(Value);")
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("public:")
+	w.Writeln("  C%sJournalReader(const std::string & sFileName)", NameSpace)
+	w.Writeln("    : m_nPosition(0), m_nLine(0)")
+	w.Writeln("  {")
+	w.Writeln("    std::ifstream Stream(sFileName, std::ios::in | std::ios::binary);")
+	w.Writeln("    if (!Stream)")
+	w.Writeln("      throw std::runtime_error(\"could not open journal \\\"\" + sFileName + \"\\\"\");")
+	w.Writeln("    std::stringstream Content;")
+	w.Writeln("    Content << Stream.rdbuf();")
+	w.Writeln("    m_sContent = Content.str();")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  // Reads all entries of the journal. The format is detected from the content of the file.")
+	w.Writeln("  void readEntries(std::vector<s%sReplayEntry> & Entries, std::string & sLibrary, std::string & sVersion)", NameSpace)
+	w.Writeln("  {")
+	w.Writeln("    size_t nFirst = m_sContent.find_first_not_of(\" \\t\\r\\n\");")
+	w.Writeln("    if (m_sContent.compare(0, 4, \"ACTJ\") == 0)")
+	w.Writeln("      readBinaryEntries(Entries, sLibrary, sVersion);")
+	w.Writeln("    else if ((nFirst != std::string::npos) && (m_sContent[nFirst] == '{'))")
+	w.Writeln("      readJSONLinesEntries(Entries, sLibrary, sVersion);")
+	w.Writeln("    else")
+	w.Writeln("      readXMLEntries(Entries, sLibrary, sVersion);")
+	w.Writeln("")
+	w.Writeln("    if (Entries.empty())")
+	w.Writeln("      throw E%sReplayError(\"the journal does not contain any entry\");", NameSpace)
+	w.Writeln("  }")
+	w.Writeln("};")
+	w.Writeln("")
+	w.Writeln("")
+}
+
+func buildReplayCppDispatch(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+
+	w.Writeln("bool C%sReplay::replayEntry(const s%sReplayEntry & Entry)", NameSpace, NameSpace)
+	w.Writeln("{")
+	w.Writeln("  m_Divergences.clear();")
+	w.Writeln("  try {")
+	w.Writeln("    if (Entry.m_sClassName.empty()) {")
+	buildReplayCppMethodDispatch(w, component.Global.Methods, "replayGlobal_",
+		fmt.Sprintf("E%sReplayError(\"%s does not have a global method \" + Entry.m_sMethodName)", NameSpace, NameSpace))
+	w.Writeln("    }")
+	for _, class := range component.Classes {
+		w.Writeln("    else if (Entry.m_sClassName == \"%s\") {", class.ClassName)
+		buildReplayCppMethodDispatch(w, class.Methods, "replay"+class.ClassName+"_",
+			fmt.Sprintf("E%sReplayError(\"class %s does not have a method \" + Entry.m_sMethodName)", NameSpace, class.ClassName))
+		w.Writeln("    }")
+	}
+	w.Writeln("    else {")
+	w.Writeln("      throw E%sReplayError(\"%s does not have a class \" + Entry.m_sClassName);", NameSpace, NameSpace)
+	w.Writeln("    }")
+	w.Writeln("  }")
+	w.Writeln("  catch (E%sReplayError & Error) {", NameSpace)
+	w.Writeln("    m_Divergences.push_back(Error.what());")
+	w.Writeln("  }")
+	w.Writeln("  catch (std::logic_error & Error) {")
+	w.Writeln("    m_Divergences.push_back(std::string(\"invalid value in the journal: \") + Error.what());")
+	w.Writeln("  }")
+	w.Writeln("  return m_Divergences.empty();")
+	w.Writeln("}")
+	w.Writeln("")
+}
+
+func buildReplayCppMethodDispatch(w LanguageWriter, methods []ComponentDefinitionMethod, functionPrefix string, unknownMethodError string) {
+	for i, method := range methods {
+		keyword := "if"
+		if i > 0 {
+			keyword = "else if"
+		}
+		w.Writeln("      %s (Entry.m_sMethodName == \"%s\")", keyword, method.MethodName)
+		w.Writeln("        %s%s(Entry);", functionPrefix, method.MethodName)
+	}
+	if len(methods) > 0 {
+		w.Writeln("      else")
+		w.Writeln("        throw %s;", unknownMethodError)
+	} else {
+		w.Writeln("      throw %s;", unknownMethodError)
+	}
+}
+
+// getReplayCppNotReplayableReason returns why a method cannot be replayed from the journal, or ""
+func getReplayCppNotReplayableReason(method ComponentDefinitionMethod, isSpecialFunction int) string {
+	switch isSpecialFunction {
+	case eSpecialMethodInjection, eSpecialMethodSymbolLookup:
+		return "the method exchanges function pointers between libraries"
+	}
+	for _, param := range method.Params {
+		if param.ParamPass != "in" {
+			continue
+		}
+		switch param.ParamType {
+		case "struct", "basicarray", "structarray", "functiontype":
+			return fmt.Sprintf("the journal does not record the %s parameter %s", param.ParamType, param.ParamName)
+		}
+	}
+	return ""
+}
+
+func buildReplayCppMethod(component ComponentDefinition, w LanguageWriter, ClassName string, method ComponentDefinitionMethod, isSpecialFunction int) error {
+	NameSpace := component.NameSpace
+	upperNameSpace := strings.ToUpper(NameSpace)
+
+	tableEntryName := "m_" + method.MethodName
+	if ClassName == "" {
+		w.Writeln("void C%sReplay::replayGlobal_%s(const s%sReplayEntry & Entry)", NameSpace, method.MethodName, NameSpace)
+	} else {
+		tableEntryName = "m_" + ClassName + "_" + method.MethodName
+		w.Writeln("void C%sReplay::replay%s_%s(const s%sReplayEntry & Entry)", NameSpace, ClassName, method.MethodName, NameSpace)
+	}
+	w.Writeln("{")
+
+	if isSpecialFunction == eSpecialMethodJournal {
+		w.Writeln("  // The journal only records this call when journaling is turned off.")
+		w.Writeln("  (void) Entry;")
+		w.Writeln("}")
+		w.Writeln("")
+		return nil
+	}
+	reason := getReplayCppNotReplayableReason(method, isSpecialFunction)
+	if reason != "" {
+		w.Writeln("  skipEntry(Entry, \"%s\");", reason)
+		w.Writeln("}")
+		w.Writeln("")
+		return nil
+	}
+
+	callParameters := make([]string, 0)
+	checkResultCode := make([]string, 0)
+	if (ClassName != "") && !method.IsStatic {
+		w.Writeln("  %s_%s pClassInstance = (%s_%s) getHandle(Entry.m_sInstanceHandle);", NameSpace, ClassName, NameSpace, ClassName)
+		callParameters = append(callParameters, "pClassInstance")
+	}
+
+	for _, param := range method.Params {
+		cParamTypeName, err := getCParameterTypeName(param.ParamType, NameSpace, param.ParamClass)
+		if err != nil {
+			return err
+		}
+		variableName := getCppVariableName(param)
+
+		if param.ParamPass == "in" {
+			valueCode := fmt.Sprintf("getParameter(Entry, \"%s\").m_sValue", param.ParamName)
+			switch param.ParamType {
+			case "bool":
+				w.Writeln("  bool %s = (%s != \"0\");", variableName, valueCode)
+			case "uint8", "uint16", "uint32":
+				w.Writeln("  %s %s = (%s) std::stoul(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "uint64":
+				w.Writeln("  %s %s = (%s) std::stoull(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "int8", "int16", "int32":
+				w.Writeln("  %s %s = (%s) std::stol(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "int64":
+				w.Writeln("  %s %s = (%s) std::stoll(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "single", "double":
+				w.Writeln("  %s %s = (%s) std::stod(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "pointer":
+				w.Writeln("  %s %s = (%s) (uintptr_t) std::stoull(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "enum":
+				w.Writeln("  %s %s = (%s) std::stol(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			case "string":
+				w.Writeln("  const s%sReplayValue & Parameter%s = getParameter(Entry, \"%s\");", NameSpace, param.ParamName, param.ParamName)
+				w.Writeln("  const char * p%s = (Parameter%s.m_sType == \"nullstring\") ? nullptr : Parameter%s.m_sValue.c_str();", param.ParamName, param.ParamName, param.ParamName)
+				variableName = "p" + param.ParamName
+			case "class", "optionalclass":
+				w.Writeln("  %s %s = (%s) getHandle(%s);", cParamTypeName, variableName, cParamTypeName, valueCode)
+			default:
+				return fmt.Errorf("invalid method parameter type \"%s\" for %s.%s(%s)", param.ParamType, ClassName, method.MethodName, param.ParamName)
+			}
+			callParameters = append(callParameters, variableName)
+			continue
+		}
+
+		switch param.ParamType {
+		case "bool":
+			w.Writeln("  bool %s = false;", variableName)
+			callParameters = append(callParameters, "&"+variableName)
+			checkResultCode = append(checkResultCode, fmt.Sprintf("checkResult(Entry, \"%s\", std::to_string((int) %s));", param.ParamName, variableName))
+		case "uint8", "uint16", "uint32", "uint64", "int8", "int16", "int32", "int64", "single", "double":
+			w.Writeln("  %s %s = 0;", cParamTypeName, variableName)
+			callParameters = append(callParameters, "&"+variableName)
+			checkResultCode = append(checkResultCode, fmt.Sprintf("checkResult(Entry, \"%s\", std::to_string(%s));", param.ParamName, variableName))
+		case "enum":
+			w.Writeln("  %s %s = (%s) 0;", cParamTypeName, variableName, cParamTypeName)
+			callParameters = append(callParameters, "&"+variableName)
+			checkResultCode = append(checkResultCode, fmt.Sprintf("checkResult(Entry, \"%s\", std::to_string((%s_int32) %s));", param.ParamName, NameSpace, variableName))
+		case "pointer":
+			// Pointers are addresses in the recorded process and cannot be compared
+			w.Writeln("  %s %s = nullptr;", cParamTypeName, variableName)
+			callParameters = append(callParameters, "&"+variableName)
+		case "string":
+			// The buffer is sized after the recorded result, so that the call is issued only once
+			w.Writeln("  const s%sReplayValue * pRecorded%s = findResult(Entry, \"%s\");", NameSpace, param.ParamName, param.ParamName)
+			w.Writeln("  std::vector<char> Buffer%s((pRecorded%s != nullptr) ? pRecorded%s->m_sValue.size() + 1 : 0);", param.ParamName, param.ParamName, param.ParamName)
+			w.Writeln("  %s_uint32 n%sNeededChars = 0;", NameSpace, param.ParamName)
+			callParameters = append(callParameters, fmt.Sprintf("(%s_uint32) Buffer%s.size(), &n%sNeededChars, Buffer%s.empty() ? nullptr : Buffer%s.data()", NameSpace, param.ParamName, param.ParamName, param.ParamName, param.ParamName))
+			checkResultCode = append(checkResultCode, fmt.Sprintf("if (!Buffer%s.empty())", param.ParamName))
+			checkResultCode = append(checkResultCode, fmt.Sprintf("  checkResult(Entry, \"%s\", std::string(Buffer%s.data()));", param.ParamName, param.ParamName))
+		case "struct":
+			structTypeName := strings.TrimSuffix(cParamTypeName, " *")
+			w.Writeln("  %s %s = {};", structTypeName, variableName)
+			callParameters = append(callParameters, "&"+variableName)
+		case "basicarray", "structarray":
+			// Arrays are not recorded in the journal, so only their size is queried
+			w.Writeln("  %s_uint64 n%sNeededCount = 0;", NameSpace, param.ParamName)
+			callParameters = append(callParameters, fmt.Sprintf("0, &n%sNeededCount, nullptr", param.ParamName))
+		case "class", "optionalclass":
+			w.Writeln("  %s %s = nullptr;", cParamTypeName, variableName)
+			callParameters = append(callParameters, "&"+variableName)
+			checkResultCode = append(checkResultCode, fmt.Sprintf("checkHandleResult(Entry, \"%s\", %s);", param.ParamName, variableName))
+		default:
+			return fmt.Errorf("invalid method parameter type \"%s\" for %s.%s(%s)", param.ParamType, ClassName, method.MethodName, param.ParamName)
+		}
+	}
+
+	w.Writeln("")
+	w.Writeln("  %sResult nErrorCode = m_WrapperTable.%s(%s);", NameSpace, tableEntryName, strings.Join(callParameters, ", "))
+	if len(checkResultCode) > 0 {
+		w.Writeln("  if (checkErrorCode(Entry, nErrorCode) && (nErrorCode == %s_SUCCESS)) {", upperNameSpace)
+		w.Writelns("    ", checkResultCode)
+		w.Writeln("  }")
+	} else {
+		w.Writeln("  checkErrorCode(Entry, nErrorCode);")
+	}
+	w.Writeln("}")
+	w.Writeln("")
+	return nil
+}
+
+func buildReplayCppMain(component ComponentDefinition, w LanguageWriter) {
+	NameSpace := component.NameSpace
+
+	w.Writeln("")
+	w.Writeln("int main(int argc, char ** argv)")
+	w.Writeln("{")
+	w.Writeln("  if (argc != 3) {")
+	w.Writeln("    std::cerr << \"Usage: %s_Replay LIBRARY_FILE JOURNAL_FILE\" << std::endl;", NameSpace)
+	w.Writeln("    return 2;")
+	w.Writeln("  }")
+	w.Writeln("")
+	w.Writeln("  try {")
+	w.Writeln("    std::vector<s%sReplayEntry> Entries;", NameSpace)
+	w.Writeln("    std::string sLibrary;")
+	w.Writeln("    std::string sVersion;")
+	w.Writeln("    C%sJournalReader Reader(argv[2]);", NameSpace)
+	w.Writeln("    Reader.readEntries(Entries, sLibrary, sVersion);")
+	w.Writeln("    if (sLibrary != \"%s\")", NameSpace)
+	w.Writeln("      std::cerr << \"Warning: the journal has been recorded for library \\\"\" << sLibrary << \"\\\"\" << std::endl;")
+	w.Writeln("    if (sVersion != \"%s\")", component.Version)
+	w.Writeln("      std::cerr << \"Warning: the journal has been recorded with version \" << sVersion << \", this replay is generated for version %s\" << std::endl;", component.Version)
+	w.Writeln("")
+	w.Writeln("    C%sReplay Replay(argv[1]);", NameSpace)
+	w.Writeln("    for (auto & Entry : Entries) {")
+	w.Writeln("      if (!Replay.replayEntry(Entry)) {")
+	w.Writeln("        std::cout << \"Call \" << Entry.m_nIndex << \" (\" << Entry.getCallName() << \" at timestamp \" << Entry.m_sTimeStamp << \") diverges from the journal:\" << std::endl;")
+	w.Writeln("        for (auto & sDivergence : Replay.getDivergences())")
+	w.Writeln("          std::cout << \"  \" << sDivergence << std::endl;")
+	w.Writeln("        return 1;")
+	w.Writeln("      }")
+	w.Writeln("    }")
+	w.Writeln("    std::cout << \"Replayed \" << Entries.size() << \" calls (\" << Replay.getSkippedEntryCount() << \" skipped) without divergence\" << std::endl;")
+	w.Writeln("  }")
+	w.Writeln("  catch (std::exception & Error) {")
+	w.Writeln("    std::cerr << \"Error: \" << Error.what() << std::endl;")
+	w.Writeln("    return 2;")
+	w.Writeln("  }")
+	w.Writeln("  return 0;")
+	w.Writeln("}")
+	w.Writeln("")
+}
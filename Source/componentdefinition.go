@@ -43,6 +43,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -60,39 +61,98 @@ const (
 	eSpecialMethodBuildinfo    = 9
 )
 
+// ComponentDefinitionDeprecation marks an element as deprecated since a version of the component,
+// optionally naming the element that replaces it
+type ComponentDefinitionDeprecation struct {
+	Deprecated  string `xml:"deprecated,attr,omitempty"`
+	Replacement string `xml:"replacement,attr,omitempty"`
+}
+
+// IsDeprecated returns true if the element is deprecated
+func (deprecation ComponentDefinitionDeprecation) IsDeprecated() bool {
+	return deprecation.Deprecated != ""
+}
+
+// DeprecationNotice returns the notice that documents a deprecated element next to its declaration
+func (deprecation ComponentDefinitionDeprecation) DeprecationNotice() string {
+	if deprecation.Replacement != "" {
+		return fmt.Sprintf("Deprecated since %s, use %s instead", deprecation.Deprecated, deprecation.Replacement)
+	}
+	return fmt.Sprintf("Deprecated since %s", deprecation.Deprecated)
+}
+
+// DeprecationMessage returns the message that warns users of a deprecated element, which is named by its
+// qualified name, e.g. "Calculator.Calculate"
+func (deprecation ComponentDefinitionDeprecation) DeprecationMessage(name string) string {
+	if deprecation.Replacement != "" {
+		return fmt.Sprintf("%s is deprecated since %s, use %s instead", name, deprecation.Deprecated, deprecation.Replacement)
+	}
+	return fmt.Sprintf("%s is deprecated since %s", name, deprecation.Deprecated)
+}
+
 // ComponentDefinitionParam definition of a method parameter used in the component's API
 type ComponentDefinitionParam struct {
 	ComponentDiffableElement
-	XMLName          xml.Name `xml:"param"`
-	ParamName        string   `xml:"name,attr"`
-	ParamType        string   `xml:"type,attr"`
-	ParamPass        string   `xml:"pass,attr"`
-	ParamClass       string   `xml:"class,attr"`
-	ParamDescription string   `xml:"description,attr"`
+	ComponentDefinitionDeprecation
+	Location         SourceLocation `xml:"-"`
+	XMLName          xml.Name       `xml:"param"`
+	ParamName        string         `xml:"name,attr"`
+	ParamType        string         `xml:"type,attr"`
+	ParamPass        string         `xml:"pass,attr"`
+	ParamClass       string         `xml:"class,attr"`
+	ParamDescription string         `xml:"description,attr"`
+	ParamDefault     *string        `xml:"default,attr"`
 }
 
 // ComponentDefinitionMethod definition of a method provided by the component's API
 type ComponentDefinitionMethod struct {
 	ComponentDiffableElement
+	ComponentDefinitionDeprecation
+	Location          SourceLocation             `xml:"-"`
 	XMLName           xml.Name                   `xml:"method"`
 	MethodName        string                     `xml:"name,attr"`
 	MethodDescription string                     `xml:"description,attr"`
+	IsStatic          bool                       `xml:"static,attr,omitempty"`
 	Params            []ComponentDefinitionParam `xml:"param"`
+	PropertyName      string                     `xml:"-"`
+}
+
+// Access modes of a property
+const (
+	ePropertyAccessRead      = "read"
+	ePropertyAccessReadWrite = "readwrite"
+)
+
+// ComponentDefinitionProperty definition of a property of a class. Normalize expands it into
+// the methods Get<Name> and, unless it is read-only, Set<Name>.
+type ComponentDefinitionProperty struct {
+	ComponentDiffableElement
+	Location    SourceLocation `xml:"-"`
+	XMLName     xml.Name       `xml:"property"`
+	Name        string         `xml:"name,attr"`
+	Type        string         `xml:"type,attr"`
+	Class       string         `xml:"class,attr"`
+	Access      string         `xml:"access,attr"`
+	Description string         `xml:"description,attr"`
 }
 
 // ComponentDefinitionClass definition of a class provided by the component's API
 type ComponentDefinitionClass struct {
 	ComponentDiffableElement
-	XMLName          xml.Name                    `xml:"class"`
-	ClassName        string                      `xml:"name,attr"`
-	ClassDescription string                      `xml:"description,attr"`
-	ParentClass      string                      `xml:"parent,attr"`
-	Methods          []ComponentDefinitionMethod `xml:"method"`
+	ComponentDefinitionDeprecation
+	Location         SourceLocation                `xml:"-"`
+	XMLName          xml.Name                      `xml:"class"`
+	ClassName        string                        `xml:"name,attr"`
+	ClassDescription string                        `xml:"description,attr"`
+	ParentClass      string                        `xml:"parent,attr"`
+	Methods          []ComponentDefinitionMethod   `xml:"method"`
+	Properties       []ComponentDefinitionProperty `xml:"property"`
 }
 
 // ComponentDefinitionFunctionType definition of a function interface provided by the component's API
 type ComponentDefinitionFunctionType struct {
 	ComponentDiffableElement
+	Location            SourceLocation             `xml:"-"`
 	XMLName             xml.Name                   `xml:"functiontype"`
 	FunctionName        string                     `xml:"name,attr"`
 	FunctionDescription string                     `xml:"description,attr"`
@@ -102,18 +162,21 @@ type ComponentDefinitionFunctionType struct {
 // ComponentDefinitionBindingList definition of the language bindings to be generated for the component's API
 type ComponentDefinitionBindingList struct {
 	ComponentDiffableElement
+	Location SourceLocation               `xml:"-"`
 	Bindings []ComponentDefinitionBinding `xml:"binding"`
 }
 
 // ComponentDefinitionImplementationList definition of the implementation interfaces or stubs to be generated for the component's API
 type ComponentDefinitionImplementationList struct {
 	ComponentDiffableElement
+	Location        SourceLocation                      `xml:"-"`
 	Implementations []ComponentDefinitionImplementation `xml:"implementation"`
 }
 
 // ComponentDefinitionGlobal definition of global functions provided the component's API
 type ComponentDefinitionGlobal struct {
 	ComponentDiffableElement
+	Location           SourceLocation              `xml:"-"`
 	XMLName            xml.Name                    `xml:"global"`
 	BaseClassName      string                      `xml:"baseclassname,attr"`
 	ErrorMethod        string                      `xml:"errormethod,attr"`
@@ -131,97 +194,127 @@ type ComponentDefinitionGlobal struct {
 // ComponentDefinitionBinding definition of a specific languages for which bindings to the component's API will be generated
 type ComponentDefinitionBinding struct {
 	ComponentDiffableElement
-	XMLName         xml.Name `xml:"binding"`
-	Language        string   `xml:"language,attr"`
-	Indentation     string   `xml:"indentation,attr"`
-	ClassIdentifier string   `xml:"classidentifier,attr"`
+	Location        SourceLocation `xml:"-"`
+	XMLName         xml.Name       `xml:"binding"`
+	Language        string         `xml:"language,attr"`
+	Indentation     string         `xml:"indentation,attr"`
+	ClassIdentifier string         `xml:"classidentifier,attr"`
 }
 
 // ComponentDefinitionImplementation definition of a specific languages for which bindings to the component's API will be generated
 type ComponentDefinitionImplementation struct {
 	ComponentDiffableElement
-	XMLName         xml.Name `xml:"implementation"`
-	Language        string   `xml:"language,attr"`
-	Indentation     string   `xml:"indentation,attr"`
-	ClassIdentifier string   `xml:"classidentifier,attr"`
-	StubIdentifier  string   `xml:"stubidentifier,attr"`
+	Location        SourceLocation `xml:"-"`
+	XMLName         xml.Name       `xml:"implementation"`
+	Language        string         `xml:"language,attr"`
+	Indentation     string         `xml:"indentation,attr"`
+	ClassIdentifier string         `xml:"classidentifier,attr"`
+	StubIdentifier  string         `xml:"stubidentifier,attr"`
 }
 
 // ComponentDefinitionEnumOption definition of an enum used in the component's API
 type ComponentDefinitionEnumOption struct {
 	ComponentDiffableElement
-	XMLName xml.Name `xml:"option"`
-	Name    string   `xml:"name,attr"`
-	Value   int      `xml:"value,attr"`
+	ComponentDefinitionDeprecation
+	Location    SourceLocation `xml:"-"`
+	XMLName     xml.Name       `xml:"option"`
+	Name        string         `xml:"name,attr"`
+	Value       int            `xml:"value,attr"`
+	Description string         `xml:"description,attr"`
 }
 
 // ComponentDefinitionEnum definition of all enums used in the component's API
 type ComponentDefinitionEnum struct {
 	ComponentDiffableElement
-	XMLName xml.Name                        `xml:"enum"`
-	Name    string                          `xml:"name,attr"`
-	Options []ComponentDefinitionEnumOption `xml:"option"`
+	ComponentDefinitionDeprecation
+	Location    SourceLocation                  `xml:"-"`
+	XMLName     xml.Name                        `xml:"enum"`
+	Name        string                          `xml:"name,attr"`
+	Description string                          `xml:"description,attr"`
+	Options     []ComponentDefinitionEnumOption `xml:"option"`
 }
 
 // ComponentDefinitionError definition of an error used in the component's API
 type ComponentDefinitionError struct {
 	ComponentDiffableElement
-	XMLName     xml.Name `xml:"error"`
-	Name        string   `xml:"name,attr"`
-	Code        int      `xml:"code,attr"`
-	Description string   `xml:"description,attr"`
+	ComponentDefinitionDeprecation
+	Location    SourceLocation `xml:"-"`
+	XMLName     xml.Name       `xml:"error"`
+	Name        string         `xml:"name,attr"`
+	Code        int            `xml:"code,attr"`
+	Description string         `xml:"description,attr"`
+}
+
+// ComponentDefinitionConstant definition of a named constant in the component's API
+type ComponentDefinitionConstant struct {
+	ComponentDiffableElement
+	Location    SourceLocation `xml:"-"`
+	XMLName     xml.Name       `xml:"constant"`
+	Name        string         `xml:"name,attr"`
+	Type        string         `xml:"type,attr"`
+	Value       string         `xml:"value,attr"`
+	Description string         `xml:"description,attr"`
 }
 
 // ComponentDefinitionErrors definition of errors in the component's API
 type ComponentDefinitionErrors struct {
 	ComponentDiffableElement
-	XMLName xml.Name                   `xml:"errors"`
-	Errors  []ComponentDefinitionError `xml:"error"`
+	Location SourceLocation             `xml:"-"`
+	XMLName  xml.Name                   `xml:"errors"`
+	Errors   []ComponentDefinitionError `xml:"error"`
 }
 
 // ComponentDefinitionImportComponent definition of errors in the component's API
 type ComponentDefinitionImportComponent struct {
 	ComponentDiffableElement
-	XMLName   xml.Name `xml:"importcomponent"`
-	URI       string   `xml:"uri,attr"`
-	Namespace string   `xml:"namespace,attr"`
+	Location  SourceLocation `xml:"-"`
+	XMLName   xml.Name       `xml:"importcomponent"`
+	URI       string         `xml:"uri,attr"`
+	Namespace string         `xml:"namespace,attr"`
 }
 
 // ComponentDefinitionMember definition of a single struct provided by the component's API
 type ComponentDefinitionMember struct {
 	ComponentDiffableElement
-	XMLName xml.Name `xml:"member"`
-	Name    string   `xml:"name,attr"`
-	Type    string   `xml:"type,attr"`
-	Class   string   `xml:"class,attr"`
-	Rows    int      `xml:"rows,attr"`
-	Columns int      `xml:"columns,attr"`
+	Location    SourceLocation `xml:"-"`
+	XMLName     xml.Name       `xml:"member"`
+	Name        string         `xml:"name,attr"`
+	Type        string         `xml:"type,attr"`
+	Class       string         `xml:"class,attr"`
+	Rows        int            `xml:"rows,attr,omitempty"`
+	Columns     int            `xml:"columns,attr,omitempty"`
+	Description string         `xml:"description,attr"`
 }
 
 // ComponentDefinitionStruct definition of all structs provided by the component's API
 type ComponentDefinitionStruct struct {
 	ComponentDiffableElement
-	XMLName xml.Name                    `xml:"struct"`
-	Name    string                      `xml:"name,attr"`
-	Members []ComponentDefinitionMember `xml:"member"`
+	Location    SourceLocation              `xml:"-"`
+	XMLName     xml.Name                    `xml:"struct"`
+	Name        string                      `xml:"name,attr"`
+	Description string                      `xml:"description,attr"`
+	Members     []ComponentDefinitionMember `xml:"member"`
 }
 
 // ComponentDefinitionLicenseLine a single line of the component's license
 type ComponentDefinitionLicenseLine struct {
 	ComponentDiffableElement
-	XMLName xml.Name `xml:"line"`
-	Value   string   `xml:"value,attr"`
+	Location SourceLocation `xml:"-"`
+	XMLName  xml.Name       `xml:"line"`
+	Value    string         `xml:"value,attr"`
 }
 
 // ComponentDefinitionLicense the component's license
 type ComponentDefinitionLicense struct {
 	ComponentDiffableElement
-	XMLName xml.Name                         `xml:"license"`
-	Lines   []ComponentDefinitionLicenseLine `xml:"line"`
+	Location SourceLocation                   `xml:"-"`
+	XMLName  xml.Name                         `xml:"license"`
+	Lines    []ComponentDefinitionLicenseLine `xml:"line"`
 }
 
 // ComponentDefinition the complete definition of the component's API
 type ComponentDefinition struct {
+	Location           SourceLocation `xml:"-"`
 	ACTVersion         string
 	XMLName            xml.Name                              `xml:"component"`
 	Version            string                                `xml:"version,attr"`
@@ -231,15 +324,16 @@ type ComponentDefinition struct {
 	LibraryName        string                                `xml:"libraryname,attr"`
 	BaseName           string                                `xml:"basename,attr"`
 	License            ComponentDefinitionLicense            `xml:"license"`
-	Classes            []ComponentDefinitionClass            `xml:"class"`
-	Functions          []ComponentDefinitionFunctionType     `xml:"functiontype"`
 	BindingList        ComponentDefinitionBindingList        `xml:"bindings"`
 	ImplementationList ComponentDefinitionImplementationList `xml:"implementations"`
+	ImportComponents   []ComponentDefinitionImportComponent  `xml:"importcomponent"`
+	Errors             ComponentDefinitionErrors             `xml:"errors"`
+	Constants          []ComponentDefinitionConstant         `xml:"constant"`
 	Enums              []ComponentDefinitionEnum             `xml:"enum"`
 	Structs            []ComponentDefinitionStruct           `xml:"struct"`
+	Functions          []ComponentDefinitionFunctionType     `xml:"functiontype"`
+	Classes            []ComponentDefinitionClass            `xml:"class"`
 	Global             ComponentDefinitionGlobal             `xml:"global"`
-	Errors             ComponentDefinitionErrors             `xml:"errors"`
-	ImportComponents   []ComponentDefinitionImportComponent  `xml:"importcomponent"`
 
 	ImportedComponentDefinitions map[string]ComponentDefinition
 	NameMapsLookup               NameMaps
@@ -266,11 +360,91 @@ func (global *ComponentDefinitionGlobal) Normalize() {
 
 // Normalize adds default values, changes deprecated constants to their later versions
 func (class *ComponentDefinitionClass) Normalize() {
+	// Imported components are normalized more than once, so the accessors of properties are replaced
+	methods := make([]ComponentDefinitionMethod, 0, len(class.Methods))
+	for _, method := range class.Methods {
+		if method.PropertyName == "" {
+			methods = append(methods, method)
+		}
+	}
+	for i := 0; i < len(class.Properties); i++ {
+		class.Properties[i].Normalize()
+		methods = append(methods, class.Properties[i].accessorMethods()...)
+	}
+	class.Methods = methods
+
 	for i := 0; i < len(class.Methods); i++ {
 		class.Methods[i].Normalize()
 	}
 }
 
+// Normalize adds the default access and changes the deprecated type "handle" to "class", like for parameters
+func (property *ComponentDefinitionProperty) Normalize() {
+	if property.Access == "" {
+		property.Access = ePropertyAccessReadWrite
+	}
+	if property.Type == "handle" {
+		property.Type = "class"
+	}
+}
+
+// GetterName returns the name of the method that reads a property
+func (property *ComponentDefinitionProperty) GetterName() string {
+	return "Get" + property.Name
+}
+
+// SetterName returns the name of the method that writes a property
+func (property *ComponentDefinitionProperty) SetterName() string {
+	return "Set" + property.Name
+}
+
+// IsReadOnly returns true if a property has no setter
+func (property *ComponentDefinitionProperty) IsReadOnly() bool {
+	return property.Access == ePropertyAccessRead
+}
+
+// valueParam returns the parameter that carries the value of a property in its accessor methods
+func (property *ComponentDefinitionProperty) valueParam(paramPass string) ComponentDefinitionParam {
+	return ComponentDefinitionParam{
+		Location:         property.Location,
+		ParamName:        property.Name,
+		ParamType:        property.Type,
+		ParamClass:       property.Class,
+		ParamPass:        paramPass,
+		ParamDescription: property.Description,
+	}
+}
+
+// Getter returns the method that reads a property
+func (property *ComponentDefinitionProperty) Getter() ComponentDefinitionMethod {
+	return ComponentDefinitionMethod{
+		Location:          property.Location,
+		MethodName:        property.GetterName(),
+		MethodDescription: "Returns the value of property " + property.Name,
+		Params:            []ComponentDefinitionParam{property.valueParam("return")},
+		PropertyName:      property.Name,
+	}
+}
+
+// Setter returns the method that writes a property
+func (property *ComponentDefinitionProperty) Setter() ComponentDefinitionMethod {
+	return ComponentDefinitionMethod{
+		Location:          property.Location,
+		MethodName:        property.SetterName(),
+		MethodDescription: "Sets the value of property " + property.Name,
+		Params:            []ComponentDefinitionParam{property.valueParam("in")},
+		PropertyName:      property.Name,
+	}
+}
+
+// accessorMethods returns the getter and, unless the property is read-only, the setter of a property
+func (property *ComponentDefinitionProperty) accessorMethods() []ComponentDefinitionMethod {
+	if property.IsReadOnly() {
+		return []ComponentDefinitionMethod{property.Getter()}
+	}
+	return []ComponentDefinitionMethod{property.Getter(), property.Setter()}
+}
+
 // Normalize adds default values, changes deprecated constants to their later versions
 func (method *ComponentDefinitionMethod) Normalize() {
 	for i := 0; i < len(method.Params); i++ {
@@ -285,11 +459,59 @@ func (param *ComponentDefinitionParam) Normalize() {
 	}
 }
 
+// HasDefault returns true if the parameter declares a default, which may be the empty string
+func (param ComponentDefinitionParam) HasDefault() bool {
+	return param.ParamDefault != nil
+}
+
+// DefaultValue returns the default of the parameter, or "" if it does not declare one
+func (param ComponentDefinitionParam) DefaultValue() string {
+	if param.ParamDefault == nil {
+		return ""
+	}
+	return *param.ParamDefault
+}
+
+// readDocument reads a single XML, JSON or YAML IDL file without resolving its imports
+func (component *ComponentDefinition) readDocument(FileName string) error {
+	file, err := os.Open(FileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	bytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	format := idlFormatOfFile(FileName)
+	if format != eIDLFormatXML {
+		return readComponentDefinitionDocument(FileName, bytes, format, component)
+	}
+
+	err = xml.Unmarshal(bytes, component)
+	if err != nil {
+		if syntaxError, ok := err.(*xml.SyntaxError); ok {
+			return ComponentDiagnostic{Severity: eDiagnosticSeverityError, FileName: FileName, Line: syntaxError.Line, Message: syntaxError.Msg}
+		}
+		return ComponentDiagnostic{Severity: eDiagnosticSeverityError, FileName: FileName, Message: err.Error()}
+	}
+
+	elementTree, err := readXMLElementTree(FileName, bytes)
+	if err != nil {
+		return err
+	}
+	component.setSourceLocations(FileName, elementTree)
+	return nil
+}
+
 // ReadComponentDefinition reads a ComponentDefinition from a file
 func ReadComponentDefinition(FileName string, ACTVersion string) (ComponentDefinition, error) {
 	var component ComponentDefinition
 	component.ImportedComponentDefinitions = make(map[string]ComponentDefinition, 0)
 	component.NameMapsLookup = NameMaps{
+		constantMap:     make(map[string]bool, 0),
 		enumMap:         make(map[string]bool, 0),
 		structMap:       make(map[string]bool, 0),
 		classMap:        make(map[string]bool, 0),
@@ -302,21 +524,11 @@ func ReadComponentDefinition(FileName string, ACTVersion string) (ComponentDefin
 	}
 	directory := filepath.Dir(absFileName)
 
-	file, err := os.Open(FileName)
-	if err != nil {
-		return component, err
-	}
-
-	bytes, err := ioutil.ReadAll(file)
+	err = component.readDocument(FileName)
 	if err != nil {
 		return component, err
 	}
-
 	component.ACTVersion = ACTVersion
-	err = xml.Unmarshal(bytes, &component)
-	if err != nil {
-		return component, err
-	}
 
 	for i := 0; i < len(component.ImportComponents); i++ {
 		importComponent := component.ImportComponents[i]
@@ -327,7 +539,8 @@ func ReadComponentDefinition(FileName string, ACTVersion string) (ComponentDefin
 			return component, err
 		}
 		if subComponent.NameSpace != importComponent.Namespace {
-			return component, fmt.Errorf("Namespace of imported component \"%s\" does not match declared namespace \"%s\"", importComponent.Namespace, subComponent.NameSpace)
+			return component, ComponentDiagnostic{Severity: eDiagnosticSeverityError, FileName: importComponent.Location.FileName, Line: importComponent.Location.Line, Column: importComponent.Location.Column,
+				Message: fmt.Sprintf("Namespace of imported component \"%s\" does not match declared namespace \"%s\"", importComponent.Namespace, subComponent.NameSpace)}
 		}
 		component.ImportedComponentDefinitions[importComponent.Namespace] = subComponent
 	}
@@ -359,26 +572,75 @@ func getIndentationString(str string) string {
 	return indentString
 }
 
-func (component *ComponentDefinition) checkImplementations() error {
+func indentationIsValid(str string) bool {
+	var IsValidIndentation = regexp.MustCompile("^(tabs|[1-8]spaces)$").MatchString
+	return IsValidIndentation(str)
+}
+
+func bindingLanguageIsSupported(language string) bool {
+	switch language {
+	case "C", "CDynamic", "Cpp", "CppDynamic", "Go", "Node", "Pascal", "CSharp", "Python", "Rust", "Java", "Fortran":
+		return true
+	}
+	return false
+}
+
+func implementationLanguageIsSupported(language string) bool {
+	switch language {
+	case "Cpp", "Pascal", "Fortran":
+		return true
+	}
+	return false
+}
+
+func (component *ComponentDefinition) checkBindings(validation *ComponentValidation) {
+	bindings := component.BindingList.Bindings
+	for i := 0; i < len(bindings); i++ {
+		binding := bindings[i]
+
+		if !bindingLanguageIsSupported(binding.Language) {
+			validation.addError(binding.Location, "unknown binding language \"%s\"", binding.Language)
+		} else if binding.Language == "Fortran" {
+			validation.addWarning(binding.Location, "interface binding for language \"%s\" is not yet supported", binding.Language)
+		}
+		if len(binding.ClassIdentifier) > 0 {
+			if !nameSpaceIsValid(binding.ClassIdentifier) {
+				validation.addError(binding.Location, "Invalid ClassIdentifier in binding \"%s\"", binding.Language)
+			}
+		}
+		if len(binding.Indentation) > 0 && !indentationIsValid(binding.Indentation) {
+			validation.addWarning(binding.Location, "invalid indentation \"%s\" in binding \"%s\"", binding.Indentation, binding.Language)
+		}
+	}
+}
+
+func (component *ComponentDefinition) checkImplementations(validation *ComponentValidation) {
 	implementations := component.ImplementationList.Implementations
 	for i := 0; i < len(implementations); i++ {
 		implementation := implementations[i]
 
+		if !implementationLanguageIsSupported(implementation.Language) {
+			validation.addError(implementation.Location, "unknown implementation language \"%s\"", implementation.Language)
+		} else if implementation.Language == "Fortran" {
+			validation.addWarning(implementation.Location, "implementation in language \"%s\" is not yet supported", implementation.Language)
+		}
 		if len(implementation.ClassIdentifier) > 0 {
 			if !nameSpaceIsValid(implementation.ClassIdentifier) {
-				return fmt.Errorf("Invalid ClassIdentifier in implementation \"%s\"", implementation.Language)
+				validation.addError(implementation.Location, "Invalid ClassIdentifier in implementation \"%s\"", implementation.Language)
 			}
 		}
 		if len(implementation.StubIdentifier) > 0 {
 			if !stubIdentifierIsValid(implementation.StubIdentifier) {
-				return fmt.Errorf("Invalid StubIdentifier in implementation \"%s\"", implementation.Language)
+				validation.addError(implementation.Location, "Invalid StubIdentifier in implementation \"%s\"", implementation.Language)
 			}
 		}
+		if len(implementation.Indentation) > 0 && !indentationIsValid(implementation.Indentation) {
+			validation.addWarning(implementation.Location, "invalid indentation \"%s\" in implementation \"%s\"", implementation.Indentation, implementation.Language)
+		}
 	}
-	return nil
 }
 
-func (component *ComponentDefinition) checkErrors() error {
+func (component *ComponentDefinition) checkErrors(validation *ComponentValidation) {
 	errorNameList := make(map[string]bool, 0)
 	errorCodeList := make(map[int]bool, 0)
 
@@ -386,20 +648,20 @@ func (component *ComponentDefinition) checkErrors() error {
 	for i := 0; i < len(errors.Errors); i++ {
 		merror := errors.Errors[i]
 		if !nameIsValidIdentifier(merror.Name) {
-			return fmt.Errorf("invalid error name \"%s\"", merror.Name)
+			validation.addError(merror.Location, "invalid error name \"%s\"", merror.Name)
 		}
 		if errorNameList[strings.ToLower(merror.Name)] {
-			return fmt.Errorf("duplicate error name \"%s\"", merror.Name)
+			validation.addError(merror.Location, "duplicate error name \"%s\"", merror.Name)
 		}
 		errorNameList[strings.ToLower(merror.Name)] = true
 
 		if errorCodeList[merror.Code] {
-			return fmt.Errorf("duplicate error code \"%d\" for error \"%s\"", merror.Code, merror.Name)
+			validation.addError(merror.Location, "duplicate error code \"%d\" for error \"%s\"", merror.Code, merror.Name)
 		}
 		errorCodeList[merror.Code] = true
 
 		if !errorDescriptionIsValid(merror.Description) {
-			return fmt.Errorf("invalid error description \"%s\" for error \"%s\"", merror.Description, merror.Name)
+			validation.addError(merror.Location, "invalid error description \"%s\" for error \"%s\"", merror.Description, merror.Name)
 		}
 	}
 
@@ -407,11 +669,9 @@ func (component *ComponentDefinition) checkErrors() error {
 		"INVALIDCAST", "BUFFERTOOSMALL", "GENERICEXCEPTION", "COULDNOTLOADLIBRARY", "COULDNOTFINDLIBRARYEXPORT", "INCOMPATIBLEBINARYVERSION"}
 	for _, req := range requiredErrors {
 		if !errorNameList[strings.ToLower(req)] {
-			return fmt.Errorf("component is missing the required error \"%s\"", req)
+			validation.addError(errors.Location, "component is missing the required error \"%s\"", req)
 		}
 	}
-
-	return nil
 }
 
 func errorDescriptionIsValid(name string) bool {
@@ -424,31 +684,77 @@ func errorDescriptionIsValid(name string) bool {
 	return false
 }
 
-func checkOptions(options []ComponentDefinitionEnumOption) error {
+func checkOptions(validation *ComponentValidation, enum ComponentDefinitionEnum) {
 	optionLowerNameList := make(map[string]bool, 0)
 	optionValueList := make(map[int]bool, 0)
 
+	options := enum.Options
 	for j := 0; j < len(options); j++ {
 		option := options[j]
 		if !nameIsValidIdentifier(option.Name) {
-			return fmt.Errorf("invalid option name \"%s\"", option.Name)
+			validation.addError(option.Location, "invalid option name \"%s\" in enum = \"%s\"", option.Name, enum.Name)
 		}
 		if math.Abs(float64(option.Value)) > math.Exp2(31)-1 {
-			return fmt.Errorf("option value out of range \"%d\" in \"%s\"", option.Value, option.Name)
+			validation.addError(option.Location, "option value out of range \"%d\" in \"%s\" in enum = \"%s\"", option.Value, option.Name, enum.Name)
 		}
 		if optionValueList[option.Value] {
-			return fmt.Errorf("duplicate option value \"%d\" in \"%s\"", option.Value, option.Name)
+			validation.addError(option.Location, "duplicate option value \"%d\" in \"%s\" in enum = \"%s\"", option.Value, option.Name, enum.Name)
 		}
 		if optionLowerNameList[strings.ToLower(option.Name)] {
-			return fmt.Errorf("duplicate option name \"%s\"", option.Name)
+			validation.addError(option.Location, "duplicate option name \"%s\" in enum = \"%s\"", option.Name, enum.Name)
+		}
+		if len(option.Description) > 0 && !descriptionIsValid(option.Description) {
+			validation.addError(option.Location, "invalid description \"%s\" of option \"%s\" in enum = \"%s\"", option.Description, option.Name, enum.Name)
 		}
 		optionValueList[option.Value] = true
 		optionLowerNameList[strings.ToLower(option.Name)] = true
 	}
-	return nil
 }
 
-func (component *ComponentDefinition) checkEnums() error {
+// checkConstants checks the names, types and values of the constants of a component.
+// The C-names of constants share the prefix of the component with its error and version constants,
+// and the bindings of some languages declare them next to the global methods.
+func (component *ComponentDefinition) checkConstants(validation *ComponentValidation) {
+	var constantNameList = &component.NameMapsLookup.constantMap
+	constantLowerNameList := make(map[string]bool, 0)
+
+	globalMethodLowerNameList := make(map[string]bool, 0)
+	for _, method := range component.Global.Methods {
+		globalMethodLowerNameList[strings.ToLower(method.MethodName)] = true
+	}
+
+	for _, constant := range component.Constants {
+		if !nameIsValidIdentifier(constant.Name) {
+			validation.addError(constant.Location, "invalid constant name \"%s\"", constant.Name)
+		}
+		if constantLowerNameList[strings.ToLower(constant.Name)] {
+			validation.addError(constant.Location, "duplicate constant name \"%s\"", constant.Name)
+		}
+		upperName := strings.ToUpper(constant.Name)
+		if (upperName == "SUCCESS") || strings.HasPrefix(upperName, "ERROR_") || strings.HasPrefix(upperName, "VERSION_") {
+			validation.addError(constant.Location, "constant name \"%s\" conflicts with the error and version constants of the component", constant.Name)
+		}
+		if globalMethodLowerNameList[strings.ToLower(constant.Name)] {
+			validation.addError(constant.Location, "constant with name \"%s\" conflicts with global method of same name", constant.Name)
+		}
+		if len(constant.Description) > 0 && !descriptionIsValid(constant.Description) {
+			validation.addError(constant.Location, "invalid description \"%s\" of constant \"%s\"", constant.Description, constant.Name)
+		}
+		if (constant.Type == "pointer") || !(isScalarType(constant.Type) || (constant.Type == "string")) {
+			validation.addError(constant.Location, "constant \"%s\" has unsupported type \"%s\"", constant.Name, constant.Type)
+		} else {
+			err := component.checkValueOfType(constant.Value, constant.Type, "")
+			if err != nil {
+				validation.addError(constant.Location, "invalid value of constant \"%s\": %s", constant.Name, err.Error())
+			}
+		}
+
+		constantLowerNameList[strings.ToLower(constant.Name)] = true
+		(*constantNameList)[constant.Name] = true
+	}
+}
+
+func (component *ComponentDefinition) checkEnums(validation *ComponentValidation) {
 	enums := component.Enums
 	var enumNameList = &component.NameMapsLookup.enumMap
 	enumLowerNameList := make(map[string]bool, 0)
@@ -456,26 +762,24 @@ func (component *ComponentDefinition) checkEnums() error {
 	for i := 0; i < len(enums); i++ {
 		enum := enums[i]
 		if !nameIsValidIdentifier(enum.Name) {
-			return fmt.Errorf("invalid enum name \"%s\"", enum.Name)
+			validation.addError(enum.Location, "invalid enum name \"%s\"", enum.Name)
 		}
 
 		if enumLowerNameList[strings.ToLower(enum.Name)] {
-			return fmt.Errorf("duplicate enum name \"%s\"", enum.Name)
+			validation.addError(enum.Location, "duplicate enum name \"%s\"", enum.Name)
 		}
-
-		err := checkOptions(enum.Options)
-		if err != nil {
-			return fmt.Errorf(err.Error()+" in enum = \"%s\"", enum.Name)
+		if len(enum.Description) > 0 && !descriptionIsValid(enum.Description) {
+			validation.addError(enum.Location, "invalid description \"%s\" of enum \"%s\"", enum.Description, enum.Name)
 		}
 
+		checkOptions(validation, enum)
+
 		enumLowerNameList[strings.ToLower(enum.Name)] = true
 		(*enumNameList)[enum.Name] = true
 	}
-
-	return nil
 }
 
-func (component *ComponentDefinition) checkStructs() error {
+func (component *ComponentDefinition) checkStructs(validation *ComponentValidation) {
 	structs := component.Structs
 	var structNameList = &component.NameMapsLookup.structMap
 	structLowerNameList := make(map[string]bool, 0)
@@ -483,10 +787,13 @@ func (component *ComponentDefinition) checkStructs() error {
 	for i := 0; i < len(structs); i++ {
 		mstruct := structs[i]
 		if !nameIsValidIdentifier(mstruct.Name) {
-			return fmt.Errorf("invalid struct name \"%s\"", mstruct.Name)
+			validation.addError(mstruct.Location, "invalid struct name \"%s\"", mstruct.Name)
+		}
+		if structLowerNameList[strings.ToLower(mstruct.Name)] == true {
+			validation.addError(mstruct.Location, "duplicate struct name \"%s\"", mstruct.Name)
 		}
-		if structLowerNameList[mstruct.Name] == true {
-			return fmt.Errorf("duplicate struct name \"%s\"", mstruct.Name)
+		if len(mstruct.Description) > 0 && !descriptionIsValid(mstruct.Description) {
+			validation.addError(mstruct.Location, "invalid description \"%s\" of struct \"%s\"", mstruct.Description, mstruct.Name)
 		}
 		(*structNameList)[mstruct.Name] = true
 		structLowerNameList[strings.ToLower(mstruct.Name)] = true
@@ -494,14 +801,16 @@ func (component *ComponentDefinition) checkStructs() error {
 		for j := 0; j < len(mstruct.Members); j++ {
 			member := mstruct.Members[j]
 			if !nameIsValidIdentifier(member.Name) {
-				return fmt.Errorf("invalid member name \"%s\"", member.Name)
+				validation.addError(member.Location, "invalid member name \"%s\"", member.Name)
+			}
+			if len(member.Description) > 0 && !descriptionIsValid(member.Description) {
+				validation.addError(member.Location, "invalid description \"%s\" of member \"%s\" in struct \"%s\"", member.Description, member.Name, mstruct.Name)
 			}
 		}
 	}
-	return nil
 }
 
-func (component *ComponentDefinition) checkClasses() error {
+func (component *ComponentDefinition) checkClasses(validation *ComponentValidation) {
 	classes := component.Classes
 	baseClassName := component.Global.BaseClassName
 	var classNameList = &component.NameMapsLookup.classMap
@@ -511,18 +820,20 @@ func (component *ComponentDefinition) checkClasses() error {
 	for i := 0; i < len(classes); i++ {
 		class := classes[i]
 		if !nameIsValidIdentifier(class.ClassName) {
-			return fmt.Errorf("invalid class name \"%s\"", class.ClassName)
+			validation.addError(class.Location, "invalid class name \"%s\"", class.ClassName)
 		}
 		if classLowerNameList[strings.ToLower(class.ClassName)] == true {
-			return fmt.Errorf("duplicate class name \"%s\"", class.ClassName)
+			validation.addError(class.Location, "duplicate class name \"%s\"", class.ClassName)
 		}
 		if len(class.ClassDescription) > 0 && !descriptionIsValid(class.ClassDescription) {
-			return fmt.Errorf("invalid class description \"%s\" in class \"%s\"", class.ClassDescription, class.ClassName)
+			validation.addError(class.Location, "invalid class description \"%s\" in class \"%s\"", class.ClassDescription, class.ClassName)
 		}
 
 		classLowerNameList[strings.ToLower(class.ClassName)] = true
 		(*classNameList)[class.ClassName] = true
-		classNameIndex[class.ClassName] = i
+		if _, ok := classNameIndex[class.ClassName]; !ok {
+			classNameIndex[class.ClassName] = i
+		}
 	}
 
 	// Check parent class definitions
@@ -534,24 +845,19 @@ func (component *ComponentDefinition) checkClasses() error {
 		}
 		if len(parentClass) > 0 {
 			if !nameIsValidIdentifier(parentClass) {
-				return fmt.Errorf("invalid parent class name \"%s\"", parentClass)
-			}
-			if (*classNameList)[parentClass] == false {
-				return fmt.Errorf("unknown parent class \"%s\" for class \"%s\"", parentClass, class.ClassName)
-			}
-			if classNameIndex[parentClass] >= i {
-				return fmt.Errorf("parent class \"%s\" for class \"%s\" is defined after its child class", parentClass, class.ClassName)
-			}
-			if strings.ToLower(class.ClassName) == strings.ToLower(parentClass) {
-				return fmt.Errorf("class \"%s\" cannot be its own parent class \"%s\"", class.ClassName, parentClass)
+				validation.addError(class.Location, "invalid parent class name \"%s\"", parentClass)
+			} else if (*classNameList)[parentClass] == false {
+				validation.addError(class.Location, "unknown parent class \"%s\" for class \"%s\"", parentClass, class.ClassName)
+			} else if strings.ToLower(class.ClassName) == strings.ToLower(parentClass) {
+				validation.addError(class.Location, "class \"%s\" cannot be its own parent class \"%s\"", class.ClassName, parentClass)
+			} else if classNameIndex[parentClass] >= i {
+				validation.addError(class.Location, "parent class \"%s\" for class \"%s\" is defined after its child class", parentClass, class.ClassName)
 			}
 		}
 	}
-
-	return nil
 }
 
-func (component *ComponentDefinition) checkFunctionTypes() error {
+func (component *ComponentDefinition) checkFunctionTypes(validation *ComponentValidation) {
 	functions := component.Functions
 	var functionNameList = &component.NameMapsLookup.functionTypeMap
 
@@ -559,141 +865,209 @@ func (component *ComponentDefinition) checkFunctionTypes() error {
 	for i := 0; i < len(functions); i++ {
 		function := functions[i]
 		if !nameIsValidIdentifier(function.FunctionName) {
-			return fmt.Errorf("invalid functiontype name \"%s\"", function.FunctionName)
+			validation.addError(function.Location, "invalid functiontype name \"%s\"", function.FunctionName)
 		}
 		if functionLowerNameList[strings.ToLower(function.FunctionName)] == true {
-			return fmt.Errorf("duplicate functiontype name \"%s\"", function.FunctionName)
+			validation.addError(function.Location, "duplicate functiontype name \"%s\"", function.FunctionName)
 		}
 		if len(function.FunctionDescription) > 0 && !descriptionIsValid(function.FunctionDescription) {
-			return fmt.Errorf("invalid function description \"%s\" in functiontype \"%s\"", function.FunctionDescription, function.FunctionName)
+			validation.addError(function.Location, "invalid function description \"%s\" in functiontype \"%s\"", function.FunctionDescription, function.FunctionName)
 		}
 
 		functionLowerNameList[strings.ToLower(function.FunctionName)] = true
 		(*functionNameList)[function.FunctionName] = true
 	}
-	return nil
 }
 
-func checkDuplicateNames(nameMaps NameMaps) error {
-	enumList := nameMaps.enumMap
-	structList := nameMaps.structMap
-	classList := nameMaps.classMap
-	functionTypeList := nameMaps.functionTypeMap
-
+// checkDuplicateNames checks that structs, enums, classes, functiontypes and constants do not share a name
+func (component *ComponentDefinition) checkDuplicateNames(validation *ComponentValidation) {
 	allLowerList := make(map[string]string, 0)
 
-	for k := range structList {
-		if val, ok := allLowerList[strings.ToLower(k)]; ok {
-			if val == "struct" {
-				return fmt.Errorf("duplicate struct name \"%s\"", k)
+	checkName := func(kind string, name string, location SourceLocation) {
+		if val, ok := allLowerList[strings.ToLower(name)]; ok {
+			if val != kind {
+				validation.addError(location, "%s with name \"%s\" conflicts with %s of same name", kind, name, val)
 			}
-			return fmt.Errorf("struct with name \"%s\" conflicts with %s of same name", k, val)
+			return
 		}
-		allLowerList[strings.ToLower(k)] = "struct"
+		allLowerList[strings.ToLower(name)] = kind
 	}
 
-	for k := range enumList {
-		if val, ok := allLowerList[strings.ToLower(k)]; ok {
-			if val == "enum" {
-				return fmt.Errorf("duplicate class name \"%s\"", k)
-			}
-			return fmt.Errorf("enum with name \"%s\" conflicts with %s of same name", k, val)
-		}
-		allLowerList[strings.ToLower(k)] = "enum"
+	for _, mstruct := range component.Structs {
+		checkName("struct", mstruct.Name, mstruct.Location)
 	}
-
-	for k := range classList {
-		if val, ok := allLowerList[strings.ToLower(k)]; ok {
-			if val == "class" {
-				return fmt.Errorf("duplicate class name \"%s\"", k)
-			}
-			return fmt.Errorf("class with name \"%s\" conflicts with %s of same name", k, val)
-		}
-		allLowerList[strings.ToLower(k)] = "class"
+	for _, enum := range component.Enums {
+		checkName("enum", enum.Name, enum.Location)
 	}
-
-	for k := range functionTypeList {
-		if val, ok := allLowerList[strings.ToLower(k)]; ok {
-			if val == "functiontype" {
-				return fmt.Errorf("duplicate functiontype name \"%s\"", k)
-			}
-			return fmt.Errorf("functiontype with name \"%s\" conflicts with %s of same name", k, val)
-		}
-		allLowerList[strings.ToLower(k)] = "functiontype"
+	for _, class := range component.Classes {
+		checkName("class", class.ClassName, class.Location)
+	}
+	for _, function := range component.Functions {
+		checkName("functiontype", function.FunctionName, function.Location)
+	}
+	for _, constant := range component.Constants {
+		checkName("constant", constant.Name, constant.Location)
 	}
-	return nil
 }
 
-func (component *ComponentDefinition) checkMethod(method ComponentDefinitionMethod, className string) error {
+func (component *ComponentDefinition) checkMethod(validation *ComponentValidation, method ComponentDefinitionMethod, className string) {
 	if !nameIsValidIdentifier(method.MethodName) {
-		return fmt.Errorf("invalid name for method \"%s.%s\"", className, method.MethodName)
+		validation.addError(method.Location, "invalid name for method \"%s.%s\"", className, method.MethodName)
 	}
 	if !descriptionIsValid(method.MethodDescription) {
-		return fmt.Errorf("invalid description for method \"%s.%s\"", className, method.MethodName)
+		validation.addError(method.Location, "invalid description for method \"%s.%s\"", className, method.MethodName)
 	}
 
 	paramNameList := make(map[string]bool, 0)
+	defaultParamName := ""
 	for k := 0; k < len(method.Params); k++ {
 		param := method.Params[k]
+		if param.HasDefault() {
+			err := component.checkParamDefault(param)
+			if err != nil {
+				validation.addError(param.Location, "invalid default of parameter \"%s\" of method \"%s.%s\": %s", param.ParamName, className, method.MethodName, err.Error())
+			}
+			defaultParamName = param.ParamName
+		} else if (defaultParamName != "") && (param.ParamPass != "return") {
+			validation.addError(param.Location, "parameter \"%s\" of method \"%s.%s\" follows parameter \"%s\" with a default, but has no default itself", param.ParamName, className, method.MethodName, defaultParamName)
+		}
 		if !nameIsValidIdentifier(param.ParamName) {
-			return fmt.Errorf("invalid param name \"%s\" in method \"%s.%s\"", param.ParamName, className, method.MethodName)
+			validation.addError(param.Location, "invalid param name \"%s\" in method \"%s.%s\"", param.ParamName, className, method.MethodName)
 		}
-		if !descriptionIsValid(method.MethodDescription) {
-			return fmt.Errorf("invalid description for parameter \"%s.%s(... %s ...)\"", className, method.MethodName, param.ParamName)
+		if len(param.ParamDescription) > 0 && !descriptionIsValid(param.ParamDescription) {
+			validation.addWarning(param.Location, "invalid description for parameter \"%s.%s(... %s ...)\"", className, method.MethodName, param.ParamName)
 		}
 		if paramNameList[strings.ToLower(param.ParamName)] {
-			return fmt.Errorf("duplicate name \"%s\" for parameter in method \"%s.%s\"", param.ParamName, className, method.MethodName)
+			validation.addError(param.Location, "duplicate name \"%s\" for parameter in method \"%s.%s\"", param.ParamName, className, method.MethodName)
 		}
 		paramNameList[strings.ToLower(param.ParamName)] = true
 
+		if (param.ParamPass != "in") && (param.ParamPass != "out") && (param.ParamPass != "return") {
+			validation.addError(param.Location, "parameter \"%s\" of method \"%s.%s\" has invalid pass \"%s\"", param.ParamName, className, method.MethodName, param.ParamPass)
+		}
+
 		if isScalarType(param.ParamType) || param.ParamType == "string" {
 			// okay
 		} else if param.ParamType == "basicarray" {
 			if !isScalarType(param.ParamClass) {
-				return fmt.Errorf("parameter \"%s\" for method \"%s.%s\" is an unknown basic type \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
+				validation.addError(param.Location, "parameter \"%s\" for method \"%s.%s\" is an unknown basic type \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
 			}
 		} else {
 			currentNameMaps := component.NameMapsLookup
 
 			namespace, paramClassName, err := decomposeParamClassName(param.ParamClass)
 			if err != nil {
-				return err
+				validation.addError(param.Location, "%s", err.Error())
+				continue
 			}
 			if len(namespace) > 0 {
 				if subComponent, ok := component.ImportedComponentDefinitions[namespace]; ok {
 					currentNameMaps = subComponent.NameMapsLookup
 				} else {
-					return fmt.Errorf("parameter \"%s\" of method \"%s.%s\" is of unknown class \"%s\": unknown namespace \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass, namespace)
+					validation.addError(param.Location, "parameter \"%s\" of method \"%s.%s\" is of unknown class \"%s\": unknown namespace \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass, namespace)
+					continue
 				}
 			}
 
 			if param.ParamType == "class" || param.ParamType == "optionalclass" {
 				if currentNameMaps.classMap[paramClassName] != true {
-					return fmt.Errorf("parameter \"%s\" of method \"%s.%s\" is of unknown class \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
+					validation.addError(param.Location, "parameter \"%s\" of method \"%s.%s\" is of unknown class \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
 				}
 			} else if (param.ParamType == "enum") || (param.ParamType == "enumarray") {
 				if currentNameMaps.enumMap[paramClassName] != true {
-					return fmt.Errorf("parameter \"%s\" for method \"%s.%s\" is an unknown enum \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
+					validation.addError(param.Location, "parameter \"%s\" for method \"%s.%s\" is an unknown enum \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
 				}
 			} else if (param.ParamType == "structarray") || (param.ParamType == "struct") {
 				if currentNameMaps.structMap[paramClassName] != true {
-					return fmt.Errorf("parameter \"%s\" for method \"%s.%s\" is an unknown struct \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
+					validation.addError(param.Location, "parameter \"%s\" for method \"%s.%s\" is an unknown struct \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
 				}
 			} else if param.ParamType == "functiontype" {
 				if currentNameMaps.functionTypeMap[paramClassName] != true {
-					return fmt.Errorf("parameter \"%s\" for method \"%s.%s\" is an unknown function type \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
+					validation.addError(param.Location, "parameter \"%s\" for method \"%s.%s\" is an unknown function type \"%s\"", param.ParamName, className, method.MethodName, param.ParamClass)
 				}
 			} else {
-				return fmt.Errorf("parameter \"%s\" of method \"%s.%s\" is of unknown type \"%s\"", param.ParamName, className, method.MethodName, param.ParamType)
+				validation.addError(param.Location, "parameter \"%s\" of method \"%s.%s\" is of unknown type \"%s\"", param.ParamName, className, method.MethodName, param.ParamType)
 			}
 		}
+	}
+}
 
+// lookupEnum returns the enum a parameter class refers to, in this or in an imported component
+func (component *ComponentDefinition) lookupEnum(paramClass string) (ComponentDefinitionEnum, bool) {
+	namespace, enumName, err := decomposeParamClassName(paramClass)
+	if err != nil {
+		return ComponentDefinitionEnum{}, false
 	}
+	enums := component.Enums
+	if len(namespace) > 0 {
+		subComponent, ok := component.ImportedComponentDefinitions[namespace]
+		if !ok {
+			return ComponentDefinitionEnum{}, false
+		}
+		enums = subComponent.Enums
+	}
+	for _, enum := range enums {
+		if enum.Name == enumName {
+			return enum, true
+		}
+	}
+	return ComponentDefinitionEnum{}, false
+}
 
-	return nil
+// checkParamDefault checks that the default of a parameter is a valid value of its type.
+// Only input parameters of scalar types, strings and enums can have a default.
+func (component *ComponentDefinition) checkParamDefault(param ComponentDefinitionParam) error {
+	if param.ParamPass != "in" {
+		return fmt.Errorf("only input parameters can have a default")
+	}
+	if (param.ParamType == "pointer") || !(isScalarType(param.ParamType) || (param.ParamType == "string") || (param.ParamType == "enum")) {
+		return fmt.Errorf("parameters of type \"%s\" cannot have a default", param.ParamType)
+	}
+	return component.checkValueOfType(param.DefaultValue(), param.ParamType, param.ParamClass)
 }
 
-func (component *ComponentDefinition) checkClassMethods() error {
+// checkValueOfType checks that a value is a literal of a scalar type or a string, or the name of an option
+// of an enum
+func (component *ComponentDefinition) checkValueOfType(value string, valueType string, valueClass string) error {
+	var err error
+	switch valueType {
+	case "uint8", "uint16", "uint32", "uint64":
+		_, err = strconv.ParseUint(value, 10, getTypeBitSize(valueType))
+	case "int8", "int16", "int32", "int64":
+		_, err = strconv.ParseInt(value, 10, getTypeBitSize(valueType))
+	case "single", "double":
+		var number float64
+		number, err = strconv.ParseFloat(value, getTypeBitSize(valueType))
+		if (err == nil) && (math.IsInf(number, 0) || math.IsNaN(number)) {
+			err = fmt.Errorf("\"%s\" is not a finite number", value)
+		}
+	case "bool":
+		if (value != "true") && (value != "false") {
+			err = fmt.Errorf("\"%s\" is neither \"true\" nor \"false\"", value)
+		}
+	case "string":
+		// every string is valid
+	case "enum":
+		enum, ok := component.lookupEnum(valueClass)
+		if !ok {
+			return fmt.Errorf("unknown enum \"%s\"", valueClass)
+		}
+		for _, option := range enum.Options {
+			if option.Name == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("\"%s\" is not an option of enum \"%s\"", value, valueClass)
+	default:
+		return fmt.Errorf("type \"%s\" has no literal values", valueType)
+	}
+	if _, ok := err.(*strconv.NumError); ok {
+		return fmt.Errorf("\"%s\" is not a valid %s", value, valueType)
+	}
+	return err
+}
+
+func (component *ComponentDefinition) checkClassMethods(validation *ComponentValidation) {
 	classes := component.Classes
 
 	for i := 0; i < len(classes); i++ {
@@ -704,17 +1078,95 @@ func (component *ComponentDefinition) checkClassMethods() error {
 			method := class.Methods[j]
 
 			if methodNameList[strings.ToLower(method.MethodName)] {
-				return fmt.Errorf("duplicate name for method \"%s.%s\"", class.ClassName, method.MethodName)
+				validation.addError(method.Location, "duplicate name for method \"%s.%s\"", class.ClassName, method.MethodName)
 			}
 			methodNameList[strings.ToLower(method.MethodName)] = true
 
-			err := component.checkMethod(method, class.ClassName)
-			if err != nil {
-				return err
+			component.checkMethod(validation, method, class.ClassName)
+		}
+
+		checkProperties(validation, class, methodNameList)
+	}
+}
+
+// checkProperties checks the properties of a class. The types of the properties are checked with the
+// parameters of their accessor methods.
+func checkProperties(validation *ComponentValidation, class ComponentDefinitionClass, methodNameList map[string]bool) {
+	propertyNameList := make(map[string]bool, 0)
+	for _, property := range class.Properties {
+		if !nameIsValidIdentifier(property.Name) {
+			validation.addError(property.Location, "invalid property name \"%s.%s\"", class.ClassName, property.Name)
+		}
+		if propertyNameList[strings.ToLower(property.Name)] {
+			validation.addError(property.Location, "duplicate name for property \"%s.%s\"", class.ClassName, property.Name)
+		}
+		propertyNameList[strings.ToLower(property.Name)] = true
+
+		if methodNameList[strings.ToLower(property.Name)] {
+			validation.addError(property.Location, "property \"%s.%s\" conflicts with method of same name", class.ClassName, property.Name)
+		}
+		if (property.Access != ePropertyAccessRead) && (property.Access != ePropertyAccessReadWrite) {
+			validation.addError(property.Location, "property \"%s.%s\" has invalid access \"%s\"", class.ClassName, property.Name, property.Access)
+		}
+		switch property.Type {
+		case "pointer", "basicarray", "enumarray", "structarray", "functiontype":
+			validation.addError(property.Location, "property \"%s.%s\" has unsupported type \"%s\"", class.ClassName, property.Name, property.Type)
+		}
+		if len(property.Description) > 0 && !descriptionIsValid(property.Description) {
+			validation.addWarning(property.Location, "invalid description for property \"%s.%s\"", class.ClassName, property.Name)
+		}
+	}
+}
+
+func (component *ComponentDefinition) checkGlobalMethods(validation *ComponentValidation) {
+	globalMethodNameList := make(map[string]bool, 0)
+	specialMethodErrorList := make(map[string]bool, 0)
+	for i := 0; i < len(component.Global.Methods); i++ {
+		method := component.Global.Methods[i]
+
+		if globalMethodNameList[strings.ToLower(method.MethodName)] {
+			validation.addError(method.Location, "duplicate name for method \"%s.%s\"", "global", method.MethodName)
+		}
+		globalMethodNameList[strings.ToLower(method.MethodName)] = true
+
+		if method.IsStatic {
+			validation.addError(method.Location, "global method \"%s\" cannot be static", method.MethodName)
+		}
+
+		_, err := CheckHeaderSpecialFunction(method, component.Global)
+		if (err != nil) && !specialMethodErrorList[err.Error()] {
+			// Errors in the attributes of <global> would be reported for every method otherwise
+			specialMethodErrorList[err.Error()] = true
+			validation.addError(method.Location, "%s", err.Error())
+		}
+
+		component.checkMethod(validation, method, "global")
+	}
+
+	// The Go, Java and Node bindings expose static methods as methods of the wrapper named after class and method
+	for _, class := range component.Classes {
+		for _, method := range class.Methods {
+			if method.IsStatic && globalMethodNameList[strings.ToLower(class.ClassName+method.MethodName)] {
+				validation.addError(method.Location, "static method \"%s.%s\" conflicts with the global method \"%s%s\"", class.ClassName, method.MethodName, class.ClassName, method.MethodName)
 			}
 		}
 	}
-	return nil
+
+	if component.Global.BaseClassName == "" {
+		validation.addError(component.Global.Location, "No base class name specified")
+		return
+	}
+	found := 0
+	for i := 0; i < len(component.Classes); i++ {
+		if component.Classes[i].ClassName == component.Global.BaseClassName {
+			found++
+		}
+	}
+	if found == 0 {
+		validation.addError(component.Global.Location, "Specified base class not found")
+	} else if found > 1 {
+		validation.addError(component.Global.Location, "Base clase defined more than once")
+	}
 }
 
 // decomposeParamClassName decomposes a classname into a namespace and the actual classname within this namespace
@@ -764,6 +1216,33 @@ func isScalarType(typeStr string) bool {
 	return false
 }
 
+// getTypeBitSize returns the size in bits of a numeric scalar type
+func getTypeBitSize(typeStr string) int {
+	switch typeStr {
+	case "uint8", "int8":
+		return 8
+	case "uint16", "int16":
+		return 16
+	case "uint32", "int32", "single":
+		return 32
+	}
+	return 64
+}
+
+// getFloatingPointLiteral returns the shortest decimal representation of a valid value of a "single" or "double".
+// It always contains a decimal point or an exponent, so that no language reads it as an integer literal.
+func getFloatingPointLiteral(value string, typeStr string) string {
+	number, err := strconv.ParseFloat(value, getTypeBitSize(typeStr))
+	if err != nil {
+		return value
+	}
+	literal := strconv.FormatFloat(number, 'g', -1, getTypeBitSize(typeStr))
+	if !strings.ContainsAny(literal, ".e") {
+		literal = literal + ".0"
+	}
+	return literal
+}
+
 func majorVersion(version string) int {
 	isValid, versions, _ := decomposeVersionString(version)
 	if !isValid {
@@ -861,129 +1340,205 @@ func baseNameIsValid(baseName string) bool {
 	return false
 }
 
-func (component *ComponentDefinition) checkComponentHeader() error {
+func (component *ComponentDefinition) checkComponentHeader(validation *ComponentValidation) {
+	location := component.Location
 	versionIsValid, _, _ := decomposeVersionString(component.Version)
 	if !versionIsValid {
-		return fmt.Errorf("Version \"%s\" is invalid", component.Version)
+		validation.addError(location, "Version \"%s\" is invalid", component.Version)
 	}
 	if component.Copyright == "" {
-		return errors.New("no Copyright information given")
+		validation.addError(location, "no Copyright information given")
 	}
 	if (component.Year < 2000) || (component.Year > 2100) {
-		return errors.New("invalid year given")
+		validation.addError(location, "invalid year given")
 	}
 	if !nameSpaceIsValid(component.NameSpace) {
-		return errors.New("Invalid Namespace")
+		validation.addError(location, "Invalid Namespace")
 	}
 	if !libraryNameIsValid(component.LibraryName) {
-		return errors.New("Invalid LilbraryName")
+		validation.addError(location, "Invalid LilbraryName")
 	}
 	if component.BaseName == "" {
-		log.Fatal("Invalid export basename")
+		validation.addError(location, "Invalid export basename")
+	} else if !baseNameIsValid(component.BaseName) {
+		validation.addError(location, "Invalid BaseName")
 	}
-	if !baseNameIsValid(component.BaseName) {
-		return errors.New("Invalid BaseName")
-	}
-	return nil
 }
 
 // NameMaps contains maps of names of elements in a component
 type NameMaps struct {
+	constantMap     map[string]bool
 	enumMap         map[string]bool
 	structMap       map[string]bool
 	classMap        map[string]bool
 	functionTypeMap map[string]bool
 }
 
-// CheckComponentDefinition checks a component and returns an error, if it fails
-func (component *ComponentDefinition) CheckComponentDefinition() error {
-	err := component.checkComponentHeader()
-	if err != nil {
-		return err
-	}
+// ValidateComponentDefinition checks a component and its imported components and collects all errors and warnings
+func (component *ComponentDefinition) ValidateComponentDefinition() ComponentValidation {
+	var validation ComponentValidation
+	component.validate(&validation)
+	return validation
+}
+
+func (component *ComponentDefinition) validate(validation *ComponentValidation) {
+	fallback := validation.fallback
+	validation.fallback = component.Location
+	defer func() { validation.fallback = fallback }()
+
+	component.checkComponentHeader(validation)
 
 	for _, subComponent := range component.ImportedComponentDefinitions {
-		err := subComponent.CheckComponentDefinition()
-		if err != nil {
-			return err
+		subComponent.validate(validation)
+		validation.fallback = component.Location
+	}
+
+	component.checkErrors(validation)
+	component.checkConstants(validation)
+	component.checkBindings(validation)
+	component.checkImplementations(validation)
+	component.checkEnums(validation)
+	component.checkStructs(validation)
+	component.checkClasses(validation)
+	component.checkFunctionTypes(validation)
+	component.checkDuplicateNames(validation)
+	component.checkClassMethods(validation)
+	component.checkGlobalMethods(validation)
+	component.checkDeprecations(validation)
+}
+
+// checkDeprecation checks that an element is deprecated since a valid version that is not later
+// than the version of the component
+func (component *ComponentDefinition) checkDeprecation(validation *ComponentValidation, deprecation ComponentDefinitionDeprecation, location SourceLocation, elementName string) {
+	if !deprecation.IsDeprecated() {
+		if deprecation.Replacement != "" {
+			validation.addError(location, "%s has a replacement, but is not deprecated", elementName)
 		}
+		return
 	}
-
-	err = component.checkErrors()
-	if err != nil {
-		return err
-	}
-
-	err = component.checkImplementations()
-	if err != nil {
-		return err
+	isValid, versions, _ := decomposeVersionString(deprecation.Deprecated)
+	if !isValid {
+		validation.addError(location, "%s is deprecated since invalid version \"%s\"", elementName, deprecation.Deprecated)
+		return
+	}
+	if deprecation.Replacement != "" && !descriptionIsValid(deprecation.Replacement) {
+		validation.addError(location, "invalid replacement \"%s\" of %s", deprecation.Replacement, elementName)
+	}
+	isComponentVersionValid, componentVersions, _ := decomposeVersionString(component.Version)
+	if isComponentVersionValid {
+		for i := 0; i < 3; i++ {
+			if versions[i] != componentVersions[i] {
+				if versions[i] > componentVersions[i] {
+					validation.addWarning(location, "%s is deprecated since version %s, which is later than the version %s of the component", elementName, deprecation.Deprecated, component.Version)
+				}
+				break
+			}
+		}
 	}
+}
 
-	err = component.checkEnums()
-	if err != nil {
-		return err
+// hasStaticMethods returns true if a class of the component has a static method
+func (component *ComponentDefinition) hasStaticMethods() bool {
+	for _, class := range component.Classes {
+		if class.hasStaticMethods() {
+			return true
+		}
 	}
+	return false
+}
 
-	err = component.checkStructs()
-	if err != nil {
-		return err
+// hasStaticMethods returns true if the class has a static method
+func (class ComponentDefinitionClass) hasStaticMethods() bool {
+	for _, method := range class.Methods {
+		if method.IsStatic {
+			return true
+		}
 	}
+	return false
+}
 
-	err = component.checkClasses()
-	if err != nil {
-		return err
+// instanceMethods returns the methods of the class that are called on an instance
+func (class ComponentDefinitionClass) instanceMethods() []ComponentDefinitionMethod {
+	methods := []ComponentDefinitionMethod{}
+	for _, method := range class.Methods {
+		if !method.IsStatic {
+			methods = append(methods, method)
+		}
 	}
+	return methods
+}
 
-	err = component.checkFunctionTypes()
-	if err != nil {
-		return err
+// hasDeprecations returns true if any class, method, parameter, enum, option or error of the component is deprecated
+func (component *ComponentDefinition) hasDeprecations() bool {
+	methodsHaveDeprecations := func(methods []ComponentDefinitionMethod) bool {
+		for _, method := range methods {
+			if method.IsDeprecated() {
+				return true
+			}
+			for _, param := range method.Params {
+				if param.IsDeprecated() {
+					return true
+				}
+			}
+		}
+		return false
 	}
-
-	err = checkDuplicateNames(component.NameMapsLookup)
-	if err != nil {
-		return err
+	for _, class := range component.Classes {
+		if class.IsDeprecated() || methodsHaveDeprecations(class.Methods) {
+			return true
+		}
 	}
-
-	err = component.checkClassMethods()
-	if err != nil {
-		return err
+	if methodsHaveDeprecations(component.Global.Methods) {
+		return true
 	}
-
-	globalMethodNameList := make(map[string]bool, 0)
-	for i := 0; i < len(component.Global.Methods); i++ {
-		method := component.Global.Methods[i]
-
-		if globalMethodNameList[strings.ToLower(method.MethodName)] {
-			return fmt.Errorf("duplicate name for method \"%s.%s\"", "global", method.MethodName)
+	for _, enum := range component.Enums {
+		if enum.IsDeprecated() {
+			return true
 		}
-		globalMethodNameList[strings.ToLower(method.MethodName)] = true
-
-		_, err := CheckHeaderSpecialFunction(method, component.Global)
-		if err != nil {
-			return err
+		for _, option := range enum.Options {
+			if option.IsDeprecated() {
+				return true
+			}
 		}
-
-		err = component.checkMethod(method, "global")
-		if err != nil {
-			return err
+	}
+	for _, errorDefinition := range component.Errors.Errors {
+		if errorDefinition.IsDeprecated() {
+			return true
 		}
 	}
+	return false
+}
 
-	if component.Global.BaseClassName == "" {
-		return errors.New("No base class name specified")
+// checkDeprecations checks the deprecation of all classes, methods, parameters, enums, options and errors
+func (component *ComponentDefinition) checkDeprecations(validation *ComponentValidation) {
+	checkMethods := func(methods []ComponentDefinitionMethod, className string) {
+		for _, method := range methods {
+			component.checkDeprecation(validation, method.ComponentDefinitionDeprecation, method.Location, fmt.Sprintf("method \"%s.%s\"", className, method.MethodName))
+			for _, param := range method.Params {
+				component.checkDeprecation(validation, param.ComponentDefinitionDeprecation, param.Location, fmt.Sprintf("parameter \"%s\" of method \"%s.%s\"", param.ParamName, className, method.MethodName))
+			}
+		}
 	}
-	found := 0
-	for i := 0; i < len(component.Classes); i++ {
-		if component.Classes[i].ClassName == component.Global.BaseClassName {
-			found++
+	for _, class := range component.Classes {
+		component.checkDeprecation(validation, class.ComponentDefinitionDeprecation, class.Location, fmt.Sprintf("class \"%s\"", class.ClassName))
+		checkMethods(class.Methods, class.ClassName)
+	}
+	checkMethods(component.Global.Methods, "global")
+	for _, enum := range component.Enums {
+		component.checkDeprecation(validation, enum.ComponentDefinitionDeprecation, enum.Location, fmt.Sprintf("enum \"%s\"", enum.Name))
+		for _, option := range enum.Options {
+			component.checkDeprecation(validation, option.ComponentDefinitionDeprecation, option.Location, fmt.Sprintf("option \"%s\" of enum \"%s\"", option.Name, enum.Name))
 		}
 	}
-	if found == 0 {
-		return errors.New("Specified base class not found")
-	} else if found > 1 {
-		return errors.New("Base clase defined more than once")
+	for _, errorDefinition := range component.Errors.Errors {
+		component.checkDeprecation(validation, errorDefinition.ComponentDefinitionDeprecation, errorDefinition.Location, fmt.Sprintf("error \"%s\"", errorDefinition.Name))
 	}
-	return nil
+}
+
+// CheckComponentDefinition checks a component and returns an error listing all errors, if it fails
+func (component *ComponentDefinition) CheckComponentDefinition() error {
+	validation := component.ValidateComponentDefinition()
+	return validation.Err()
 }
 
 // CheckHeaderSpecialFunction checks a special function of the header against their required definitions
@@ -1219,6 +1774,27 @@ func (component *ComponentDefinition) isBaseClass(class ComponentDefinitionClass
 	return class.ClassName == component.Global.BaseClassName
 }
 
+// importedNameSpaces returns the namespaces of all imported components in a stable order
+func (component *ComponentDefinition) importedNameSpaces() []string {
+	namespaces := []string{}
+	for _, subComponent := range component.ImportedComponentDefinitions {
+		namespaces = append(namespaces, subComponent.NameSpace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// parentClassName returns the name of the class a class inherits from, or "" for the base class
+func (component *ComponentDefinition) parentClassName(class ComponentDefinitionClass) string {
+	if component.isBaseClass(class) {
+		return ""
+	}
+	if class.ParentClass != "" {
+		return class.ParentClass
+	}
+	return component.Global.BaseClassName
+}
+
 func (component *ComponentDefinition) baseClass() ComponentDefinitionClass {
 	for i := 0; i < len(component.Classes); i++ {
 		if component.isBaseClass(component.Classes[i]) {
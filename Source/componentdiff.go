@@ -35,11 +35,32 @@ package main
 
 import (
 	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Classifications of a change with respect to semantic versioning
+const (
+	eDiffClassificationNone     = "none"
+	eDiffClassificationCosmetic = "cosmetic"
+	eDiffClassificationAdditive = "additive"
+	eDiffClassificationBreaking = "breaking"
+)
+
+// Version bumps between two semantic versions
+const (
+	eVersionBumpDowngrade = "downgrade"
+	eVersionBumpNone      = "none"
+	eVersionBumpMicro     = "micro"
+	eVersionBumpMinor     = "minor"
+	eVersionBumpMajor     = "major"
 )
 
 // ComponentDiffBase is the base class for all component diff bases
 type ComponentDiffBase struct {
-	Path string `xml:"xpath,attr"`
+	Path           string `xml:"xpath,attr"`
+	Classification string `xml:"classification,attr"`
 }
 
 // ComponentDiffElementRemove encodes the removal or an element
@@ -74,11 +95,32 @@ type ComponentDiffAttributeChange struct {
 	XMLName  xml.Name `xml:"changeattribute"`
 	OldValue string   `xml:"oldvalue"`
 	NewValue string   `xml:"newvalue"`
+	// OldValueIsEmpty and NewValueIsEmpty tell an attribute that is set to "" from one that is not set at all
+	OldValueIsEmpty bool `xml:"oldvalueisempty,attr,omitempty"`
+	NewValueIsEmpty bool `xml:"newvalueisempty,attr,omitempty"`
+}
+
+// hasOldValue returns true if the attribute was set before the change
+func (change ComponentDiffAttributeChange) hasOldValue() bool {
+	return change.OldValue != "" || change.OldValueIsEmpty
+}
+
+// ComponentDiffVersionCheck states whether the version bump between two component definitions is sufficient
+// for the classification of their differences under semantic versioning
+type ComponentDiffVersionCheck struct {
+	XMLName        xml.Name `xml:"versioncheck"`
+	OldVersion     string   `xml:"oldversion,attr"`
+	NewVersion     string   `xml:"newversion,attr"`
+	Classification string   `xml:"classification,attr"`
+	RequiredBump   string   `xml:"requiredbump,attr"`
+	ActualBump     string   `xml:"actualbump,attr"`
+	Sufficient     bool     `xml:"sufficient,attr"`
 }
 
 // ComponentDiff contains the difference between two component definitions
 type ComponentDiff struct {
 	XMLName            xml.Name                       `xml:"componentdiff"`
+	VersionCheck       ComponentDiffVersionCheck      `xml:"versioncheck"`
 	AttributeRemovals  []ComponentDiffAttributeRemove `xml:"removeattribute"`
 	AttributeAdditions []ComponentDiffAttributeAdd    `xml:"addattribute"`
 	AttributeChanges   []ComponentDiffAttributeChange `xml:"changeattribute"`
@@ -90,6 +132,27 @@ type ComponentDiff struct {
 type ComponentDiffableElement interface {
 }
 
+// diffDeprecation compares the deprecation of an element
+func diffDeprecation(path string, deprecationA ComponentDefinitionDeprecation, deprecationB ComponentDefinitionDeprecation) []ComponentDiffAttributeChange {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	if deprecationA.Deprecated != deprecationB.Deprecated {
+		var change ComponentDiffAttributeChange
+		change.Path = path + "/deprecated"
+		change.OldValue = deprecationA.Deprecated
+		change.NewValue = deprecationB.Deprecated
+		changes = append(changes, change)
+	}
+
+	if deprecationA.Replacement != deprecationB.Replacement {
+		var change ComponentDiffAttributeChange
+		change.Path = path + "/replacement"
+		change.OldValue = deprecationA.Replacement
+		change.NewValue = deprecationB.Replacement
+		changes = append(changes, change)
+	}
+	return changes
+}
+
 func diffParam(path string, paramA ComponentDefinitionParam, paramB ComponentDefinitionParam) ([]ComponentDiffAttributeChange, error) {
 	changes := make([]ComponentDiffAttributeChange, 0)
 
@@ -126,29 +189,32 @@ func diffParam(path string, paramA ComponentDefinitionParam, paramB ComponentDef
 		changes = append(changes, change)
 	}
 
+	if (paramA.HasDefault() != paramB.HasDefault()) || (paramA.DefaultValue() != paramB.DefaultValue()) {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/default"
+		change.OldValue = paramA.DefaultValue()
+		change.NewValue = paramB.DefaultValue()
+		change.OldValueIsEmpty = paramA.HasDefault() && (paramA.DefaultValue() == "")
+		change.NewValueIsEmpty = paramB.HasDefault() && (paramB.DefaultValue() == "")
+		changes = append(changes, change)
+	}
+	changes = append(changes, diffDeprecation(pathA, paramA.ComponentDefinitionDeprecation, paramB.ComponentDefinitionDeprecation)...)
+
 	return changes, nil
 }
 
-func diffMethod(path string, methodA ComponentDefinitionMethod, methodB ComponentDefinitionMethod) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+// diffParams compares the parameter lists of two methods or function types.
+// Parameters are matched by position up to the first renamed or moved parameter.
+func diffParams(pathA string, pathB string, paramsA []ComponentDefinitionParam, paramsB []ComponentDefinitionParam) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
 	changes := make([]ComponentDiffAttributeChange, 0)
 	adds := make([]ComponentDiffElementAdd, 0)
 	removes := make([]ComponentDiffElementRemove, 0)
 
-	pathA := path + "/method[@name='" + methodA.MethodName + "']"
-	pathB := path + "/method[@name='" + methodB.MethodName + "']"
-	if methodA.MethodDescription != methodB.MethodDescription {
-		var change ComponentDiffAttributeChange
-		change.Path = pathA + "/description"
-		change.OldValue = methodA.MethodDescription
-		change.NewValue = methodB.MethodDescription
-		changes = append(changes, change)
-	}
-
-	IFirstChangedParam := len(methodA.Params)
-	for iA, paramA := range methodA.Params {
+	IFirstChangedParam := len(paramsA)
+	for iA, paramA := range paramsA {
 		BHasParamA := false
-		if (iA < IFirstChangedParam) && (iA < len(methodB.Params)) {
-			paramB := methodB.Params[iA]
+		if (iA < IFirstChangedParam) && (iA < len(paramsB)) {
+			paramB := paramsB[iA]
 			if paramA.ParamName == paramB.ParamName {
 				Pchanges, err := diffParam(pathA, paramA, paramB)
 				if err != nil {
@@ -167,10 +233,10 @@ func diffMethod(path string, methodA ComponentDefinitionMethod, methodB Componen
 		}
 	}
 
-	for iB, paramB := range methodB.Params {
+	for iB, paramB := range paramsB {
 		AHasParamB := false
-		if (iB < IFirstChangedParam) && (iB < len(methodA.Params)) {
-			paramA := methodA.Params[iB]
+		if (iB < IFirstChangedParam) && (iB < len(paramsA)) {
+			paramA := paramsA[iB]
 			if paramA.ParamName == paramB.ParamName {
 				AHasParamB = true
 			}
@@ -185,6 +251,39 @@ func diffMethod(path string, methodA ComponentDefinitionMethod, methodB Componen
 	return adds, removes, changes, nil
 }
 
+func diffMethod(path string, methodA ComponentDefinitionMethod, methodB ComponentDefinitionMethod) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	pathA := path + "/method[@name='" + methodA.MethodName + "']"
+	pathB := path + "/method[@name='" + methodB.MethodName + "']"
+	if methodA.MethodDescription != methodB.MethodDescription {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = methodA.MethodDescription
+		change.NewValue = methodB.MethodDescription
+		changes = append(changes, change)
+	}
+	if methodA.IsStatic != methodB.IsStatic {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/static"
+		change.OldValue = strconv.FormatBool(methodA.IsStatic)
+		change.NewValue = strconv.FormatBool(methodB.IsStatic)
+		changes = append(changes, change)
+	}
+	changes = append(changes, diffDeprecation(pathA, methodA.ComponentDefinitionDeprecation, methodB.ComponentDefinitionDeprecation)...)
+
+	Padds, Premoves, Pchanges, err := diffParams(pathA, pathB, methodA.Params, methodB.Params)
+	if err != nil {
+		return adds, removes, changes, err
+	}
+	adds = append(adds, Padds...)
+	removes = append(removes, Premoves...)
+	changes = append(changes, Pchanges...)
+	return adds, removes, changes, nil
+}
+
 func diffClass(path string, classA ComponentDefinitionClass, classB ComponentDefinitionClass) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
 	changes := make([]ComponentDiffAttributeChange, 0)
 	adds := make([]ComponentDiffElementAdd, 0)
@@ -207,11 +306,17 @@ func diffClass(path string, classA ComponentDefinitionClass, classB ComponentDef
 		change.NewValue = classB.ParentClass
 		changes = append(changes, change)
 	}
+	changes = append(changes, diffDeprecation(pathA, classA.ComponentDefinitionDeprecation, classB.ComponentDefinitionDeprecation)...)
 
+	// The accessor methods of the properties are left to the comparison of the properties,
+	// so that a change of a property is reported once
 	for _, methodA := range classA.Methods {
+		if methodA.PropertyName != "" {
+			continue
+		}
 		BHasMethodA := false
 		for _, methodB := range classB.Methods {
-			if methodA.MethodName == methodB.MethodName {
+			if (methodB.PropertyName == "") && (methodA.MethodName == methodB.MethodName) {
 				BHasMethodA = true
 				Madds, Mremoves, Mchanges, err := diffMethod(pathA, methodA, methodB)
 				if err != nil {
@@ -225,16 +330,19 @@ func diffClass(path string, classA ComponentDefinitionClass, classB ComponentDef
 		}
 		if !BHasMethodA {
 			var remove ComponentDiffElementRemove
-			remove.Path = path
+			remove.Path = pathA
 			remove.Removal = methodA
 			removes = append(removes, remove)
 		}
 	}
 
 	for _, methodB := range classB.Methods {
+		if methodB.PropertyName != "" {
+			continue
+		}
 		AHasMethodB := false
 		for _, methodA := range classA.Methods {
-			if methodA.MethodName == methodB.MethodName {
+			if (methodA.PropertyName == "") && (methodA.MethodName == methodB.MethodName) {
 				AHasMethodB = true
 				break
 			}
@@ -247,9 +355,81 @@ func diffClass(path string, classA ComponentDefinitionClass, classB ComponentDef
 		}
 	}
 
+	for _, propertyA := range classA.Properties {
+		BHasPropertyA := false
+		for _, propertyB := range classB.Properties {
+			if propertyA.Name == propertyB.Name {
+				BHasPropertyA = true
+				changes = append(changes, diffProperty(pathA, propertyA, propertyB)...)
+				break
+			}
+		}
+		if !BHasPropertyA {
+			var remove ComponentDiffElementRemove
+			remove.Path = pathA
+			remove.Removal = propertyA
+			removes = append(removes, remove)
+		}
+	}
+
+	for _, propertyB := range classB.Properties {
+		AHasPropertyB := false
+		for _, propertyA := range classA.Properties {
+			if propertyA.Name == propertyB.Name {
+				AHasPropertyB = true
+				break
+			}
+		}
+		if !AHasPropertyB {
+			var add ComponentDiffElementAdd
+			add.Path = pathB
+			add.Addition = propertyB
+			adds = append(adds, add)
+		}
+	}
+
 	return adds, removes, changes, nil
 }
 
+func diffProperty(path string, propertyA ComponentDefinitionProperty, propertyB ComponentDefinitionProperty) []ComponentDiffAttributeChange {
+	changes := make([]ComponentDiffAttributeChange, 0)
+
+	pathA := path + "/property[@name='" + propertyA.Name + "']"
+	if propertyA.Type != propertyB.Type {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/type"
+		change.OldValue = propertyA.Type
+		change.NewValue = propertyB.Type
+		changes = append(changes, change)
+	}
+
+	if propertyA.Class != propertyB.Class {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/class"
+		change.OldValue = propertyA.Class
+		change.NewValue = propertyB.Class
+		changes = append(changes, change)
+	}
+
+	if propertyA.Access != propertyB.Access {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/access"
+		change.OldValue = propertyA.Access
+		change.NewValue = propertyB.Access
+		changes = append(changes, change)
+	}
+
+	if propertyA.Description != propertyB.Description {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = propertyA.Description
+		change.NewValue = propertyB.Description
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
 func diffClasses(path string, classesA []ComponentDefinitionClass, classesB []ComponentDefinitionClass) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
 	changes := make([]ComponentDiffAttributeChange, 0)
 	adds := make([]ComponentDiffElementAdd, 0)
@@ -304,6 +484,15 @@ func diffEnum(path string, enumA ComponentDefinitionEnum, enumB ComponentDefinit
 
 	pathA := path + "/enum[@name='" + enumA.Name + "']"
 	pathB := path + "/enum[@name='" + enumB.Name + "']"
+	changes = append(changes, diffDeprecation(pathA, enumA.ComponentDefinitionDeprecation, enumB.ComponentDefinitionDeprecation)...)
+
+	if enumA.Description != enumB.Description {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = enumA.Description
+		change.NewValue = enumB.Description
+		changes = append(changes, change)
+	}
 
 	for _, optionA := range enumA.Options {
 		BHasOptionA := false
@@ -312,17 +501,25 @@ func diffEnum(path string, enumA ComponentDefinitionEnum, enumB ComponentDefinit
 				BHasOptionA = true
 				if optionA.Value != optionB.Value {
 					var change ComponentDiffAttributeChange
-					change.Path = pathA + "/value"
-					change.OldValue = string(optionA.Value)
-					change.NewValue = string(optionB.Value)
+					change.Path = pathA + "/option[@name='" + optionA.Name + "']/value"
+					change.OldValue = strconv.Itoa(optionA.Value)
+					change.NewValue = strconv.Itoa(optionB.Value)
 					changes = append(changes, change)
 				}
+				if optionA.Description != optionB.Description {
+					var change ComponentDiffAttributeChange
+					change.Path = pathA + "/option[@name='" + optionA.Name + "']" + "/description"
+					change.OldValue = optionA.Description
+					change.NewValue = optionB.Description
+					changes = append(changes, change)
+				}
+				changes = append(changes, diffDeprecation(pathA+"/option[@name='"+optionA.Name+"']", optionA.ComponentDefinitionDeprecation, optionB.ComponentDefinitionDeprecation)...)
 				break
 			}
 		}
 		if !BHasOptionA {
 			var remove ComponentDiffElementRemove
-			remove.Path = path
+			remove.Path = pathA
 			remove.Removal = optionA
 			removes = append(removes, remove)
 		}
@@ -339,7 +536,7 @@ func diffEnum(path string, enumA ComponentDefinitionEnum, enumB ComponentDefinit
 		if !AHasOptionB {
 			var add ComponentDiffElementAdd
 			add.Path = pathB
-			add.Addition = enumB
+			add.Addition = optionB
 			adds = append(adds, add)
 		}
 	}
@@ -401,8 +598,8 @@ func diffError(path string, errorA ComponentDefinitionError, errorB ComponentDef
 	if errorA.Code != errorB.Code {
 		var change ComponentDiffAttributeChange
 		change.Path = pathA + "/code"
-		change.OldValue = string(errorA.Code)
-		change.NewValue = string(errorB.Code)
+		change.OldValue = strconv.Itoa(errorA.Code)
+		change.NewValue = strconv.Itoa(errorB.Code)
 		changes = append(changes, change)
 	}
 
@@ -413,6 +610,7 @@ func diffError(path string, errorA ComponentDefinitionError, errorB ComponentDef
 		change.NewValue = errorB.Description
 		changes = append(changes, change)
 	}
+	changes = append(changes, diffDeprecation(pathA, errorA.ComponentDefinitionDeprecation, errorB.ComponentDefinitionDeprecation)...)
 
 	return changes, nil
 }
@@ -462,6 +660,343 @@ func diffErrors(path string, errorsA []ComponentDefinitionError, errorsB []Compo
 	return adds, removes, changes, nil
 }
 
+func diffConstant(path string, constantA ComponentDefinitionConstant, constantB ComponentDefinitionConstant) ([]ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+
+	pathA := path + "/constant[@name='" + constantA.Name + "']"
+	if constantA.Type != constantB.Type {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/type"
+		change.OldValue = constantA.Type
+		change.NewValue = constantB.Type
+		changes = append(changes, change)
+	}
+
+	if constantA.Value != constantB.Value {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/value"
+		change.OldValue = constantA.Value
+		change.NewValue = constantB.Value
+		changes = append(changes, change)
+	}
+
+	if constantA.Description != constantB.Description {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = constantA.Description
+		change.NewValue = constantB.Description
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func diffConstants(path string, constantsA []ComponentDefinitionConstant, constantsB []ComponentDefinitionConstant) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	for _, constantA := range constantsA {
+		BHasConstantA := false
+		for _, constantB := range constantsB {
+			if constantA.Name == constantB.Name {
+				BHasConstantA = true
+				Cchanges, err := diffConstant(path, constantA, constantB)
+				if err != nil {
+					return adds, removes, changes, err
+				}
+				changes = append(changes, Cchanges...)
+				break
+			}
+		}
+		if !BHasConstantA {
+			var remove ComponentDiffElementRemove
+			remove.Path = path
+			remove.Removal = constantA
+			removes = append(removes, remove)
+		}
+	}
+
+	for _, constantB := range constantsB {
+		AHasConstantB := false
+		for _, constantA := range constantsA {
+			if constantB.Name == constantA.Name {
+				AHasConstantB = true
+				break
+			}
+		}
+		if !AHasConstantB {
+			var add ComponentDiffElementAdd
+			add.Path = path
+			add.Addition = constantB
+			adds = append(adds, add)
+		}
+	}
+
+	return adds, removes, changes, nil
+}
+
+func diffFunctionType(path string, functionA ComponentDefinitionFunctionType, functionB ComponentDefinitionFunctionType) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	pathA := path + "/functiontype[@name='" + functionA.FunctionName + "']"
+	pathB := path + "/functiontype[@name='" + functionB.FunctionName + "']"
+	if functionA.FunctionDescription != functionB.FunctionDescription {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = functionA.FunctionDescription
+		change.NewValue = functionB.FunctionDescription
+		changes = append(changes, change)
+	}
+
+	Padds, Premoves, Pchanges, err := diffParams(pathA, pathB, functionA.Params, functionB.Params)
+	if err != nil {
+		return adds, removes, changes, err
+	}
+	adds = append(adds, Padds...)
+	removes = append(removes, Premoves...)
+	changes = append(changes, Pchanges...)
+	return adds, removes, changes, nil
+}
+
+func diffFunctionTypes(path string, functionsA []ComponentDefinitionFunctionType, functionsB []ComponentDefinitionFunctionType) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	for _, functionA := range functionsA {
+		BHasFunctionA := false
+		for _, functionB := range functionsB {
+			if functionA.FunctionName == functionB.FunctionName {
+				BHasFunctionA = true
+				Fadds, Fremoves, Fchanges, err := diffFunctionType(path, functionA, functionB)
+				if err != nil {
+					return adds, removes, changes, err
+				}
+				adds = append(adds, Fadds...)
+				removes = append(removes, Fremoves...)
+				changes = append(changes, Fchanges...)
+				break
+			}
+		}
+		if !BHasFunctionA {
+			var remove ComponentDiffElementRemove
+			remove.Path = path
+			remove.Removal = functionA
+			removes = append(removes, remove)
+		}
+	}
+
+	for _, functionB := range functionsB {
+		AHasFunctionB := false
+		for _, functionA := range functionsA {
+			if functionA.FunctionName == functionB.FunctionName {
+				AHasFunctionB = true
+				break
+			}
+		}
+		if !AHasFunctionB {
+			var add ComponentDiffElementAdd
+			add.Path = path
+			add.Addition = functionB
+			adds = append(adds, add)
+		}
+	}
+
+	return adds, removes, changes, nil
+}
+
+func diffImportComponents(path string, importsA []ComponentDefinitionImportComponent, importsB []ComponentDefinitionImportComponent) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	for _, importA := range importsA {
+		BHasImportA := false
+		for _, importB := range importsB {
+			if importA.Namespace == importB.Namespace {
+				BHasImportA = true
+				if importA.URI != importB.URI {
+					var change ComponentDiffAttributeChange
+					change.Path = path + "/importcomponent[@namespace='" + importA.Namespace + "']/uri"
+					change.OldValue = importA.URI
+					change.NewValue = importB.URI
+					changes = append(changes, change)
+				}
+				break
+			}
+		}
+		if !BHasImportA {
+			var remove ComponentDiffElementRemove
+			remove.Path = path
+			remove.Removal = importA
+			removes = append(removes, remove)
+		}
+	}
+
+	for _, importB := range importsB {
+		AHasImportB := false
+		for _, importA := range importsA {
+			if importA.Namespace == importB.Namespace {
+				AHasImportB = true
+				break
+			}
+		}
+		if !AHasImportB {
+			var add ComponentDiffElementAdd
+			add.Path = path
+			add.Addition = importB
+			adds = append(adds, add)
+		}
+	}
+
+	return adds, removes, changes, nil
+}
+
+func diffBindings(path string, bindingsA []ComponentDefinitionBinding, bindingsB []ComponentDefinitionBinding) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	for _, bindingA := range bindingsA {
+		BHasBindingA := false
+		for _, bindingB := range bindingsB {
+			if bindingA.Language == bindingB.Language {
+				BHasBindingA = true
+				pathA := path + "/binding[@language='" + bindingA.Language + "']"
+				if bindingA.Indentation != bindingB.Indentation {
+					var change ComponentDiffAttributeChange
+					change.Path = pathA + "/indentation"
+					change.OldValue = bindingA.Indentation
+					change.NewValue = bindingB.Indentation
+					changes = append(changes, change)
+				}
+				if bindingA.ClassIdentifier != bindingB.ClassIdentifier {
+					var change ComponentDiffAttributeChange
+					change.Path = pathA + "/classidentifier"
+					change.OldValue = bindingA.ClassIdentifier
+					change.NewValue = bindingB.ClassIdentifier
+					changes = append(changes, change)
+				}
+				break
+			}
+		}
+		if !BHasBindingA {
+			var remove ComponentDiffElementRemove
+			remove.Path = path
+			remove.Removal = bindingA
+			removes = append(removes, remove)
+		}
+	}
+
+	for _, bindingB := range bindingsB {
+		AHasBindingB := false
+		for _, bindingA := range bindingsA {
+			if bindingA.Language == bindingB.Language {
+				AHasBindingB = true
+				break
+			}
+		}
+		if !AHasBindingB {
+			var add ComponentDiffElementAdd
+			add.Path = path
+			add.Addition = bindingB
+			adds = append(adds, add)
+		}
+	}
+
+	return adds, removes, changes, nil
+}
+
+func diffImplementations(path string, implementationsA []ComponentDefinitionImplementation, implementationsB []ComponentDefinitionImplementation) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+	adds := make([]ComponentDiffElementAdd, 0)
+	removes := make([]ComponentDiffElementRemove, 0)
+
+	for _, implementationA := range implementationsA {
+		BHasImplementationA := false
+		for _, implementationB := range implementationsB {
+			if implementationA.Language == implementationB.Language {
+				BHasImplementationA = true
+				pathA := path + "/implementation[@language='" + implementationA.Language + "']"
+				if implementationA.Indentation != implementationB.Indentation {
+					var change ComponentDiffAttributeChange
+					change.Path = pathA + "/indentation"
+					change.OldValue = implementationA.Indentation
+					change.NewValue = implementationB.Indentation
+					changes = append(changes, change)
+				}
+				if implementationA.ClassIdentifier != implementationB.ClassIdentifier {
+					var change ComponentDiffAttributeChange
+					change.Path = pathA + "/classidentifier"
+					change.OldValue = implementationA.ClassIdentifier
+					change.NewValue = implementationB.ClassIdentifier
+					changes = append(changes, change)
+				}
+				if implementationA.StubIdentifier != implementationB.StubIdentifier {
+					var change ComponentDiffAttributeChange
+					change.Path = pathA + "/stubidentifier"
+					change.OldValue = implementationA.StubIdentifier
+					change.NewValue = implementationB.StubIdentifier
+					changes = append(changes, change)
+				}
+				break
+			}
+		}
+		if !BHasImplementationA {
+			var remove ComponentDiffElementRemove
+			remove.Path = path
+			remove.Removal = implementationA
+			removes = append(removes, remove)
+		}
+	}
+
+	for _, implementationB := range implementationsB {
+		AHasImplementationB := false
+		for _, implementationA := range implementationsA {
+			if implementationA.Language == implementationB.Language {
+				AHasImplementationB = true
+				break
+			}
+		}
+		if !AHasImplementationB {
+			var add ComponentDiffElementAdd
+			add.Path = path
+			add.Addition = implementationB
+			adds = append(adds, add)
+		}
+	}
+
+	return adds, removes, changes, nil
+}
+
+func diffLicense(path string, licenseA ComponentDefinitionLicense, licenseB ComponentDefinitionLicense) ([]ComponentDiffAttributeChange, error) {
+	changes := make([]ComponentDiffAttributeChange, 0)
+
+	linesA := make([]string, 0)
+	for _, line := range licenseA.Lines {
+		linesA = append(linesA, line.Value)
+	}
+	linesB := make([]string, 0)
+	for _, line := range licenseB.Lines {
+		linesB = append(linesB, line.Value)
+	}
+
+	textA := strings.Join(linesA, "\n")
+	textB := strings.Join(linesB, "\n")
+	if textA != textB {
+		var change ComponentDiffAttributeChange
+		change.Path = path + "/license"
+		change.OldValue = textA
+		change.NewValue = textB
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
 func diffGlobal(path string, globalA ComponentDefinitionGlobal, globalB ComponentDefinitionGlobal) ([]ComponentDiffElementAdd, []ComponentDiffElementRemove, []ComponentDiffAttributeChange, error) {
 	changes := make([]ComponentDiffAttributeChange, 0)
 	adds := make([]ComponentDiffElementAdd, 0)
@@ -490,6 +1025,55 @@ func diffGlobal(path string, globalA ComponentDefinitionGlobal, globalB Componen
 		change.NewValue = globalB.VersionMethod
 		changes = append(changes, change)
 	}
+	if globalA.BaseClassName != globalB.BaseClassName {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/baseclassname"
+		change.OldValue = globalA.BaseClassName
+		change.NewValue = globalB.BaseClassName
+		changes = append(changes, change)
+	}
+	if globalA.ErrorMethod != globalB.ErrorMethod {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/errormethod"
+		change.OldValue = globalA.ErrorMethod
+		change.NewValue = globalB.ErrorMethod
+		changes = append(changes, change)
+	}
+	if globalA.AcquireMethod != globalB.AcquireMethod {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/acquiremethod"
+		change.OldValue = globalA.AcquireMethod
+		change.NewValue = globalB.AcquireMethod
+		changes = append(changes, change)
+	}
+	if globalA.SymbolLookupMethod != globalB.SymbolLookupMethod {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/symbollookupmethod"
+		change.OldValue = globalA.SymbolLookupMethod
+		change.NewValue = globalB.SymbolLookupMethod
+		changes = append(changes, change)
+	}
+	if globalA.InjectionMethod != globalB.InjectionMethod {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/injectionmethod"
+		change.OldValue = globalA.InjectionMethod
+		change.NewValue = globalB.InjectionMethod
+		changes = append(changes, change)
+	}
+	if globalA.PrereleaseMethod != globalB.PrereleaseMethod {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/prereleasemethod"
+		change.OldValue = globalA.PrereleaseMethod
+		change.NewValue = globalB.PrereleaseMethod
+		changes = append(changes, change)
+	}
+	if globalA.BuildinfoMethod != globalB.BuildinfoMethod {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/buildinfomethod"
+		change.OldValue = globalA.BuildinfoMethod
+		change.NewValue = globalB.BuildinfoMethod
+		changes = append(changes, change)
+	}
 
 	for _, methodA := range globalA.Methods {
 		BHasMethodA := false
@@ -508,7 +1092,7 @@ func diffGlobal(path string, globalA ComponentDefinitionGlobal, globalB Componen
 		}
 		if !BHasMethodA {
 			var remove ComponentDiffElementRemove
-			remove.Path = path
+			remove.Path = pathA
 			remove.Removal = methodA
 			removes = append(removes, remove)
 		}
@@ -556,16 +1140,24 @@ func diffMember(path string, memberA ComponentDefinitionMember, memberB Componen
 	if memberA.Columns != memberB.Columns {
 		var change ComponentDiffAttributeChange
 		change.Path = pathA + "/columns"
-		change.OldValue = string(memberA.Columns)
-		change.NewValue = string(memberB.Columns)
+		change.OldValue = strconv.Itoa(memberA.Columns)
+		change.NewValue = strconv.Itoa(memberB.Columns)
 		changes = append(changes, change)
 	}
 
 	if memberA.Rows != memberB.Rows {
 		var change ComponentDiffAttributeChange
 		change.Path = pathA + "/rows"
-		change.OldValue = string(memberA.Rows)
-		change.NewValue = string(memberB.Rows)
+		change.OldValue = strconv.Itoa(memberA.Rows)
+		change.NewValue = strconv.Itoa(memberB.Rows)
+		changes = append(changes, change)
+	}
+
+	if memberA.Description != memberB.Description {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = memberA.Description
+		change.NewValue = memberB.Description
 		changes = append(changes, change)
 	}
 
@@ -577,8 +1169,16 @@ func diffStruct(path string, structA ComponentDefinitionStruct, structB Componen
 	adds := make([]ComponentDiffElementAdd, 0)
 	removes := make([]ComponentDiffElementRemove, 0)
 
-	pathA := path + "/structA[@name='" + structA.Name + "']"
-	pathB := path + "/structB[@name='" + structB.Name + "']"
+	pathA := path + "/struct[@name='" + structA.Name + "']"
+	pathB := path + "/struct[@name='" + structB.Name + "']"
+
+	if structA.Description != structB.Description {
+		var change ComponentDiffAttributeChange
+		change.Path = pathA + "/description"
+		change.OldValue = structA.Description
+		change.NewValue = structB.Description
+		changes = append(changes, change)
+	}
 
 	IFirstChangedMember := len(structA.Members)
 	for iA, memberA := range structA.Members {
@@ -596,7 +1196,7 @@ func diffStruct(path string, structA ComponentDefinitionStruct, structB Componen
 		}
 		if !BHasMemberA {
 			var remove ComponentDiffElementRemove
-			remove.Path = path
+			remove.Path = pathA
 			remove.Removal = memberA
 			removes = append(removes, remove)
 		}
@@ -674,8 +1274,15 @@ func diffComponentAttributes(path string, componentA ComponentDefinition, compon
 	if componentA.Year != componentB.Year {
 		var change ComponentDiffAttributeChange
 		change.Path = path + "/year"
-		change.OldValue = string(componentA.Year)
-		change.NewValue = string(componentB.Year)
+		change.OldValue = strconv.Itoa(componentA.Year)
+		change.NewValue = strconv.Itoa(componentB.Year)
+		changes = append(changes, change)
+	}
+	if componentA.Copyright != componentB.Copyright {
+		var change ComponentDiffAttributeChange
+		change.Path = path + "/copyright"
+		change.OldValue = componentA.Copyright
+		change.NewValue = componentB.Copyright
 		changes = append(changes, change)
 	}
 	if componentA.NameSpace != componentB.NameSpace {
@@ -708,15 +1315,43 @@ func DiffComponentDefinitions(A ComponentDefinition, B ComponentDefinition) (Com
 
 	path := "/component"
 
-	changes, err := diffComponentAttributes(path, A, B)
+	attributeChanges, err := diffComponentAttributes(path, A, B)
+	if err != nil {
+		return diff, err
+	}
+	diff.AttributeChanges = append(diff.AttributeChanges, attributeChanges...)
+
+	attributeChanges, err = diffLicense(path, A.License, B.License)
+	if err != nil {
+		return diff, err
+	}
+	diff.AttributeChanges = append(diff.AttributeChanges, attributeChanges...)
+
+	adds, removes, changes, err := diffBindings(path+"/bindings", A.BindingList.Bindings, B.BindingList.Bindings)
+	if err != nil {
+		return diff, err
+	}
+	diff.ElementAdditions = append(diff.ElementAdditions, adds...)
+	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
+	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
+
+	adds, removes, changes, err = diffImplementations(path+"/implementations", A.ImplementationList.Implementations, B.ImplementationList.Implementations)
 	if err != nil {
 		return diff, err
 	}
+	diff.ElementAdditions = append(diff.ElementAdditions, adds...)
+	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
+	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
 
-	// TODO: check license
-	// TODO: check bindings(!?)
+	adds, removes, changes, err = diffImportComponents(path, A.ImportComponents, B.ImportComponents)
+	if err != nil {
+		return diff, err
+	}
+	diff.ElementAdditions = append(diff.ElementAdditions, adds...)
+	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
+	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
 
-	adds, removes, changes, err := diffGlobal(path, A.Global, B.Global)
+	adds, removes, changes, err = diffGlobal(path, A.Global, B.Global)
 	if err != nil {
 		return diff, err
 	}
@@ -740,7 +1375,15 @@ func DiffComponentDefinitions(A ComponentDefinition, B ComponentDefinition) (Com
 	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
 	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
 
-	adds, removes, changes, err = diffErrors(path+"/Errors", A.Errors.Errors, B.Errors.Errors)
+	adds, removes, changes, err = diffErrors(path+"/errors", A.Errors.Errors, B.Errors.Errors)
+	if err != nil {
+		return diff, err
+	}
+	diff.ElementAdditions = append(diff.ElementAdditions, adds...)
+	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
+	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
+
+	adds, removes, changes, err = diffConstants(path, A.Constants, B.Constants)
 	if err != nil {
 		return diff, err
 	}
@@ -755,5 +1398,216 @@ func DiffComponentDefinitions(A ComponentDefinition, B ComponentDefinition) (Com
 	diff.ElementAdditions = append(diff.ElementAdditions, adds...)
 	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
 	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
+
+	adds, removes, changes, err = diffFunctionTypes(path, A.Functions, B.Functions)
+	if err != nil {
+		return diff, err
+	}
+	diff.ElementAdditions = append(diff.ElementAdditions, adds...)
+	diff.ElementRemovals = append(diff.ElementRemovals, removes...)
+	diff.AttributeChanges = append(diff.AttributeChanges, changes...)
+
+	diff.classify()
+	diff.VersionCheck, err = checkVersionBump(A.Version, B.Version, diff.Classification())
+	if err != nil {
+		return diff, err
+	}
 	return diff, nil
 }
+
+// isInformativePath returns true if a path refers to a part of a component definition that does not
+// affect the interface seen by consumers of the component, i.e. its license and its implementations
+func isInformativePath(path string) bool {
+	return strings.HasPrefix(path, "/component/license") || strings.HasPrefix(path, "/component/implementations")
+}
+
+// classifyAttribute classifies the change of a scalar attribute by its path.
+// Descriptions, deprecations and informative attributes do not affect the binary interface, and introducing
+// one of the optional special methods or the default of a parameter, or making a read-only property writable,
+// only adds to it. Everything else breaks it.
+func classifyAttribute(path string, oldValue string, hasOldValue bool) string {
+	if isInformativePath(path) {
+		return eDiffClassificationCosmetic
+	}
+	attribute := path[strings.LastIndex(path, "/")+1:]
+	switch attribute {
+	case "description", "year", "copyright", "libraryname", "indentation", "uri", "deprecated", "replacement":
+		return eDiffClassificationCosmetic
+	case "journalmethod", "symbollookupmethod", "injectionmethod", "prereleasemethod", "buildinfomethod", "default":
+		if !hasOldValue {
+			return eDiffClassificationAdditive
+		}
+	case "access":
+		if oldValue == ePropertyAccessRead {
+			return eDiffClassificationAdditive
+		}
+	}
+	return eDiffClassificationBreaking
+}
+
+// classifyRemoval classifies the removal of an element
+func classifyRemoval(path string) string {
+	if isInformativePath(path) {
+		return eDiffClassificationCosmetic
+	}
+	return eDiffClassificationBreaking
+}
+
+// classifyAddition classifies the addition of an element.
+// Adding a parameter to an existing method or a member to an existing struct changes its binary layout,
+// all other additions are backwards compatible.
+func classifyAddition(path string, element ComponentDiffableElement) string {
+	if isInformativePath(path) {
+		return eDiffClassificationCosmetic
+	}
+	switch element.(type) {
+	case ComponentDefinitionParam, ComponentDefinitionMember:
+		return eDiffClassificationBreaking
+	}
+	return eDiffClassificationAdditive
+}
+
+func classificationRank(classification string) int {
+	switch classification {
+	case eDiffClassificationCosmetic:
+		return 1
+	case eDiffClassificationAdditive:
+		return 2
+	case eDiffClassificationBreaking:
+		return 3
+	}
+	return 0
+}
+
+// classify sets the classification of every change in the diff
+func (diff *ComponentDiff) classify() {
+	for i := range diff.AttributeRemovals {
+		diff.AttributeRemovals[i].Classification = eDiffClassificationBreaking
+	}
+	for i := range diff.AttributeAdditions {
+		diff.AttributeAdditions[i].Classification = classifyAttribute(diff.AttributeAdditions[i].Path, "", false)
+	}
+	for i := range diff.AttributeChanges {
+		change := diff.AttributeChanges[i]
+		diff.AttributeChanges[i].Classification = classifyAttribute(change.Path, change.OldValue, change.hasOldValue())
+	}
+	for i := range diff.ElementRemovals {
+		diff.ElementRemovals[i].Classification = classifyRemoval(diff.ElementRemovals[i].Path)
+	}
+	for i := range diff.ElementAdditions {
+		diff.ElementAdditions[i].Classification = classifyAddition(diff.ElementAdditions[i].Path, diff.ElementAdditions[i].Addition)
+	}
+}
+
+// Classifications returns the classifications of all changes in the diff
+func (diff ComponentDiff) Classifications() []string {
+	classifications := make([]string, 0)
+	for _, change := range diff.AttributeRemovals {
+		classifications = append(classifications, change.Classification)
+	}
+	for _, change := range diff.AttributeAdditions {
+		classifications = append(classifications, change.Classification)
+	}
+	for _, change := range diff.AttributeChanges {
+		classifications = append(classifications, change.Classification)
+	}
+	for _, change := range diff.ElementRemovals {
+		classifications = append(classifications, change.Classification)
+	}
+	for _, change := range diff.ElementAdditions {
+		classifications = append(classifications, change.Classification)
+	}
+	return classifications
+}
+
+// CountClassification returns the number of changes in the diff with a given classification
+func (diff ComponentDiff) CountClassification(classification string) int {
+	count := 0
+	for _, changeClassification := range diff.Classifications() {
+		if changeClassification == classification {
+			count++
+		}
+	}
+	return count
+}
+
+// Classification returns the most severe classification of all changes in the diff
+func (diff ComponentDiff) Classification() string {
+	result := eDiffClassificationNone
+	for _, classification := range diff.Classifications() {
+		if classificationRank(classification) > classificationRank(result) {
+			result = classification
+		}
+	}
+	return result
+}
+
+// versionBump returns the kind of bump from one semantic version to another
+func versionBump(versionA string, versionB string) (string, error) {
+	isValidA, numbersA, _ := decomposeVersionString(versionA)
+	if !isValidA {
+		return "", fmt.Errorf("invalid component version \"%s\"", versionA)
+	}
+	isValidB, numbersB, _ := decomposeVersionString(versionB)
+	if !isValidB {
+		return "", fmt.Errorf("invalid component version \"%s\"", versionB)
+	}
+	bumps := []string{eVersionBumpMajor, eVersionBumpMinor, eVersionBumpMicro}
+	for i := range numbersA {
+		if numbersB[i] > numbersA[i] {
+			return bumps[i], nil
+		}
+		if numbersB[i] < numbersA[i] {
+			return eVersionBumpDowngrade, nil
+		}
+	}
+	return eVersionBumpNone, nil
+}
+
+func versionBumpRank(bump string) int {
+	switch bump {
+	case eVersionBumpDowngrade:
+		return -1
+	case eVersionBumpMicro:
+		return 1
+	case eVersionBumpMinor:
+		return 2
+	case eVersionBumpMajor:
+		return 3
+	}
+	return 0
+}
+
+// checkVersionBump decides whether the bump from versionA to versionB suffices for changes of a given classification.
+// Breaking changes require a new major version, additive changes a new minor version and cosmetic changes a new micro version.
+// Before version 1.0.0, a new minor version suffices for breaking changes.
+func checkVersionBump(versionA string, versionB string, classification string) (ComponentDiffVersionCheck, error) {
+	var check ComponentDiffVersionCheck
+	check.OldVersion = versionA
+	check.NewVersion = versionB
+	check.Classification = classification
+
+	actualBump, err := versionBump(versionA, versionB)
+	if err != nil {
+		return check, err
+	}
+	check.ActualBump = actualBump
+
+	switch classification {
+	case eDiffClassificationBreaking:
+		if majorVersion(versionA) == 0 {
+			check.RequiredBump = eVersionBumpMinor
+		} else {
+			check.RequiredBump = eVersionBumpMajor
+		}
+	case eDiffClassificationAdditive:
+		check.RequiredBump = eVersionBumpMinor
+	case eDiffClassificationCosmetic:
+		check.RequiredBump = eVersionBumpMicro
+	default:
+		check.RequiredBump = eVersionBumpNone
+	}
+
+	check.Sufficient = (actualBump != eVersionBumpDowngrade) && (versionBumpRank(actualBump) >= versionBumpRank(check.RequiredBump))
+	return check, nil
+}
@@ -0,0 +1,413 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// componentdiffreport.go
+// contains the writers of component diffs in XML, JSON, Markdown and plain text
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Change kinds of a diff report entry
+const (
+	eDiffChangeAdded   = "added"
+	eDiffChangeRemoved = "removed"
+	eDiffChangeChanged = "changed"
+)
+
+// ComponentDiffReportEntry is a single change of a component diff in a form that is independent of the diff's XML model
+type ComponentDiffReportEntry struct {
+	Change         string `json:"change"`
+	Classification string `json:"classification"`
+	Path           string `json:"path"`
+	Group          string `json:"group"`
+	Element        string `json:"element,omitempty"`
+	Name           string `json:"name,omitempty"`
+	OldValue       string `json:"oldValue,omitempty"`
+	NewValue       string `json:"newValue,omitempty"`
+	// OldValueIsEmpty and NewValueIsEmpty tell a value that is set to "" from one that is not set at all
+	OldValueIsEmpty bool `json:"oldValueIsEmpty,omitempty"`
+	NewValueIsEmpty bool `json:"newValueIsEmpty,omitempty"`
+}
+
+// componentDiffPathSegment is a step of an XPath in a component diff, e.g. method[@name='GetValue']
+type componentDiffPathSegment struct {
+	Element string
+	Name    string
+}
+
+var componentDiffPathSegmentRegExp = regexp.MustCompile(`^([a-z]+)(\[@[a-z]+='([^']*)'\])?$`)
+
+func parseComponentDiffPath(path string) []componentDiffPathSegment {
+	segments := make([]componentDiffPathSegment, 0)
+	for _, step := range strings.Split(strings.TrimPrefix(path, "/component"), "/") {
+		if step == "" {
+			continue
+		}
+		match := componentDiffPathSegmentRegExp.FindStringSubmatch(step)
+		if match == nil {
+			segments = append(segments, componentDiffPathSegment{Element: step})
+		} else {
+			segments = append(segments, componentDiffPathSegment{Element: match[1], Name: match[3]})
+		}
+	}
+	return segments
+}
+
+// describeDiffableElement returns the element name and the identifying name of an element of a component definition
+func describeDiffableElement(element ComponentDiffableElement) (string, string, string) {
+	switch e := element.(type) {
+	case ComponentDefinitionClass:
+		return "class", "name", e.ClassName
+	case ComponentDefinitionMethod:
+		return "method", "name", e.MethodName
+	case ComponentDefinitionProperty:
+		return "property", "name", e.Name
+	case ComponentDefinitionParam:
+		return "param", "name", e.ParamName
+	case ComponentDefinitionFunctionType:
+		return "functiontype", "name", e.FunctionName
+	case ComponentDefinitionEnum:
+		return "enum", "name", e.Name
+	case ComponentDefinitionEnumOption:
+		return "option", "name", e.Name
+	case ComponentDefinitionError:
+		return "error", "name", e.Name
+	case ComponentDefinitionConstant:
+		return "constant", "name", e.Name
+	case ComponentDefinitionStruct:
+		return "struct", "name", e.Name
+	case ComponentDefinitionMember:
+		return "member", "name", e.Name
+	case ComponentDefinitionImportComponent:
+		return "importcomponent", "namespace", e.Namespace
+	case ComponentDefinitionBinding:
+		return "binding", "language", e.Language
+	case ComponentDefinitionImplementation:
+		return "implementation", "language", e.Language
+	}
+	return "element", "name", ""
+}
+
+// readableElementName returns the name of an IDL element as used in reports
+func readableElementName(element string) string {
+	switch element {
+	case "param":
+		return "parameter"
+	case "functiontype":
+		return "function type"
+	case "importcomponent":
+		return "imported component"
+	case "global":
+		return "global"
+	}
+	return element
+}
+
+// componentDiffGroup returns the heading under which a change at a given path is reported
+func componentDiffGroup(segments []componentDiffPathSegment) string {
+	if len(segments) == 0 {
+		return "Component"
+	}
+	switch segments[0].Element {
+	case "class":
+		return "Class " + segments[0].Name
+	case "enum":
+		return "Enum " + segments[0].Name
+	case "struct":
+		return "Struct " + segments[0].Name
+	case "functiontype":
+		return "Function type " + segments[0].Name
+	case "global":
+		return "Global methods"
+	case "errors":
+		return "Errors"
+	case "constant":
+		return "Constants"
+	}
+	return "Component"
+}
+
+func newComponentDiffReportEntry(change string, classification string, path string, element ComponentDiffableElement) ComponentDiffReportEntry {
+	var entry ComponentDiffReportEntry
+	entry.Change = change
+	entry.Classification = classification
+	entry.Path = path
+	groupPath := path
+	if element != nil {
+		elementName, attributeName, name := describeDiffableElement(element)
+		entry.Element = elementName
+		entry.Name = name
+		groupPath = path + "/" + elementName + "[@" + attributeName + "='" + name + "']"
+	}
+	entry.Group = componentDiffGroup(parseComponentDiffPath(groupPath))
+	return entry
+}
+
+// ReportEntries returns all changes of the diff, grouped by the class, enum, struct or function type they affect
+// and ordered by their classification within each group
+func (diff ComponentDiff) ReportEntries() []ComponentDiffReportEntry {
+	entries := make([]ComponentDiffReportEntry, 0)
+	for _, change := range diff.AttributeChanges {
+		entry := newComponentDiffReportEntry(eDiffChangeChanged, change.Classification, change.Path, nil)
+		entry.OldValue = change.OldValue
+		entry.NewValue = change.NewValue
+		entry.OldValueIsEmpty = change.OldValueIsEmpty
+		entry.NewValueIsEmpty = change.NewValueIsEmpty
+		entries = append(entries, entry)
+	}
+	for _, change := range diff.AttributeAdditions {
+		entries = append(entries, newComponentDiffReportEntry(eDiffChangeAdded, change.Classification, change.Path, nil))
+	}
+	for _, change := range diff.AttributeRemovals {
+		entries = append(entries, newComponentDiffReportEntry(eDiffChangeRemoved, change.Classification, change.Path, nil))
+	}
+	for _, change := range diff.ElementRemovals {
+		entries = append(entries, newComponentDiffReportEntry(eDiffChangeRemoved, change.Classification, change.Path, change.Removal))
+	}
+	for _, change := range diff.ElementAdditions {
+		entries = append(entries, newComponentDiffReportEntry(eDiffChangeAdded, change.Classification, change.Path, change.Addition))
+	}
+
+	groups := make([]string, 0)
+	groupEntries := make(map[string][]ComponentDiffReportEntry)
+	for _, entry := range entries {
+		if _, ok := groupEntries[entry.Group]; !ok {
+			groups = append(groups, entry.Group)
+		}
+		groupEntries[entry.Group] = append(groupEntries[entry.Group], entry)
+	}
+
+	result := make([]ComponentDiffReportEntry, 0, len(entries))
+	for _, group := range groups {
+		for rank := classificationRank(eDiffClassificationBreaking); rank >= 0; rank-- {
+			for _, entry := range groupEntries[group] {
+				if classificationRank(entry.Classification) == rank {
+					result = append(result, entry)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Sentence describes a change in a single sentence, using quote to mark names and values
+func (entry ComponentDiffReportEntry) Sentence(quote func(string) string) string {
+	segments := parseComponentDiffPath(entry.Path)
+	if len(segments) > 0 && componentDiffGroup(segments) != "Component" {
+		// the group is named in the heading already, unless the change is an attribute of the group element itself
+		isGroupAttribute := entry.Element == "" && len(segments) == 2 && segments[1].Name == ""
+		if !isGroupAttribute {
+			segments = segments[1:]
+		}
+	}
+
+	attribute := ""
+	if entry.Element == "" && len(segments) > 0 && segments[len(segments)-1].Name == "" {
+		attribute = segments[len(segments)-1].Element
+		segments = segments[:len(segments)-1]
+	}
+
+	context := make([]string, 0)
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i].Name == "" {
+			context = append(context, readableElementName(segments[i].Element))
+		} else {
+			context = append(context, readableElementName(segments[i].Element)+" "+quote(segments[i].Name))
+		}
+	}
+	of := strings.Join(context, " of ")
+
+	switch entry.Change {
+	case eDiffChangeAdded, eDiffChangeRemoved:
+		subject := readableElementName(entry.Element) + " " + quote(entry.Name)
+		if entry.Element == "" {
+			subject = attribute
+		}
+		verb := "Added"
+		preposition := " to "
+		if entry.Change == eDiffChangeRemoved {
+			verb = "Removed"
+			preposition = " from "
+		}
+		if of == "" {
+			return verb + " " + subject
+		}
+		return verb + " " + subject + preposition + of
+	}
+
+	subject := attribute
+	if of != "" {
+		subject = attribute + " of " + of
+	}
+	oldValue := strings.Replace(entry.OldValue, "\n", " ", -1)
+	newValue := strings.Replace(entry.NewValue, "\n", " ", -1)
+	if (oldValue == "") && !entry.OldValueIsEmpty {
+		return fmt.Sprintf("Set %s to %s", subject, quote(newValue))
+	}
+	if (newValue == "") && !entry.NewValueIsEmpty {
+		return fmt.Sprintf("Cleared %s (was %s)", subject, quote(oldValue))
+	}
+	return fmt.Sprintf("Changed %s from %s to %s", subject, quote(oldValue), quote(newValue))
+}
+
+// versionCheckSentence summarizes the version check of the diff
+func (diff ComponentDiff) versionCheckSentence() string {
+	check := diff.VersionCheck
+	if check.ActualBump == eVersionBumpDowngrade {
+		return fmt.Sprintf("The version was downgraded from %s to %s, which is NOT sufficient.", check.OldVersion, check.NewVersion)
+	}
+	if check.Classification == eDiffClassificationNone {
+		if check.ActualBump == eVersionBumpNone {
+			return fmt.Sprintf("There are no changes and the version %s was not bumped.", check.OldVersion)
+		}
+		return fmt.Sprintf("There are no changes, the %s version bump from %s to %s is not required.", check.ActualBump, check.OldVersion, check.NewVersion)
+	}
+	if check.ActualBump == eVersionBumpNone {
+		return fmt.Sprintf("The version %s was not bumped, which is NOT sufficient for %s changes, a %s version bump is required.", check.OldVersion, check.Classification, check.RequiredBump)
+	}
+	if check.Sufficient {
+		return fmt.Sprintf("The %s version bump from %s to %s is sufficient for %s changes.", check.ActualBump, check.OldVersion, check.NewVersion, check.Classification)
+	}
+	return fmt.Sprintf("The %s version bump from %s to %s is NOT sufficient for %s changes, a %s version bump is required.", check.ActualBump, check.OldVersion, check.NewVersion, check.Classification, check.RequiredBump)
+}
+
+// markdownCodeSpan returns text as Markdown code span. The span is delimited by a backtick run that is longer
+// than any backtick run within text, so that backticks in names and values are printed verbatim
+func markdownCodeSpan(text string) string {
+	longestRun := 0
+	run := 0
+	for _, c := range text {
+		if c == '`' {
+			run++
+			if run > longestRun {
+				longestRun = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	if longestRun == 0 {
+		return "`" + text + "`"
+	}
+	fence := strings.Repeat("`", longestRun+1)
+	return fence + " " + text + " " + fence
+}
+
+// WriteXML writes the diff as an XML document
+func (diff ComponentDiff) WriteXML(w io.Writer) error {
+	output, err := xml.MarshalIndent(diff, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// WriteJSON writes the diff as a JSON document
+func (diff ComponentDiff) WriteJSON(w io.Writer) error {
+	type jsonReport struct {
+		OldVersion     string                     `json:"oldVersion"`
+		NewVersion     string                     `json:"newVersion"`
+		Classification string                     `json:"classification"`
+		RequiredBump   string                     `json:"requiredBump"`
+		ActualBump     string                     `json:"actualBump"`
+		Sufficient     bool                       `json:"sufficient"`
+		Changes        []ComponentDiffReportEntry `json:"changes"`
+	}
+	report := jsonReport{
+		OldVersion:     diff.VersionCheck.OldVersion,
+		NewVersion:     diff.VersionCheck.NewVersion,
+		Classification: diff.VersionCheck.Classification,
+		RequiredBump:   diff.VersionCheck.RequiredBump,
+		ActualBump:     diff.VersionCheck.ActualBump,
+		Sufficient:     diff.VersionCheck.Sufficient,
+		Changes:        diff.ReportEntries(),
+	}
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// WriteMarkdown writes the diff as a release notes section, with one subsection per affected class, enum, struct or function type
+func (diff ComponentDiff) WriteMarkdown(w io.Writer) error {
+	fmt.Fprintf(w, "## Changes from %s to %s\n", diff.VersionCheck.OldVersion, diff.VersionCheck.NewVersion)
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "%d breaking, %d additive and %d cosmetic change(s). %s\n",
+		diff.CountClassification(eDiffClassificationBreaking),
+		diff.CountClassification(eDiffClassificationAdditive),
+		diff.CountClassification(eDiffClassificationCosmetic),
+		diff.versionCheckSentence())
+
+	group := ""
+	for _, entry := range diff.ReportEntries() {
+		if entry.Group != group {
+			group = entry.Group
+			fmt.Fprintln(w, "")
+			fmt.Fprintf(w, "### %s\n", group)
+			fmt.Fprintln(w, "")
+		}
+		fmt.Fprintf(w, "- **%s%s:** %s\n", strings.ToUpper(entry.Classification[:1]), entry.Classification[1:], entry.Sentence(markdownCodeSpan))
+	}
+	return nil
+}
+
+// WriteText writes the diff as plain text, with one line per change
+func (diff ComponentDiff) WriteText(w io.Writer) error {
+	quote := func(s string) string {
+		return "\"" + s + "\""
+	}
+	fmt.Fprintf(w, "Changes from %s to %s: %d breaking, %d additive, %d cosmetic\n", diff.VersionCheck.OldVersion, diff.VersionCheck.NewVersion,
+		diff.CountClassification(eDiffClassificationBreaking),
+		diff.CountClassification(eDiffClassificationAdditive),
+		diff.CountClassification(eDiffClassificationCosmetic))
+	fmt.Fprintln(w, diff.versionCheckSentence())
+
+	group := ""
+	for _, entry := range diff.ReportEntries() {
+		if entry.Group != group {
+			group = entry.Group
+			fmt.Fprintln(w, "")
+			fmt.Fprintf(w, "%s:\n", group)
+		}
+		fmt.Fprintf(w, "  %-9s %s\n", entry.Classification, entry.Sentence(quote))
+	}
+	return nil
+}
@@ -0,0 +1,645 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// componentformats.go
+// reads and writes component definitions as JSON and YAML documents, and writes them as XML.
+// Both formats map onto the same structures as the XML format, see Documentation/IDL.md.
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	eIDLFormatXML  = "xml"
+	eIDLFormatJSON = "json"
+	eIDLFormatYAML = "yaml"
+)
+
+// actIDLXMLNameSpace is the XML namespace of IDL files
+const actIDLXMLNameSpace = "http://schemas.autodesk.com/netfabb/automaticcomponenttoolkit/2018"
+
+// idlFormatOfFile returns the format of an IDL file from its extension. Unknown extensions are read as XML.
+func idlFormatOfFile(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		return eIDLFormatJSON
+	case ".yaml", ".yml":
+		return eIDLFormatYAML
+	}
+	return eIDLFormatXML
+}
+
+const (
+	eIDLNodeScalar = iota
+	eIDLNodeMapping
+	eIDLNodeSequence
+)
+
+// idlNode is a node of a JSON or YAML document together with its position in the document
+type idlNode struct {
+	Kind     int
+	Value    string
+	IsString bool
+	Keys     []string
+	Values   []*idlNode
+	Items    []*idlNode
+	Location SourceLocation
+
+	KeyLocations []SourceLocation
+}
+
+func (node *idlNode) add(key string, keyLocation SourceLocation, value *idlNode) {
+	node.Keys = append(node.Keys, key)
+	node.KeyLocations = append(node.KeyLocations, keyLocation)
+	node.Values = append(node.Values, value)
+}
+
+func (node *idlNode) isEmpty() bool {
+	switch node.Kind {
+	case eIDLNodeMapping:
+		return len(node.Keys) == 0
+	case eIDLNodeSequence:
+		return len(node.Items) == 0
+	}
+	return false
+}
+
+// idlField is a field of a component definition structure that is part of the IDL
+type idlField struct {
+	Index     []int
+	XMLName   string
+	Key       string
+	IsAttr    bool
+	OmitEmpty bool
+}
+
+// pluralizeIDLName returns the key of a list of elements in JSON and YAML documents, e.g. "classes" for "class"
+func pluralizeIDLName(name string) string {
+	if strings.HasSuffix(name, "s") {
+		return name + "es"
+	}
+	if strings.HasSuffix(name, "y") {
+		return strings.TrimSuffix(name, "y") + "ies"
+	}
+	return name + "s"
+}
+
+// getIDLFields returns the fields of a structure that have an XML attribute or element, followed by
+// the fields of embedded structures. Their key in JSON and YAML documents is the name of the XML attribute
+// or element, or its plural for lists, unless the field has a json tag.
+func getIDLFields(structType reflect.Type) []idlField {
+	fields := []idlField{}
+	embeddedFields := []idlField{}
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		xmlTag := structField.Tag.Get("xml")
+		if structField.Anonymous && (structField.Type.Kind() == reflect.Struct) && (xmlTag != "-") {
+			for _, embeddedField := range getIDLFields(structField.Type) {
+				embeddedField.Index = append([]int{i}, embeddedField.Index...)
+				embeddedFields = append(embeddedFields, embeddedField)
+			}
+			continue
+		}
+		if structField.Anonymous || (structField.Name == "XMLName") || (xmlTag == "") || (xmlTag == "-") {
+			continue
+		}
+		xmlOptions := strings.Split(xmlTag, ",")
+		field := idlField{Index: []int{i}, XMLName: xmlOptions[0], Key: xmlOptions[0]}
+		field.IsAttr = (len(xmlOptions) > 1) && (xmlOptions[1] == "attr")
+		for _, option := range xmlOptions[1:] {
+			field.OmitEmpty = field.OmitEmpty || (option == "omitempty")
+		}
+		if structField.Type.Kind() == reflect.Slice {
+			field.Key = pluralizeIDLName(field.XMLName)
+		}
+		jsonOptions := strings.Split(structField.Tag.Get("json"), ",")
+		if jsonOptions[0] != "" {
+			field.Key = jsonOptions[0]
+		}
+		for _, option := range jsonOptions[1:] {
+			field.OmitEmpty = field.OmitEmpty || (option == "omitempty")
+		}
+		fields = append(fields, field)
+	}
+	return append(fields, embeddedFields...)
+}
+
+// isIDLListType returns true for structures that only hold a list of elements, like <errors>.
+// JSON and YAML documents write them as this list.
+func isIDLListType(structType reflect.Type) bool {
+	fields := getIDLFields(structType)
+	return (len(fields) == 1) && !fields[0].IsAttr && (structType.FieldByIndex(fields[0].Index).Type.Kind() == reflect.Slice)
+}
+
+// isIDLValueType returns true for structures that only hold a single attribute, like a <line> of the license.
+// JSON and YAML documents write them as this value.
+func isIDLValueType(structType reflect.Type) bool {
+	fields := getIDLFields(structType)
+	return (len(fields) == 1) && fields[0].IsAttr
+}
+
+// isIDLAttributeOmitted returns true if an attribute is not written, because it has its default value
+func isIDLAttributeOmitted(field idlField, value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String() == ""
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int:
+		return field.OmitEmpty && (value.Int() == 0)
+	case reflect.Ptr:
+		return value.IsNil()
+	}
+	return false
+}
+
+func idlLocationError(location SourceLocation, format string, a ...interface{}) error {
+	return ComponentDiagnostic{Severity: eDiagnosticSeverityError, FileName: location.FileName, Line: location.Line, Column: location.Column,
+		Message: fmt.Sprintf(format, a...)}
+}
+
+// decodeIDLNode assigns the content of a node of a JSON or YAML document to a component definition structure
+func decodeIDLNode(node *idlNode, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.String, reflect.Int, reflect.Bool:
+		if node.Kind != eIDLNodeScalar {
+			return idlLocationError(node.Location, "expected a value")
+		}
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString(node.Value)
+		case reflect.Int:
+			number, err := strconv.Atoi(node.Value)
+			if err != nil {
+				return idlLocationError(node.Location, "invalid integer \"%s\"", node.Value)
+			}
+			value.SetInt(int64(number))
+		case reflect.Bool:
+			boolean, err := strconv.ParseBool(node.Value)
+			if err != nil {
+				return idlLocationError(node.Location, "invalid boolean \"%s\"", node.Value)
+			}
+			value.SetBool(boolean)
+		}
+		return nil
+
+	case reflect.Ptr:
+		// optional attributes that may be empty, like the default of a parameter
+		element := reflect.New(value.Type().Elem())
+		err := decodeIDLNode(node, element.Elem())
+		if err != nil {
+			return err
+		}
+		value.Set(element)
+		return nil
+
+	case reflect.Slice:
+		if node.Kind != eIDLNodeSequence {
+			return idlLocationError(node.Location, "expected a list")
+		}
+		value.Set(reflect.MakeSlice(value.Type(), len(node.Items), len(node.Items)))
+		for i, item := range node.Items {
+			err := decodeIDLNode(item, value.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Struct:
+		location := value.FieldByName("Location")
+		if location.IsValid() {
+			location.Set(reflect.ValueOf(node.Location))
+		}
+		fields := getIDLFields(value.Type())
+		if isIDLListType(value.Type()) || isIDLValueType(value.Type()) {
+			return decodeIDLNode(node, value.FieldByIndex(fields[0].Index))
+		}
+		if node.Kind != eIDLNodeMapping {
+			return idlLocationError(node.Location, "expected a mapping")
+		}
+		for k, key := range node.Keys {
+			found := false
+			for _, field := range fields {
+				if field.Key == key {
+					err := decodeIDLNode(node.Values[k], value.FieldByIndex(field.Index))
+					if err != nil {
+						return err
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				return idlLocationError(node.KeyLocations[k], "unknown key \"%s\"", key)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported type \"%s\" in component definition", value.Type())
+}
+
+// encodeIDLNode converts a component definition structure into the node of a JSON or YAML document
+func encodeIDLNode(value reflect.Value) *idlNode {
+	switch value.Kind() {
+	case reflect.String:
+		return &idlNode{Kind: eIDLNodeScalar, Value: value.String(), IsString: true}
+	case reflect.Int:
+		return &idlNode{Kind: eIDLNodeScalar, Value: strconv.FormatInt(value.Int(), 10)}
+	case reflect.Bool:
+		return &idlNode{Kind: eIDLNodeScalar, Value: strconv.FormatBool(value.Bool())}
+	case reflect.Ptr:
+		if value.IsNil() {
+			return &idlNode{Kind: eIDLNodeScalar}
+		}
+		return encodeIDLNode(value.Elem())
+
+	case reflect.Slice:
+		node := &idlNode{Kind: eIDLNodeSequence}
+		for i := 0; i < value.Len(); i++ {
+			node.Items = append(node.Items, encodeIDLNode(value.Index(i)))
+		}
+		return node
+
+	case reflect.Struct:
+		fields := getIDLFields(value.Type())
+		if isIDLListType(value.Type()) || isIDLValueType(value.Type()) {
+			return encodeIDLNode(value.FieldByIndex(fields[0].Index))
+		}
+		node := &idlNode{Kind: eIDLNodeMapping}
+		for _, field := range fields {
+			fieldValue := value.FieldByIndex(field.Index)
+			if field.IsAttr && isIDLAttributeOmitted(field, fieldValue) {
+				continue
+			}
+			child := encodeIDLNode(fieldValue)
+			if !child.isEmpty() {
+				node.add(field.Key, SourceLocation{}, child)
+			}
+		}
+		return node
+	}
+	return &idlNode{Kind: eIDLNodeScalar}
+}
+
+// jsonSourceLocation returns the position of an offset in a JSON document
+func jsonSourceLocation(fileName string, data []byte, offset int64) SourceLocation {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	column := int(offset) - bytes.LastIndexByte(data[:offset], '\n')
+	return SourceLocation{FileName: fileName, Line: line, Column: column}
+}
+
+// jsonTokenLocation returns the position of the JSON token that follows an offset
+func jsonTokenLocation(fileName string, data []byte, offset int64) SourceLocation {
+	for (offset < int64(len(data))) && strings.ContainsRune(" \t\r\n,:", rune(data[offset])) {
+		offset++
+	}
+	return jsonSourceLocation(fileName, data, offset)
+}
+
+func readJSONIDLNode(decoder *json.Decoder, fileName string, data []byte) (*idlNode, error) {
+	location := jsonTokenLocation(fileName, data, decoder.InputOffset())
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &idlNode{Kind: eIDLNodeScalar, Location: location}
+	switch value := token.(type) {
+	case json.Delim:
+		switch value {
+		case '{':
+			node.Kind = eIDLNodeMapping
+			for decoder.More() {
+				keyLocation := jsonTokenLocation(fileName, data, decoder.InputOffset())
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := keyToken.(string)
+				for _, existingKey := range node.Keys {
+					if existingKey == key {
+						return nil, idlLocationError(keyLocation, "duplicate key \"%s\"", key)
+					}
+				}
+				child, err := readJSONIDLNode(decoder, fileName, data)
+				if err != nil {
+					return nil, err
+				}
+				node.add(key, keyLocation, child)
+			}
+		case '[':
+			node.Kind = eIDLNodeSequence
+			for decoder.More() {
+				child, err := readJSONIDLNode(decoder, fileName, data)
+				if err != nil {
+					return nil, err
+				}
+				node.Items = append(node.Items, child)
+			}
+		}
+		_, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+	case string:
+		node.Value = value
+		node.IsString = true
+	case json.Number:
+		node.Value = value.String()
+	case bool:
+		node.Value = strconv.FormatBool(value)
+	}
+	return node, nil
+}
+
+// readJSONIDLTree reads a JSON document and records the position of every value
+func readJSONIDLTree(fileName string, data []byte) (*idlNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := readJSONIDLNode(decoder, fileName, data)
+	if err == nil {
+		_, err = decoder.Token()
+		if err == io.EOF {
+			return node, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected content after the end of the document")
+		}
+	}
+	if _, ok := err.(ComponentDiagnostic); ok {
+		return nil, err
+	}
+	if syntaxError, ok := err.(*json.SyntaxError); ok {
+		location := jsonSourceLocation(fileName, data, syntaxError.Offset-1)
+		return nil, idlLocationError(location, "%s", syntaxError.Error())
+	}
+	return nil, idlLocationError(jsonTokenLocation(fileName, data, decoder.InputOffset()), "%s", err.Error())
+}
+
+func convertYAMLIDLNode(fileName string, yamlNode *yaml.Node) (*idlNode, error) {
+	location := SourceLocation{FileName: fileName, Line: yamlNode.Line, Column: yamlNode.Column}
+	node := &idlNode{Kind: eIDLNodeScalar, Location: location}
+	switch yamlNode.Kind {
+	case yaml.DocumentNode:
+		if len(yamlNode.Content) == 0 {
+			return nil, idlLocationError(location, "empty document")
+		}
+		return convertYAMLIDLNode(fileName, yamlNode.Content[0])
+	case yaml.AliasNode:
+		return convertYAMLIDLNode(fileName, yamlNode.Alias)
+	case yaml.MappingNode:
+		node.Kind = eIDLNodeMapping
+		for i := 0; i+1 < len(yamlNode.Content); i += 2 {
+			key := yamlNode.Content[i].Value
+			keyLocation := SourceLocation{FileName: fileName, Line: yamlNode.Content[i].Line, Column: yamlNode.Content[i].Column}
+			for _, existingKey := range node.Keys {
+				if existingKey == key {
+					return nil, idlLocationError(keyLocation, "duplicate key \"%s\"", key)
+				}
+			}
+			child, err := convertYAMLIDLNode(fileName, yamlNode.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			node.add(key, keyLocation, child)
+		}
+	case yaml.SequenceNode:
+		node.Kind = eIDLNodeSequence
+		for _, item := range yamlNode.Content {
+			child, err := convertYAMLIDLNode(fileName, item)
+			if err != nil {
+				return nil, err
+			}
+			node.Items = append(node.Items, child)
+		}
+	case yaml.ScalarNode:
+		if yamlNode.Tag != "!!null" {
+			node.Value = yamlNode.Value
+		}
+		node.IsString = yamlNode.Tag == "!!str"
+	}
+	return node, nil
+}
+
+// readYAMLIDLTree reads a YAML document and records the position of every value
+func readYAMLIDLTree(fileName string, data []byte) (*idlNode, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		diagnostic := ComponentDiagnostic{Severity: eDiagnosticSeverityError, FileName: fileName, Message: err.Error()}
+		match := regexp.MustCompile(`^yaml: line (\d+): (.*)$`).FindStringSubmatch(err.Error())
+		if match != nil {
+			diagnostic.Line, _ = strconv.Atoi(match[1])
+			diagnostic.Message = match[2]
+		}
+		return nil, diagnostic
+	}
+	return convertYAMLIDLNode(fileName, &document)
+}
+
+// readComponentDefinitionDocument reads a component definition from a JSON or YAML document
+func readComponentDefinitionDocument(fileName string, data []byte, format string, component *ComponentDefinition) error {
+	var node *idlNode
+	var err error
+	if format == eIDLFormatJSON {
+		node, err = readJSONIDLTree(fileName, data)
+	} else {
+		node, err = readYAMLIDLTree(fileName, data)
+	}
+	if err != nil {
+		return err
+	}
+	return decodeIDLNode(node, reflect.ValueOf(component).Elem())
+}
+
+func writeJSONIDLNode(w io.Writer, node *idlNode, indent string) error {
+	switch node.Kind {
+	case eIDLNodeMapping, eIDLNodeSequence:
+		open, close := "{", "}"
+		count := len(node.Keys)
+		if node.Kind == eIDLNodeSequence {
+			open, close = "[", "]"
+			count = len(node.Items)
+		}
+		if count == 0 {
+			fmt.Fprint(w, open+close)
+			return nil
+		}
+		fmt.Fprintln(w, open)
+		for i := 0; i < count; i++ {
+			fmt.Fprint(w, indent+"  ")
+			child := node.Items
+			if node.Kind == eIDLNodeMapping {
+				key, err := json.Marshal(node.Keys[i])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "%s: ", key)
+				child = node.Values
+			}
+			err := writeJSONIDLNode(w, child[i], indent+"  ")
+			if err != nil {
+				return err
+			}
+			if i+1 < count {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintln(w, "")
+		}
+		fmt.Fprint(w, indent+close)
+
+	default:
+		if !node.IsString {
+			fmt.Fprint(w, node.Value)
+			return nil
+		}
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(node.Value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, strings.TrimSuffix(buffer.String(), "\n"))
+	}
+	return nil
+}
+
+func convertIDLNodeToYAML(node *idlNode) *yaml.Node {
+	switch node.Kind {
+	case eIDLNodeMapping:
+		yamlNode := &yaml.Node{Kind: yaml.MappingNode}
+		for i, key := range node.Keys {
+			yamlNode.Content = append(yamlNode.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, convertIDLNodeToYAML(node.Values[i]))
+		}
+		return yamlNode
+	case eIDLNodeSequence:
+		yamlNode := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range node.Items {
+			yamlNode.Content = append(yamlNode.Content, convertIDLNodeToYAML(item))
+		}
+		return yamlNode
+	}
+	tag := "!!str"
+	if !node.IsString {
+		tag = ""
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: node.Value}
+}
+
+// writeXMLIDLElement writes a component definition structure as XML element.
+// Elements without children are written as empty-element tags.
+func writeXMLIDLElement(w io.Writer, indent string, name string, value reflect.Value, attributes string) error {
+	fields := getIDLFields(value.Type())
+	for _, field := range fields {
+		fieldValue := value.FieldByIndex(field.Index)
+		if field.IsAttr && !isIDLAttributeOmitted(field, fieldValue) {
+			var escaped bytes.Buffer
+			err := xml.EscapeText(&escaped, []byte(encodeIDLNode(fieldValue).Value))
+			if err != nil {
+				return err
+			}
+			attributes += fmt.Sprintf(" %s=\"%s\"", field.XMLName, escaped.String())
+		}
+	}
+
+	var children bytes.Buffer
+	for _, field := range fields {
+		fieldValue := value.FieldByIndex(field.Index)
+		if field.IsAttr {
+			continue
+		}
+		if fieldValue.Kind() == reflect.Slice {
+			for i := 0; i < fieldValue.Len(); i++ {
+				err := writeXMLIDLElement(&children, indent+"\t", field.XMLName, fieldValue.Index(i), "")
+				if err != nil {
+					return err
+				}
+			}
+		} else if !encodeIDLNode(fieldValue).isEmpty() {
+			err := writeXMLIDLElement(&children, indent+"\t", field.XMLName, fieldValue, "")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if children.Len() == 0 {
+		_, err := fmt.Fprintf(w, "%s<%s%s />\n", indent, name, attributes)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s<%s%s>\n%s%s</%s>\n", indent, name, attributes, children.String(), indent, name)
+	return err
+}
+
+// WriteComponentDefinition writes a component definition as XML, JSON or YAML document.
+// Imported components are not written, only the references to them.
+func WriteComponentDefinition(component ComponentDefinition, format string, w io.Writer) error {
+	switch format {
+	case eIDLFormatXML:
+		fmt.Fprintln(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>")
+		return writeXMLIDLElement(w, "", "component", reflect.ValueOf(component), fmt.Sprintf(" xmlns=\"%s\"", actIDLXMLNameSpace))
+
+	case eIDLFormatJSON:
+		err := writeJSONIDLNode(w, encodeIDLNode(reflect.ValueOf(component)), "")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, "")
+		return err
+
+	case eIDLFormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		err := encoder.Encode(convertIDLNodeToYAML(encodeIDLNode(reflect.ValueOf(component))))
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return fmt.Errorf("unknown IDL format \"%s\"", format)
+}
@@ -0,0 +1,359 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// componentvalidation.go
+// contains the types to collect and report the findings of the validation of a component definition
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	eDiagnosticSeverityError   = "error"
+	eDiagnosticSeverityWarning = "warning"
+)
+
+// SourceLocation is the position of an element within the IDL file it has been read from
+type SourceLocation struct {
+	FileName string `xml:"-" json:"-"`
+	Line     int    `xml:"-" json:"-"`
+	Column   int    `xml:"-" json:"-"`
+}
+
+// String formats a source location as "file:line:column"
+func (location SourceLocation) String() string {
+	if location.Line == 0 {
+		return location.FileName
+	}
+	return fmt.Sprintf("%s:%d:%d", location.FileName, location.Line, location.Column)
+}
+
+// ComponentDiagnostic is a single error or warning found in a component definition
+type ComponentDiagnostic struct {
+	Severity string `json:"severity"`
+	FileName string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+}
+
+// Error formats the diagnostic like a compiler message, such that editors can locate it
+func (diagnostic ComponentDiagnostic) Error() string {
+	location := SourceLocation{FileName: diagnostic.FileName, Line: diagnostic.Line, Column: diagnostic.Column}
+	return fmt.Sprintf("%s: %s: %s", location.String(), diagnostic.Severity, diagnostic.Message)
+}
+
+// ComponentValidation collects all diagnostics of the validation of a component definition and its imported components
+type ComponentValidation struct {
+	Diagnostics []ComponentDiagnostic
+	fallback    SourceLocation
+}
+
+func (validation *ComponentValidation) add(severity string, location SourceLocation, format string, a ...interface{}) {
+	if location.Line == 0 {
+		location = validation.fallback
+	}
+	var diagnostic ComponentDiagnostic
+	diagnostic.Severity = severity
+	diagnostic.FileName = location.FileName
+	diagnostic.Line = location.Line
+	diagnostic.Column = location.Column
+	diagnostic.Message = fmt.Sprintf(format, a...)
+	validation.Diagnostics = append(validation.Diagnostics, diagnostic)
+}
+
+func (validation *ComponentValidation) addError(location SourceLocation, format string, a ...interface{}) {
+	validation.add(eDiagnosticSeverityError, location, format, a...)
+}
+
+func (validation *ComponentValidation) addWarning(location SourceLocation, format string, a ...interface{}) {
+	validation.add(eDiagnosticSeverityWarning, location, format, a...)
+}
+
+// ErrorCount returns the number of errors found
+func (validation *ComponentValidation) ErrorCount() int {
+	count := 0
+	for _, diagnostic := range validation.Diagnostics {
+		if diagnostic.Severity == eDiagnosticSeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// WarningCount returns the number of warnings found
+func (validation *ComponentValidation) WarningCount() int {
+	return len(validation.Diagnostics) - validation.ErrorCount()
+}
+
+// Err returns nil if no errors have been found, and an error listing all errors otherwise
+func (validation *ComponentValidation) Err() error {
+	messages := make([]string, 0)
+	for _, diagnostic := range validation.Diagnostics {
+		if diagnostic.Severity == eDiagnosticSeverityError {
+			messages = append(messages, diagnostic.Error())
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
+
+// WriteText writes one line per diagnostic and a summary line
+func (validation *ComponentValidation) WriteText(w io.Writer) {
+	for _, diagnostic := range validation.Diagnostics {
+		fmt.Fprintln(w, diagnostic.Error())
+	}
+	fmt.Fprintf(w, "%d error(s), %d warning(s)\n", validation.ErrorCount(), validation.WarningCount())
+}
+
+// WriteJSON writes all diagnostics as a JSON document
+func (validation *ComponentValidation) WriteJSON(w io.Writer) error {
+	type jsonReport struct {
+		Valid       bool                  `json:"valid"`
+		Errors      int                   `json:"errors"`
+		Warnings    int                   `json:"warnings"`
+		Diagnostics []ComponentDiagnostic `json:"diagnostics"`
+	}
+	report := jsonReport{
+		Valid:       validation.ErrorCount() == 0,
+		Errors:      validation.ErrorCount(),
+		Warnings:    validation.WarningCount(),
+		Diagnostics: validation.Diagnostics,
+	}
+	if report.Diagnostics == nil {
+		report.Diagnostics = make([]ComponentDiagnostic, 0)
+	}
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// xmlElementNode is an element of an XML document together with its position in the document
+type xmlElementNode struct {
+	Name     string
+	Location SourceLocation
+	Children []*xmlElementNode
+}
+
+// childrenNamed returns all child elements of a node with a given name, in document order
+func (node *xmlElementNode) childrenNamed(name string) []*xmlElementNode {
+	children := make([]*xmlElementNode, 0)
+	if node == nil {
+		return children
+	}
+	for _, child := range node.Children {
+		if child.Name == name {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// firstChildNamed returns the first child element of a node with a given name or nil
+func (node *xmlElementNode) firstChildNamed(name string) *xmlElementNode {
+	children := node.childrenNamed(name)
+	if len(children) == 0 {
+		return nil
+	}
+	return children[0]
+}
+
+func (node *xmlElementNode) location() SourceLocation {
+	if node == nil {
+		return SourceLocation{}
+	}
+	return node.Location
+}
+
+// readXMLElementTree reads the element structure of an XML document and records the position of every element
+func readXMLElementTree(fileName string, data []byte) (*xmlElementNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlElementNode{}
+	stack := []*xmlElementNode{root}
+	for {
+		// Tokens are contiguous, so the position before reading a token is the start of that token
+		line, column := decoder.InputPos()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			node := &xmlElementNode{
+				Name:     element.Name.Local,
+				Location: SourceLocation{FileName: fileName, Line: line, Column: column},
+			}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return root.firstChildNamed("component"), nil
+}
+
+func setMethodSourceLocations(methods []ComponentDefinitionMethod, node *xmlElementNode) {
+	for i, methodNode := range node.childrenNamed("method") {
+		if i >= len(methods) {
+			break
+		}
+		methods[i].Location = methodNode.location()
+		setParamSourceLocations(methods[i].Params, methodNode)
+	}
+}
+
+func setParamSourceLocations(params []ComponentDefinitionParam, node *xmlElementNode) {
+	for i, paramNode := range node.childrenNamed("param") {
+		if i >= len(params) {
+			break
+		}
+		params[i].Location = paramNode.location()
+	}
+}
+
+// setSourceLocations assigns the positions of the elements of an IDL file to the component definition read from it
+func (component *ComponentDefinition) setSourceLocations(fileName string, root *xmlElementNode) {
+	component.Location = SourceLocation{FileName: fileName}
+	if root == nil {
+		return
+	}
+	component.Location = root.location()
+
+	license := root.firstChildNamed("license")
+	component.License.Location = license.location()
+	for i, lineNode := range license.childrenNamed("line") {
+		if i < len(component.License.Lines) {
+			component.License.Lines[i].Location = lineNode.location()
+		}
+	}
+
+	bindings := root.firstChildNamed("bindings")
+	component.BindingList.Location = bindings.location()
+	for i, bindingNode := range bindings.childrenNamed("binding") {
+		if i < len(component.BindingList.Bindings) {
+			component.BindingList.Bindings[i].Location = bindingNode.location()
+		}
+	}
+
+	implementations := root.firstChildNamed("implementations")
+	component.ImplementationList.Location = implementations.location()
+	for i, implementationNode := range implementations.childrenNamed("implementation") {
+		if i < len(component.ImplementationList.Implementations) {
+			component.ImplementationList.Implementations[i].Location = implementationNode.location()
+		}
+	}
+
+	errors := root.firstChildNamed("errors")
+	component.Errors.Location = errors.location()
+	for i, errorNode := range errors.childrenNamed("error") {
+		if i < len(component.Errors.Errors) {
+			component.Errors.Errors[i].Location = errorNode.location()
+		}
+	}
+
+	for i, constantNode := range root.childrenNamed("constant") {
+		if i < len(component.Constants) {
+			component.Constants[i].Location = constantNode.location()
+		}
+	}
+
+	for i, importNode := range root.childrenNamed("importcomponent") {
+		if i < len(component.ImportComponents) {
+			component.ImportComponents[i].Location = importNode.location()
+		}
+	}
+
+	for i, enumNode := range root.childrenNamed("enum") {
+		if i >= len(component.Enums) {
+			break
+		}
+		component.Enums[i].Location = enumNode.location()
+		for j, optionNode := range enumNode.childrenNamed("option") {
+			if j < len(component.Enums[i].Options) {
+				component.Enums[i].Options[j].Location = optionNode.location()
+			}
+		}
+	}
+
+	for i, structNode := range root.childrenNamed("struct") {
+		if i >= len(component.Structs) {
+			break
+		}
+		component.Structs[i].Location = structNode.location()
+		for j, memberNode := range structNode.childrenNamed("member") {
+			if j < len(component.Structs[i].Members) {
+				component.Structs[i].Members[j].Location = memberNode.location()
+			}
+		}
+	}
+
+	for i, functionNode := range root.childrenNamed("functiontype") {
+		if i >= len(component.Functions) {
+			break
+		}
+		component.Functions[i].Location = functionNode.location()
+		setParamSourceLocations(component.Functions[i].Params, functionNode)
+	}
+
+	for i, classNode := range root.childrenNamed("class") {
+		if i >= len(component.Classes) {
+			break
+		}
+		component.Classes[i].Location = classNode.location()
+		setMethodSourceLocations(component.Classes[i].Methods, classNode)
+		for j, propertyNode := range classNode.childrenNamed("property") {
+			if j < len(component.Classes[i].Properties) {
+				component.Classes[i].Properties[j].Location = propertyNode.location()
+			}
+		}
+	}
+
+	global := root.firstChildNamed("global")
+	component.Global.Location = global.location()
+	if global != nil {
+		setMethodSourceLocations(component.Global.Methods, global)
+	}
+}
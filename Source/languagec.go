@@ -36,7 +36,9 @@ package main
 import (
 	"fmt"
 	"log"
+	"math"
 	"path"
+	"strconv"
 	"strings"
 )
 
@@ -137,7 +139,11 @@ func buildSharedCCPPTypesHeader(component ComponentDefinition, w LanguageWriter,
 	w.Writeln("#define %s_SUCCESS 0", strings.ToUpper(NameSpace))
 	for i := 0; i < len(component.Errors.Errors); i++ {
 		errorcode := component.Errors.Errors[i]
-		w.Writeln("#define %s_ERROR_%s %d", strings.ToUpper(NameSpace), errorcode.Name, errorcode.Code)
+		deprecatedTag := ""
+		if errorcode.IsDeprecated() {
+			deprecatedTag = " @deprecated " + errorcode.DeprecationMessage(errorcode.Name)
+		}
+		w.Writeln("#define %s_ERROR_%s %d /**< %s%s */", strings.ToUpper(NameSpace), errorcode.Name, errorcode.Code, errorcode.Description, deprecatedTag)
 	}
 
 	w.Writeln("")
@@ -197,6 +203,10 @@ func buildCCPPTypesHeader(component ComponentDefinition, w LanguageWriter, NameS
 		w.AddIndentationLevel(1)
 	}
 
+	err = buildCCPPConstants(component, w, NameSpace, useCPPTypes)
+	if err != nil {
+		return err
+	}
 	err = buildCCPPEnums(component, w, NameSpace, useCPPTypes)
 	if err != nil {
 		return err
@@ -378,14 +388,16 @@ func WriteCCPPAbiMethod(method ComponentDefinitionMethod, w LanguageWriter, Name
 	} else {
 		CMethodName = fmt.Sprintf("%s_%s_%s", strings.ToLower(NameSpace), strings.ToLower(ClassName), strings.ToLower(method.MethodName))
 		CCallbackName = fmt.Sprintf("P%s%s_%sPtr", NameSpace, ClassName, method.MethodName)
-		parameters = fmt.Sprintf("%s_%s p%s", NameSpace, ClassName, ClassName)
+		if !method.IsStatic {
+			parameters = fmt.Sprintf("%s_%s p%s", NameSpace, ClassName, ClassName)
+		}
 	}
 
 	w.Writeln("")
 	w.Writeln("/**")
 	w.Writeln("* %s", method.MethodDescription)
 	w.Writeln("*")
-	if !isGlobal {
+	if !isGlobal && !method.IsStatic {
 		w.Writeln("* @param[in] p%s - %s instance.", ClassName, ClassName)
 	}
 
@@ -416,6 +428,84 @@ func WriteCCPPAbiMethod(method ComponentDefinitionMethod, w LanguageWriter, Name
 	return nil
 }
 
+// getCConstantName returns the name of the macro that defines a constant in C
+func getCConstantName(NameSpace string, constant ComponentDefinitionConstant) string {
+	return strings.ToUpper(NameSpace) + "_" + strings.ToUpper(constant.Name)
+}
+
+// getCConstantValue returns the C literal of the value of a constant.
+// Negative numbers are parenthesized, as macros are expanded textually.
+func getCConstantValue(constant ComponentDefinitionConstant) string {
+	if strings.HasPrefix(constant.Value, "-") && (constant.Type != "string") {
+		return "(" + getCConstantLiteral(constant) + ")"
+	}
+	return getCConstantLiteral(constant)
+}
+
+// getCConstantLiteral returns the C literal of the value of a constant.
+// C has no negative literals, so the minimum of int32 and int64 is written as the negated maximum minus one,
+// as its magnitude does not fit into the signed type.
+func getCConstantLiteral(constant ComponentDefinitionConstant) string {
+	value, err := strconv.ParseInt(constant.Value, 10, 64)
+	isInteger := (err == nil)
+	switch constant.Type {
+	case "uint8", "uint16", "uint32":
+		return constant.Value + "U"
+	case "uint64":
+		return constant.Value + "ULL"
+	case "int32":
+		if isInteger && (value == math.MinInt32) {
+			return fmt.Sprintf("%d - 1", math.MinInt32+1)
+		}
+	case "int64":
+		if isInteger && (value == math.MinInt64) {
+			return fmt.Sprintf("%dLL - 1", math.MinInt64+1)
+		}
+		return constant.Value + "LL"
+	case "single":
+		return getFloatingPointLiteral(constant.Value, constant.Type) + "f"
+	case "double":
+		return getFloatingPointLiteral(constant.Value, constant.Type)
+	case "string":
+		return strconv.Quote(constant.Value)
+	}
+	return constant.Value
+}
+
+func buildCCPPConstants(component ComponentDefinition, w LanguageWriter, NameSpace string, useCPPTypes bool) error {
+	if len(component.Constants) == 0 {
+		return nil
+	}
+
+	w.Writeln("/*************************************************************************************************************************")
+	w.Writeln(" Declaration of constants")
+	w.Writeln("**************************************************************************************************************************/")
+	w.Writeln("")
+
+	for _, constant := range component.Constants {
+		comment := ""
+		if constant.Description != "" {
+			comment = " /**< " + constant.Description + " */"
+		}
+		if !useCPPTypes {
+			w.Writeln("#define %s %s%s", getCConstantName(NameSpace, constant), getCConstantValue(constant), comment)
+			continue
+		}
+		typeName := "const char *"
+		if constant.Type != "string" {
+			var err error
+			typeName, err = getCParameterTypeName(constant.Type, NameSpace, "")
+			if err != nil {
+				return err
+			}
+		}
+		w.Writeln("constexpr %s %s = %s;%s", typeName, constant.Name, getCConstantValue(constant), comment)
+	}
+	w.Writeln("")
+
+	return nil
+}
+
 func buildCCPPStructs(component ComponentDefinition, w LanguageWriter, NameSpace string, useCPPTypes bool) error {
 	if len(component.Structs) == 0 {
 		return nil
@@ -495,7 +585,7 @@ func buildCCPPEnums(component ComponentDefinition, w LanguageWriter, NameSpace s
 			}
 			option := enum.Options[j]
 			if useCPPTypes {
-				w.Writeln("  %s = %d%s", option.Name, option.Value, comma)
+				w.Writeln("  %s %s= %d%s", option.Name, getCppDeprecatedAttribute(option.ComponentDefinitionDeprecation, enum.Name+"."+option.Name), option.Value, comma)
 			} else {
 				w.Writeln("  e%s%s = %d%s", enum.Name, option.Name, option.Value, comma)
 			}
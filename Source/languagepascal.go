@@ -77,10 +77,27 @@ func writePascalBaseTypeDefinitions(componentdefinition ComponentDefinition, w L
 
 	for i := 0; i < len(componentdefinition.Errors.Errors); i++ {
 		errorcode := componentdefinition.Errors.Errors[i]
-		w.Writeln("  %s_ERROR_%s = %d;", strings.ToUpper(NameSpace), errorcode.Name, errorcode.Code)
+		if errorcode.IsDeprecated() {
+			w.Writeln("  %s_ERROR_%s = %d; // %s", strings.ToUpper(NameSpace), errorcode.Name, errorcode.Code, errorcode.DeprecationMessage(errorcode.Name))
+		} else {
+			w.Writeln("  %s_ERROR_%s = %d;", strings.ToUpper(NameSpace), errorcode.Name, errorcode.Code)
+		}
 	}
 	w.Writeln("")
 
+	if len(componentdefinition.Constants) > 0 {
+		w.Writeln("(*************************************************************************************************************************")
+		w.Writeln(" Declaration of constants")
+		w.Writeln("**************************************************************************************************************************)")
+		w.Writeln("")
+		w.Writeln("const")
+		for _, constant := range componentdefinition.Constants {
+			writePascalDocComment(w, "  ", []string{constant.Description})
+			w.Writeln("  %s = %s;", getCConstantName(NameSpace, constant), getPascalConstantValue(constant))
+		}
+		w.Writeln("")
+	}
+
 	if len(componentdefinition.Enums) > 0 {
 		w.Writeln("(*************************************************************************************************************************")
 		w.Writeln(" Declaration of enums")
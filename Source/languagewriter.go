@@ -36,7 +36,6 @@ package main
 import (
 	"fmt"
 	"io"
-	"os"
 	"strings"
 )
 
@@ -185,7 +184,7 @@ func CreateLanguageFile (fileName string, indentString string) (LanguageWriter,
 	
 	result.IndentString = indentString
 	result.Indentation = 0
-	result.Writer, err = os.Create(fileName)
+	result.Writer, err = CreateOutputFile(fileName)
 	if err != nil {
 		return result, err;
 	}
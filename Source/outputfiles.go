@@ -0,0 +1,350 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// outputfiles.go
+// creation of generated files and folders, either on disk or in memory for the verification of existing output
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// outputManifestName is the name of the file in the folder of a component that lists the files of its
+// Bindings-, Implementations- and Documentation-folders that have been generated by ACT
+const outputManifestName = "act_manifest.txt"
+
+// GeneratedFiles holds the content of generated files in memory
+type GeneratedFiles struct {
+	files map[string]*bytes.Buffer
+	kept  map[string]bool
+}
+
+// generatedFilesInMemory receives all generated files instead of the file system, if it is set
+var generatedFilesInMemory *GeneratedFiles
+
+// writtenOutputFiles records the files that are written to disk or left in place by the generation, if it is set
+var writtenOutputFiles map[string]bool
+
+// recordOutputFile adds a file that is written to disk or left in place to writtenOutputFiles
+func recordOutputFile(fileName string) {
+	if writtenOutputFiles != nil {
+		writtenOutputFiles[filepath.Clean(fileName)] = true
+	}
+}
+
+func newGeneratedFiles() *GeneratedFiles {
+	return &GeneratedFiles{files: make(map[string]*bytes.Buffer, 0), kept: make(map[string]bool, 0)}
+}
+
+// create returns an empty buffer for a file, replacing previous content just like os.Create
+func (generated *GeneratedFiles) create(fileName string) *bytes.Buffer {
+	buffer := new(bytes.Buffer)
+	generated.files[filepath.Clean(fileName)] = buffer
+	return buffer
+}
+
+func (generated *GeneratedFiles) exists(fileName string) bool {
+	_, ok := generated.files[filepath.Clean(fileName)]
+	return ok
+}
+
+// keep records an existing file that the generation leaves in place, like an implementation stub
+func (generated *GeneratedFiles) keep(fileName string) {
+	generated.kept[filepath.Clean(fileName)] = true
+}
+
+// isOutput returns true if a file is either generated or left in place by the generation
+func (generated *GeneratedFiles) isOutput(fileName string) bool {
+	return generated.exists(fileName) || generated.kept[filepath.Clean(fileName)]
+}
+
+// FileNames returns the names of all generated files in sorted order
+func (generated *GeneratedFiles) FileNames() []string {
+	fileNames := make([]string, 0, len(generated.files))
+	for fileName := range generated.files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	return fileNames
+}
+
+// Content returns the generated content of a file
+func (generated *GeneratedFiles) Content(fileName string) []byte {
+	buffer, ok := generated.files[filepath.Clean(fileName)]
+	if !ok {
+		return nil
+	}
+	return buffer.Bytes()
+}
+
+// CreateOutputFile creates a file for generated source code
+func CreateOutputFile(fileName string) (io.Writer, error) {
+	if generatedFilesInMemory != nil {
+		return generatedFilesInMemory.create(fileName), nil
+	}
+	recordOutputFile(fileName)
+	return os.Create(fileName)
+}
+
+// CreateOutputFolder creates a folder for generated source code
+func CreateOutputFolder(folderName string) error {
+	if generatedFilesInMemory != nil {
+		return nil
+	}
+	return os.MkdirAll(folderName, os.ModePerm)
+}
+
+// isVerifiedOutputFile returns true if a generated file is part of the verified output of a component,
+// i.e. the Bindings-, Implementations- and Documentation-folders.
+func isVerifiedOutputFile(outfolderBase string, fileName string) bool {
+	relativeName, err := filepath.Rel(outfolderBase, fileName)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(relativeName), "/")
+	if len(parts) < 3 {
+		return false
+	}
+	return (parts[1] == "Bindings") || (parts[1] == "Implementations") || (parts[1] == "Documentation")
+}
+
+// getComponentFolder returns the folder of the component a file in outfolderBase belongs to
+func getComponentFolder(outfolderBase string, fileName string) string {
+	relativeName, _ := filepath.Rel(outfolderBase, fileName)
+	return filepath.Join(outfolderBase, strings.Split(filepath.ToSlash(relativeName), "/")[0])
+}
+
+// readOutputManifest returns the files that are listed in the manifest of a component folder. A missing
+// manifest, e.g. of output that has been generated by an earlier version of ACT, lists no files.
+func readOutputManifest(componentFolder string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(componentFolder, outputManifestName))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fileNames := make([]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if (line == "") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fileName := filepath.Join(componentFolder, filepath.FromSlash(line))
+		if getComponentFolder(filepath.Dir(componentFolder), fileName) != componentFolder {
+			continue
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	return fileNames, nil
+}
+
+// writeOutputManifests writes the manifest of every component folder that files have been written to.
+// Files of an earlier manifest that still exist remain listed, so that --verify keeps reporting them
+// until they are deleted.
+func writeOutputManifests(fileNames map[string]bool, outfolderBase string) error {
+	componentFiles := make(map[string]map[string]bool, 0)
+	for fileName := range fileNames {
+		if !isVerifiedOutputFile(outfolderBase, fileName) {
+			continue
+		}
+		componentFolder := getComponentFolder(outfolderBase, fileName)
+		if componentFiles[componentFolder] == nil {
+			componentFiles[componentFolder] = make(map[string]bool, 0)
+		}
+		componentFiles[componentFolder][fileName] = true
+	}
+
+	for componentFolder, files := range componentFiles {
+		previousFileNames, err := readOutputManifest(componentFolder)
+		if err != nil {
+			return err
+		}
+		for _, fileName := range previousFileNames {
+			info, err := os.Stat(fileName)
+			if (err == nil) && info.Mode().IsRegular() && isVerifiedOutputFile(outfolderBase, fileName) {
+				files[fileName] = true
+			}
+		}
+
+		lines := make([]string, 0, len(files))
+		for fileName := range files {
+			relativeName, _ := filepath.Rel(componentFolder, fileName)
+			lines = append(lines, filepath.ToSlash(relativeName))
+		}
+		sort.Strings(lines)
+		content := "# Files generated by ACT. act generate --verify reports the listed files that are not generated anymore.\n" +
+			strings.Join(lines, "\n") + "\n"
+		err = ioutil.WriteFile(filepath.Join(componentFolder, outputManifestName), []byte(content), 0666)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeComponent generates a component on disk and records its generated files in the manifests of the
+// component folders
+func writeComponent(component ComponentDefinition, outfolderBase string) error {
+	writtenOutputFiles = make(map[string]bool, 0)
+	fileNames := writtenOutputFiles
+	err := createComponent(component, outfolderBase)
+	writtenOutputFiles = nil
+	if err != nil {
+		return err
+	}
+	return writeOutputManifests(fileNames, outfolderBase)
+}
+
+// getStaleOutputFiles returns the existing files of earlier generations that are not generated anymore,
+// e.g. the files of a removed class or binding. Only files in the manifests of the component folders are
+// considered, so that build output and other files next to the generated code are not reported.
+func getStaleOutputFiles(generated *GeneratedFiles, outfolderBase string) ([]string, error) {
+	staleFiles := make([]string, 0)
+	componentFolders := make(map[string]bool, 0)
+	for _, fileName := range generated.FileNames() {
+		if !isVerifiedOutputFile(outfolderBase, fileName) {
+			continue
+		}
+		componentFolder := getComponentFolder(outfolderBase, fileName)
+		if componentFolders[componentFolder] {
+			continue
+		}
+		componentFolders[componentFolder] = true
+
+		fileNames, err := readOutputManifest(componentFolder)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileName := range fileNames {
+			if !isVerifiedOutputFile(outfolderBase, fileName) || generated.isOutput(fileName) {
+				continue
+			}
+			info, err := os.Stat(fileName)
+			if (err == nil) && info.Mode().IsRegular() {
+				staleFiles = append(staleFiles, fileName)
+			}
+		}
+	}
+	sort.Strings(staleFiles)
+	return staleFiles, nil
+}
+
+// isBinaryContent returns true if the content of a file is not text, which is never written as a diff
+func isBinaryContent(content []byte) bool {
+	return (bytes.IndexByte(content, 0) >= 0) || !utf8.Valid(content)
+}
+
+// writeOutputDifference writes the difference of a file as a unified diff, or names the file if
+// either of its contents is binary
+func writeOutputDifference(w io.Writer, oldName string, newName string, oldContent []byte, newContent []byte) {
+	if isBinaryContent(oldContent) || isBinaryContent(newContent) {
+		fmt.Fprintf(w, "Binary files %s and %s differ\n", oldName, newName)
+		return
+	}
+	fmt.Fprint(w, UnifiedDiff(oldName, newName, string(oldContent), string(newContent), 3))
+}
+
+// getOutputDisplayName returns the name of an output file relative to the output folder
+func getOutputDisplayName(outfolderBase string, fileName string) string {
+	displayName, err := filepath.Rel(outfolderBase, fileName)
+	if err != nil {
+		displayName = fileName
+	}
+	return filepath.ToSlash(displayName)
+}
+
+// verifyComponent generates a component in memory and writes a unified diff of every generated file
+// that differs from the existing output to w. Existing files that would not be generated anymore are
+// written as deletions. It returns the number of differing files.
+func verifyComponent(component ComponentDefinition, outfolderBase string, w io.Writer) (int, error) {
+	generatedFilesInMemory = newGeneratedFiles()
+	generated := generatedFilesInMemory
+	// Nothing is written, so the messages about created files are left out
+	logOutput := log.Writer()
+	log.SetOutput(ioutil.Discard)
+	err := createComponent(component, outfolderBase)
+	log.SetOutput(logOutput)
+	generatedFilesInMemory = nil
+	if err != nil {
+		return 0, err
+	}
+
+	differences := 0
+	for _, fileName := range generated.FileNames() {
+		if !isVerifiedOutputFile(outfolderBase, fileName) {
+			continue
+		}
+		newContent := generated.Content(fileName)
+		oldName := fileName
+		oldContent, err := ioutil.ReadFile(fileName)
+		if os.IsNotExist(err) {
+			oldName = os.DevNull
+			oldContent = []byte{}
+		} else if err != nil {
+			return differences, err
+		}
+		if bytes.Equal(oldContent, newContent) {
+			continue
+		}
+
+		differences++
+		displayName := getOutputDisplayName(outfolderBase, fileName)
+		oldDisplayName := "a/" + displayName
+		if oldName == os.DevNull {
+			oldDisplayName = os.DevNull
+		}
+		writeOutputDifference(w, oldDisplayName, "b/"+displayName, oldContent, newContent)
+	}
+
+	staleFiles, err := getStaleOutputFiles(generated, outfolderBase)
+	if err != nil {
+		return differences, err
+	}
+	for _, fileName := range staleFiles {
+		oldContent, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return differences, err
+		}
+		differences++
+		writeOutputDifference(w, "a/"+getOutputDisplayName(outfolderBase, fileName), os.DevNull, oldContent, []byte{})
+	}
+	return differences, nil
+}
@@ -0,0 +1,218 @@
+/*++
+
+Copyright (C) 2018 Autodesk Inc. (Original Author)
+
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without modification,
+are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice, this
+list of conditions and the following disclaimer.
+2. Redistributions in binary form must reproduce the above copyright notice,
+this list of conditions and the following disclaimer in the documentation
+and/or other materials provided with the distribution.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR
+ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+--*/
+
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+// stubupdate.go
+// update of existing implementation stubs, which keeps the hand-written code in protected regions
+//////////////////////////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+// protectedRegionBegin and protectedRegionEnd enclose hand-written code in implementation stubs
+const protectedRegionBegin = "// ACT protected region begin: "
+const protectedRegionEnd = "// ACT protected region end: "
+
+// updateImplementationStubs makes ACT merge existing implementation stubs with the current component
+// definition instead of leaving them untouched
+var updateImplementationStubs bool
+
+// StubFile is an implementation stub. If the stub already exists and stubs are updated, the new stub is
+// collected in memory and merged with the protected regions of the existing stub on Close.
+type StubFile struct {
+	LanguageWriter
+	FileName string
+	language string
+	buffer   *bytes.Buffer
+}
+
+// CreateStubFile creates an implementation stub for the language "Cpp" or "Pascal"
+func CreateStubFile(fileName string, indentString string, language string) (StubFile, error) {
+	stub := StubFile{FileName: fileName, language: language}
+	if !FileExists(fileName) {
+		log.Printf("Creating \"%s\"", fileName)
+		w, err := CreateLanguageFile(fileName, indentString)
+		stub.LanguageWriter = w
+		return stub, err
+	}
+
+	log.Printf("Updating \"%s\"", fileName)
+	stub.buffer = new(bytes.Buffer)
+	stub.LanguageWriter = LanguageWriter{IndentString: indentString, Writer: stub.buffer}
+	return stub, nil
+}
+
+// Close writes an updated stub. The content of every protected region of the existing stub replaces the
+// region of the same name in the new stub. Regions that are not generated anymore are disabled and kept
+// at the end of the file.
+func (stub *StubFile) Close() error {
+	if stub.buffer == nil {
+		return nil
+	}
+	existing, err := ioutil.ReadFile(stub.FileName)
+	if err != nil {
+		return err
+	}
+	regions, order, err := parseProtectedRegions(string(existing), stub.FileName)
+	if err != nil {
+		return err
+	}
+	if len(order) == 0 {
+		log.Printf("Omitting update of \"%s\": it does not contain protected regions", stub.FileName)
+		return nil
+	}
+
+	merged, removed := mergeProtectedRegions(stub.buffer.String(), regions, order, stub.language)
+	for _, name := range removed {
+		log.Printf("Protected region \"%s\" of \"%s\" is not generated anymore and has been disabled", name, stub.FileName)
+	}
+
+	w, err := CreateOutputFile(stub.FileName)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(merged))
+	return err
+}
+
+// writeProtectedRegion writes a protected region with its default content
+func writeProtectedRegion(w LanguageWriter, indent string, name string, lines []string) {
+	w.Writeln(indent+"%s%s", protectedRegionBegin, name)
+	w.Writelns(indent, lines)
+	w.Writeln(indent+"%s%s", protectedRegionEnd, name)
+}
+
+// getProtectedRegionMarker returns the name of the region a line begins or ends, if it is a marker
+func getProtectedRegionMarker(line string) (string, bool, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, protectedRegionBegin) {
+		return strings.TrimSpace(trimmed[len(protectedRegionBegin):]), true, false
+	}
+	if strings.HasPrefix(trimmed, protectedRegionEnd) {
+		return strings.TrimSpace(trimmed[len(protectedRegionEnd):]), false, true
+	}
+	return "", false, false
+}
+
+// parseProtectedRegions returns the lines inside every protected region of a file, and the names of the
+// regions in the order of their appearance
+func parseProtectedRegions(content string, fileName string) (map[string][]string, []string, error) {
+	regions := make(map[string][]string)
+	order := []string{}
+	currentRegion := ""
+	var currentLines []string
+	for i, line := range splitLines(content) {
+		name, isBegin, isEnd := getProtectedRegionMarker(line)
+		if isBegin {
+			if currentRegion != "" {
+				return nil, nil, fmt.Errorf("%s:%d: protected region \"%s\" begins inside of region \"%s\"", fileName, i+1, name, currentRegion)
+			}
+			if _, ok := regions[name]; ok {
+				return nil, nil, fmt.Errorf("%s:%d: duplicate protected region \"%s\"", fileName, i+1, name)
+			}
+			currentRegion = name
+			currentLines = []string{}
+		} else if isEnd {
+			if name != currentRegion {
+				return nil, nil, fmt.Errorf("%s:%d: unexpected end of protected region \"%s\"", fileName, i+1, name)
+			}
+			regions[name] = currentLines
+			order = append(order, name)
+			currentRegion = ""
+		} else if currentRegion != "" {
+			currentLines = append(currentLines, line)
+		}
+	}
+	if currentRegion != "" {
+		return nil, nil, fmt.Errorf("%s: protected region \"%s\" is not closed", fileName, currentRegion)
+	}
+	return regions, order, nil
+}
+
+// mergeProtectedRegions replaces the content of the protected regions of a generated stub by the content
+// of existing regions. It returns the merged stub and the names of the existing regions that do not occur
+// in the generated stub, which are appended in a disabled form.
+func mergeProtectedRegions(generated string, regions map[string][]string, order []string, language string) (string, []string) {
+	var merged strings.Builder
+	used := make(map[string]bool)
+	skipping := false
+	for _, line := range splitLines(generated) {
+		name, isBegin, isEnd := getProtectedRegionMarker(line)
+		if isEnd {
+			skipping = false
+		}
+		if skipping {
+			continue
+		}
+		merged.WriteString(line)
+		if isBegin {
+			if lines, ok := regions[name]; ok {
+				for _, existingLine := range lines {
+					merged.WriteString(existingLine)
+				}
+				used[name] = true
+				skipping = true
+			}
+		}
+	}
+
+	removed := []string{}
+	for _, name := range order {
+		if used[name] {
+			continue
+		}
+		removed = append(removed, name)
+		merged.WriteString("\n")
+		if language == "Pascal" {
+			// The compiler ignores everything after the final "end." of a unit
+			merged.WriteString(fmt.Sprintf("// The protected region \"%s\" is not generated anymore, e.g. because its method has been removed.\n", name))
+		} else {
+			merged.WriteString("#if 0\n")
+			merged.WriteString(fmt.Sprintf("// The protected region \"%s\" is not generated anymore, e.g. because its method has been removed.\n", name))
+		}
+		merged.WriteString(protectedRegionBegin + name + "\n")
+		for _, existingLine := range regions[name] {
+			merged.WriteString(existingLine)
+		}
+		if len(regions[name]) > 0 && !strings.HasSuffix(regions[name][len(regions[name])-1], "\n") {
+			merged.WriteString("\n")
+		}
+		merged.WriteString(protectedRegionEnd + name + "\n")
+		if language != "Pascal" {
+			merged.WriteString("#endif\n")
+		}
+	}
+	return merged.String(), removed
+}